@@ -20,6 +20,7 @@ func NewContainerCmd(flags *flags.GlobalFlags) *cobra.Command {
 	containerCmd.AddCommand(NewVSCodeWebAsyncCmd())
 	containerCmd.AddCommand(NewCodeServerAsyncCmd())
 	containerCmd.AddCommand(NewCredentialsServerCmd(flags))
+	containerCmd.AddCommand(NewRefreshCredentialsCmd(flags))
 	containerCmd.AddCommand(NewSetupLoftPlatformAccessCmd(flags))
 	containerCmd.AddCommand(NewSSHServerCmd(flags))
 	return containerCmd
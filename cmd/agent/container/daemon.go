@@ -18,6 +18,7 @@ import (
 	config2 "github.com/skevetter/devpod/pkg/config"
 	agentd "github.com/skevetter/devpod/pkg/daemon/agent"
 	"github.com/skevetter/devpod/pkg/platform/client"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/devpod/pkg/ts"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
@@ -250,6 +251,14 @@ func runSshServer(ctx context.Context, cmd *DaemonCmd) error {
 	if cmd.Config.Ssh.User != "" {
 		args = append(args, "--remote-user", cmd.Config.Ssh.User)
 	}
+	algorithms, err := helperssh.ParseAlgorithms(
+		cmd.Config.Ssh.Ciphers,
+		cmd.Config.Ssh.KeyExchanges,
+	)
+	if err != nil {
+		return err
+	}
+	args = append(args, algorithms.Args()...)
 
 	sshCmd := exec.CommandContext(ctx, binaryPath, args...) // #nosec G204
 	sshCmd.Stdout = os.Stdout
@@ -0,0 +1,252 @@
+package container
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/cli/cli/config"
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent/tunnel"
+	"github.com/skevetter/devpod/pkg/agent/tunnelserver"
+	pkgconfig "github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/credentials"
+	"github.com/skevetter/devpod/pkg/dockercredentials"
+	"github.com/skevetter/devpod/pkg/gitcredentials"
+	"github.com/skevetter/devpod/pkg/util"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// RefreshCredentialsCmd holds the cmd flags.
+type RefreshCredentialsCmd struct {
+	*flags.GlobalFlags
+
+	Repository string
+
+	ConfigureGitHelper    bool
+	ConfigureDockerHelper bool
+}
+
+// NewRefreshCredentialsCmd creates a new command.
+func NewRefreshCredentialsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RefreshCredentialsCmd{
+		GlobalFlags: flags,
+	}
+	refreshCredentialsCmd := &cobra.Command{
+		Use:   "refresh-credentials",
+		Short: "Writes the current git and docker credentials of the host into the container",
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return cmd.Run(c.Context())
+		},
+	}
+	refreshCredentialsCmd.Flags().
+		StringVar(&cmd.Repository, "repository", "",
+			"The git repository to refresh the credentials of")
+	refreshCredentialsCmd.Flags().
+		BoolVar(&cmd.ConfigureGitHelper, "configure-git-helper", false,
+			"If true will refresh the git credentials")
+	refreshCredentialsCmd.Flags().
+		BoolVar(&cmd.ConfigureDockerHelper, "configure-docker-helper", false,
+			"If true will refresh the docker credentials")
+	return refreshCredentialsCmd
+}
+
+// Run fetches the credentials once through a credentials server that is only running for
+// the duration of the command and stores them for the current user.
+func (cmd *RefreshCredentialsCmd) Run(ctx context.Context) error {
+	tunnelClient, err := tunnelserver.NewTunnelClient(os.Stdin, os.Stdout, true, ExitCodeIO)
+	if err != nil {
+		return fmt.Errorf("error creating tunnel client: %w", err)
+	}
+
+	_, err = tunnelClient.Ping(ctx, &tunnel.Empty{})
+	if err != nil {
+		return fmt.Errorf("ping client: %w", err)
+	}
+	log := tunnelserver.NewTunnelLogger(ctx, tunnelClient, cmd.Debug)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	port, err := credentials.StartCredentialsServer(ctx, tunnelClient, log)
+	if err != nil {
+		return err
+	}
+
+	if cmd.ConfigureDockerHelper {
+		err = refreshDockerCredentials(port, log)
+		if err != nil {
+			return fmt.Errorf("refresh docker credentials: %w", err)
+		}
+	}
+
+	if cmd.ConfigureGitHelper && cmd.Repository != "" {
+		err = refreshGitCredentials(ctx, cmd.Repository, port, log)
+		if err != nil {
+			return fmt.Errorf("refresh git credentials: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// refreshDockerCredentials resolves the docker credentials of the host with the credential
+// helper of the machine setup and hands them to the credential helpers configured for the
+// user.
+func refreshDockerCredentials(port int, log log.Logger) error {
+	home, err := util.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	userConfig, err := config.Load(
+		cmp.Or(os.Getenv("DOCKER_CONFIG"), filepath.Join(home, ".docker")),
+	)
+	if err != nil {
+		return err
+	}
+
+	dockerConfigDir, err := dockercredentials.ConfigureCredentialsMachine(
+		os.TempDir(),
+		port,
+		log,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(dockerConfigDir) }()
+
+	forwardedConfig, err := config.Load(dockerConfigDir)
+	if err != nil {
+		return err
+	}
+	auths, err := forwardedConfig.GetAllCredentials()
+	if err != nil {
+		return err
+	}
+
+	stored, err := storeDockerAuths(userConfig, auths)
+	if err != nil {
+		return err
+	} else if stored == 0 {
+		log.Warnf("no docker credential helper besides DevPod's is configured in the workspace, " +
+			"the credentials are only available while connected with devpod ssh")
+		return nil
+	}
+
+	log.Infof("Refreshed docker credentials of %d registries", stored)
+	return nil
+}
+
+// storeDockerAuths hands the auths to the credential helpers configured in the docker
+// config of the user and returns how many were stored. Registries without a helper, or
+// whose helper is the one of DevPod, are skipped, the auths are never written into the
+// config file itself.
+func storeDockerAuths(
+	configFile *configfile.ConfigFile,
+	auths map[string]types.AuthConfig,
+) (int, error) {
+	stored := 0
+	for registry, auth := range auths {
+		if auth.Username == "" && auth.Password == "" && auth.IdentityToken == "" {
+			continue
+		} else if dockerCredentialHelper(configFile, registry) == "" {
+			continue
+		}
+
+		auth.ServerAddress = registry
+		err := configFile.GetCredentialsStore(registry).Store(auth)
+		if err != nil {
+			return stored, fmt.Errorf("store docker credentials of %s: %w", registry, err)
+		}
+		stored++
+	}
+
+	return stored, nil
+}
+
+// dockerCredentialHelper returns the credential helper the docker config uses for the
+// registry, or an empty string if there is none besides the one of DevPod.
+func dockerCredentialHelper(configFile *configfile.ConfigFile, registry string) string {
+	helper := cmp.Or(configFile.CredentialHelpers[registry], configFile.CredentialsStore)
+	if helper == pkgconfig.BinaryName {
+		return ""
+	}
+
+	return helper
+}
+
+// refreshGitCredentials fills the git credentials of the repository host with the git
+// credential helper that is injected on up and hands them to the credential helpers
+// configured in the container.
+func refreshGitCredentials(ctx context.Context, repository string, port int, log log.Logger) error {
+	request, ok := gitCredentialsRequest(repository)
+	if !ok {
+		log.Debugf("skip refreshing git credentials of %s, not a http repository", repository)
+		return nil
+	}
+
+	binaryPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	helper := gitcredentials.HelperCommand(binaryPath, port)
+	filled, err := runGitCredential(
+		ctx,
+		gitcredentials.ToString(request),
+		"-c", "credential.helper=",
+		"-c", "credential.helper="+helper,
+		"credential", "fill",
+	)
+	if err != nil {
+		return err
+	}
+
+	configuredHelpers, _ := runGitCredential(ctx, "", "config", "--get-all", "credential.helper")
+	if strings.TrimSpace(configuredHelpers) == "" {
+		log.Warnf("no git credential helper is configured in the workspace, " +
+			"the credentials are only available while connected with devpod ssh")
+		return nil
+	}
+
+	_, err = runGitCredential(ctx, filled, "credential", "approve")
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Refreshed git credentials of %s", request.Host)
+	return nil
+}
+
+// gitCredentialsRequest returns the git credential request for the host of the repository.
+func gitCredentialsRequest(repository string) (*gitcredentials.GitCredentials, bool) {
+	parsed, err := url.Parse(repository)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, false
+	}
+
+	return &gitcredentials.GitCredentials{Protocol: parsed.Scheme, Host: parsed.Host}, true
+}
+
+func runGitCredential(ctx context.Context, stdin string, args ...string) (string, error) {
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	gitCmd := exec.CommandContext(ctx, "git", args...)
+	gitCmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	gitCmd.Stdin = strings.NewReader(stdin)
+	gitCmd.Stdout = stdout
+	gitCmd.Stderr = stderr
+	err := gitCmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[len(args)-1], err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
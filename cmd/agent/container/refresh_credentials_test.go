@@ -0,0 +1,48 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/docker/cli/cli/config/configfile"
+	"github.com/docker/cli/cli/config/types"
+	pkgconfig "github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerCredentialHelper(t *testing.T) {
+	configFile := &configfile.ConfigFile{
+		CredentialsStore:  pkgconfig.BinaryName,
+		CredentialHelpers: map[string]string{"gcr.io": "gcloud"},
+	}
+	assert.Empty(t, dockerCredentialHelper(configFile, "ghcr.io"))
+	assert.Equal(t, "gcloud", dockerCredentialHelper(configFile, "gcr.io"))
+
+	configFile = &configfile.ConfigFile{CredentialsStore: "pass"}
+	assert.Equal(t, "pass", dockerCredentialHelper(configFile, "ghcr.io"))
+
+	assert.Empty(t, dockerCredentialHelper(&configfile.ConfigFile{}, "ghcr.io"))
+}
+
+func TestStoreDockerAuthsSkipsPlaintext(t *testing.T) {
+	configFile := &configfile.ConfigFile{CredentialsStore: pkgconfig.BinaryName}
+
+	stored, err := storeDockerAuths(configFile, map[string]types.AuthConfig{
+		"ghcr.io": {Username: "user", Password: "renewed"},
+	})
+	require.NoError(t, err)
+
+	assert.Zero(t, stored)
+	assert.Equal(t, pkgconfig.BinaryName, configFile.CredentialsStore)
+	assert.Empty(t, configFile.AuthConfigs)
+}
+
+func TestGitCredentialsRequest(t *testing.T) {
+	request, ok := gitCredentialsRequest("https://github.com/skevetter/devpod.git")
+	require.True(t, ok)
+	assert.Equal(t, "https", request.Protocol)
+	assert.Equal(t, "github.com", request.Host)
+
+	_, ok = gitCredentialsRequest("git@github.com:skevetter/devpod.git")
+	assert.False(t, ok)
+}
@@ -52,6 +52,7 @@ type SetupContainerCmd struct {
 	ChownWorkspace         bool
 	StreamMounts           bool
 	InjectGitCredentials   bool
+	SkipLifecycleCommands  bool
 	ContainerWorkspaceInfo string
 	SetupInfo              string
 	AccessKey              string
@@ -79,6 +80,9 @@ func NewSetupContainerCmd(flags *flags.GlobalFlags) *cobra.Command {
 	setupContainerCmd.Flags().
 		BoolVar(&cmd.InjectGitCredentials, "inject-git-credentials", false,
 			"If DevPod should inject git credentials during setup")
+	setupContainerCmd.Flags().
+		BoolVar(&cmd.SkipLifecycleCommands, "skip-lifecycle-commands", false,
+			"If DevPod should skip the lifecycle commands up to postStartCommand")
 	setupContainerCmd.Flags().
 		StringVar(&cmd.ContainerWorkspaceInfo, "container-workspace-info", "", "The container workspace info")
 	setupContainerCmd.Flags().
@@ -195,10 +199,14 @@ func (cmd *SetupContainerCmd) finalizeSetup(sctx *setupContext) error {
 	cfg := &setup.ContainerSetupConfig{
 		SetupInfo:         sctx.setupInfo,
 		ExtraWorkspaceEnv: sctx.workspaceInfo.CLIOptions.WorkspaceEnv,
+		LifecycleEnv:      sctx.workspaceInfo.CLIOptions.DevContainerEnv,
 		ChownProjects:     cmd.ChownWorkspace,
 		PlatformOptions:   &sctx.workspaceInfo.CLIOptions.Platform,
+		RegistryMirror:    sctx.workspaceInfo.CLIOptions.RegistryMirror,
 		TunnelClient:      sctx.tunnelClient,
 		Log:               sctx.logger,
+
+		SkipLifecycleCommands: cmd.SkipLifecycleCommands,
 	}
 
 	if err := setup.SetupContainerPreAttach(sctx.ctx, cfg); err != nil {
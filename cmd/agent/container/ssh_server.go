@@ -21,9 +21,11 @@ var BaseLogDir = agent.ContainerDataDir
 type SSHServerCmd struct {
 	*flags.GlobalFlags
 
-	Address    string
-	Workdir    string
-	RemoteUser string
+	Address      string
+	Workdir      string
+	RemoteUser   string
+	Ciphers      string
+	KeyExchanges string
 }
 
 // NewSSHServerCmd creates a new ssh command.
@@ -44,13 +46,22 @@ func NewSSHServerCmd(flags *flags.GlobalFlags) *cobra.Command {
 		StringVar(&cmd.RemoteUser, "remote-user", "", "The remote user for this workspace")
 	sshCmd.Flags().
 		StringVar(&cmd.Workdir, "workdir", "", "Directory where commands will run on the host")
+	sshCmd.Flags().
+		StringVar(&cmd.Ciphers, "ciphers", "", "Comma separated list of ciphers the server allows")
+	sshCmd.Flags().StringVar(&cmd.KeyExchanges, "key-exchanges", "",
+		"Comma separated list of key exchange algorithms the server allows")
 	return sshCmd
 }
 
 // Run runs the command logic.
 func (cmd *SSHServerCmd) Run(_ *cobra.Command, _ []string) error {
 	logger := getFileLogger(cmd.RemoteUser, cmd.Debug)
-	server, err := helperssh.NewContainerServer(cmd.Address, cmd.Workdir, logger)
+	algorithms, err := helperssh.ParseAlgorithms(cmd.Ciphers, cmd.KeyExchanges)
+	if err != nil {
+		return err
+	}
+
+	server, err := helperssh.NewContainerServer(cmd.Address, cmd.Workdir, algorithms, logger)
 	if err != nil {
 		return err
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/encoding"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
 )
@@ -26,6 +27,8 @@ type ContainerTunnelCmd struct {
 
 	WorkspaceInfo string
 	User          string
+	Ciphers       string
+	KeyExchanges  string
 }
 
 // NewContainerTunnelCmd creates a new command.
@@ -46,12 +49,22 @@ func NewContainerTunnelCmd(flags *flags.GlobalFlags) *cobra.Command {
 		StringVar(&cmd.User, "user", "", "The user to create the tunnel with")
 	containerTunnelCmd.Flags().
 		StringVar(&cmd.WorkspaceInfo, "workspace-info", "", "The workspace info")
+	containerTunnelCmd.Flags().
+		StringVar(&cmd.Ciphers, "ciphers", "", "Comma separated list of ciphers the server allows")
+	containerTunnelCmd.Flags().
+		StringVar(&cmd.KeyExchanges, "key-exchanges", "",
+			"Comma separated list of key exchange algorithms the server allows")
 	_ = containerTunnelCmd.MarkFlagRequired("workspace-info")
 	return containerTunnelCmd
 }
 
 // Run runs the command logic.
 func (cmd *ContainerTunnelCmd) Run(ctx context.Context, log log.Logger) error {
+	algorithms, err := helperssh.ParseAlgorithms(cmd.Ciphers, cmd.KeyExchanges)
+	if err != nil {
+		return err
+	}
+
 	// write workspace info
 	shouldExit, workspaceInfo, err := agent.WriteWorkspaceInfo(cmd.WorkspaceInfo, log)
 	if err != nil {
@@ -98,6 +111,7 @@ func (cmd *ContainerTunnelCmd) Run(ctx context.Context, log log.Logger) error {
 		os.Stderr,
 		log,
 		workspaceInfo.InjectTimeout,
+		algorithms,
 	)
 	if err != nil {
 		return err
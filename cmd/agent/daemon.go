@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,7 +13,10 @@ import (
 	"github.com/skevetter/devpod/cmd/flags"
 	"github.com/skevetter/devpod/pkg/agent"
 	"github.com/skevetter/devpod/pkg/client/clientimplementation"
+	agentdaemon "github.com/skevetter/devpod/pkg/daemon/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer"
 	"github.com/skevetter/devpod/pkg/driver/custom"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
@@ -149,10 +153,58 @@ func (cmd *DaemonCmd) checkAndShutdown(
 		return
 	}
 
+	// check if there are still processes running in the container
+	if hasActiveProcesses(ctx, workspace, log) {
+		return
+	}
+
 	// run shutdown command
 	cmd.runShutdownCommand(ctx, workspace, log)
 }
 
+func hasActiveProcesses(
+	ctx context.Context,
+	workspace *provider2.AgentWorkspaceInfo,
+	log log.Logger,
+) bool {
+	if workspace.Agent.MinActiveProcesses == "" {
+		return false
+	}
+
+	minActiveProcesses, err := strconv.Atoi(workspace.Agent.MinActiveProcesses)
+	if err != nil {
+		log.Errorf("error parsing min active processes: %v", err)
+		return false
+	} else if minActiveProcesses <= 0 {
+		return false
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(workspace, log)
+	if err != nil {
+		log.Errorf("error creating driver: %v", err)
+		return false
+	}
+
+	activeProcesses, err := agentdaemon.CountActiveProcesses(
+		ctx,
+		workspaceDriver,
+		devcontainer.GetRunnerIDFromWorkspace(workspace.Workspace),
+	)
+	if err != nil {
+		log.Errorf("error counting active processes: %v", err)
+		return false
+	} else if activeProcesses < minActiveProcesses {
+		return false
+	}
+
+	log.Infof(
+		"Workspace %q has %d active processes, will not auto-stop machine",
+		workspace.Workspace.ID,
+		activeProcesses,
+	)
+	return true
+}
+
 func (cmd *DaemonCmd) runShutdownCommand(
 	ctx context.Context,
 	workspace *provider2.AgentWorkspaceInfo,
@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ConfigDiffCmd holds the cmd flags.
+type ConfigDiffCmd struct {
+	*flags.GlobalFlags
+
+	ID string
+}
+
+// NewConfigDiffCmd creates a new command.
+func NewConfigDiffCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ConfigDiffCmd{
+		GlobalFlags: flags,
+	}
+	configDiffCmd := &cobra.Command{
+		Use:   "config-diff",
+		Short: "Prints the merged devcontainer config resolved from the current devcontainer.json",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	configDiffCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	_ = configDiffCmd.MarkFlagRequired("id")
+	return configDiffCmd
+}
+
+func (cmd *ConfigDiffCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	runner, err := CreateRunner(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	mergedConfig, err := runner.ResolveConfig(ctx, workspaceInfo.CLIOptions)
+	if err != nil {
+		return fmt.Errorf("resolve devcontainer config: %w", err)
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(mergedConfig)
+}
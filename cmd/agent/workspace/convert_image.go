@@ -0,0 +1,138 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ConvertImageCmd holds the cmd flags.
+type ConvertImageCmd struct {
+	*flags.GlobalFlags
+
+	ID    string
+	Image string
+}
+
+// NewConvertImageCmd creates a new command.
+func NewConvertImageCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ConvertImageCmd{
+		GlobalFlags: flags,
+	}
+	convertImageCmd := &cobra.Command{
+		Use:   "convert-image",
+		Short: "Commits the workspace container to an image and pushes it",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	convertImageCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	convertImageCmd.Flags().StringVar(&cmd.Image, "image", "", "The image to commit and push")
+	_ = convertImageCmd.MarkFlagRequired("id")
+	_ = convertImageCmd.MarkFlagRequired("image")
+	return convertImageCmd
+}
+
+func (cmd *ConvertImageCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return fmt.Errorf("this command is only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return err
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return err
+	} else if containerDetails == nil {
+		return fmt.Errorf("couldn't find workspace container")
+	}
+
+	logger.Infof("Removing credentials and shell history from container %s", containerDetails.ID)
+	err = dockerHelper.Run(
+		ctx,
+		[]string{"exec", "-u", "root", containerDetails.ID, "sh", "-c", scrubCommand()},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("remove credentials from container: %w", err)
+	}
+
+	logger.Infof("Committing container %s to image %s", containerDetails.ID, cmd.Image)
+	err = dockerHelper.Commit(
+		ctx,
+		containerDetails.ID,
+		cmd.Image,
+		// clear the workspace id so containers of the image are not mistaken for the workspace
+		[]string{"LABEL " + config.DockerIDLabel + "=\"\""},
+	)
+	if err != nil {
+		return fmt.Errorf("commit container: %w", err)
+	}
+
+	logger.Infof("Pushing image %s", cmd.Image)
+	err = dockerDriver.PushDevContainer(ctx, cmd.Image)
+	if err != nil {
+		return fmt.Errorf("push image: %w", err)
+	}
+
+	return nil
+}
+
+// scrubbedFiles are the files below each home directory that hold credentials or shell history
+// and must not end up in a pushed image.
+var scrubbedFiles = []string{
+	".docker/config.json",
+	".git-credentials",
+	".config/git/credentials",
+	".bash_history",
+	".zsh_history",
+	".local/share/fish/fish_history",
+}
+
+// scrubCommand returns the shell command that removes the scrubbed files and the git credential
+// helpers of every user of the container.
+func scrubCommand() string {
+	files := []string{}
+	for _, file := range scrubbedFiles {
+		files = append(files, `"$home/`+file+`"`)
+	}
+
+	return `for home in /root /home/*; do rm -f ` + strings.Join(files, " ") + `; ` +
+		`if [ -f "$home/.gitconfig" ] && command -v git >/dev/null 2>&1; then ` +
+		`git config --file "$home/.gitconfig" --unset-all credential.helper; fi; done; true`
+}
@@ -0,0 +1,16 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrubCommand(t *testing.T) {
+	command := scrubCommand()
+
+	for _, file := range scrubbedFiles {
+		assert.Contains(t, command, `"$home/`+file+`"`)
+	}
+	assert.Contains(t, command, "--unset-all credential.helper")
+}
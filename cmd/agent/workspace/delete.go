@@ -66,6 +66,9 @@ func (cmd *DeleteCmd) Run(ctx context.Context) error {
 		}
 	}
 
+	// cleanup workspace marker
+	removeWorkspaceMarker(workspaceInfo, log.Default)
+
 	// cleanup docker container
 	if cmd.Container {
 		err = removeContainer(ctx, workspaceInfo, log.Default)
@@ -0,0 +1,175 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// diffIgnoredPaths are paths DevPod itself modifies while setting up the container.
+var diffIgnoredPaths = []string{
+	"/etc/passwd",
+	"/etc/group",
+	"/etc/shadow",
+	"/etc/gshadow",
+	"/tmp",
+	"/.devpod",
+	agent.ContainerDataDir,
+	agent.ContainerDevPodHelperLocation,
+}
+
+// DiffCmd holds the cmd flags.
+type DiffCmd struct {
+	*flags.GlobalFlags
+
+	ID     string
+	Filter []string
+}
+
+// NewDiffCmd creates a new command.
+func NewDiffCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DiffCmd{
+		GlobalFlags: flags,
+	}
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Prints the files changed in the workspace container",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	diffCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	diffCmd.Flags().
+		StringSliceVar(&cmd.Filter, "filter", []string{}, "Only print changes of the given kind (C, A or D)")
+	_ = diffCmd.MarkFlagRequired("id")
+	return diffCmd
+}
+
+func (cmd *DiffCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	changes, err := containerDiff(ctx, workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range filterContainerChanges(changes, cmd.Filter) {
+		fmt.Printf("%s %s\n", change.Kind, change.Path)
+	}
+
+	return nil
+}
+
+func containerDiff(
+	ctx context.Context,
+	workspaceInfo *provider2.AgentWorkspaceInfo,
+	log log.Logger,
+) ([]docker.ContainerChange, error) {
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, log)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return nil, fmt.Errorf("workspace diff is only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return nil, err
+	} else if containerDetails == nil {
+		return nil, fmt.Errorf("couldn't find workspace container")
+	}
+
+	return dockerHelper.Diff(ctx, containerDetails.ID)
+}
+
+func findWorkspaceContainer(
+	ctx context.Context,
+	dockerDriver driver.DockerDriver,
+	workspaceInfo *provider2.AgentWorkspaceInfo,
+) (*config.ContainerDetails, error) {
+	runnerID := devcontainer.GetRunnerIDFromWorkspace(workspaceInfo.Workspace)
+	lastConfig := workspaceInfo.LastDevContainerConfig
+	if lastConfig == nil || lastConfig.Config == nil ||
+		len(lastConfig.Config.DockerComposeFile) == 0 {
+		return dockerDriver.FindDevContainer(ctx, runnerID)
+	}
+
+	composeHelper, err := dockerDriver.ComposeHelper()
+	if err != nil {
+		return nil, fmt.Errorf("find docker compose: %w", err)
+	}
+
+	return composeHelper.FindDevContainer(
+		ctx,
+		composeHelper.GetProjectName(runnerID),
+		lastConfig.Config.Service,
+	)
+}
+
+// filterContainerChanges removes DevPod internal paths and keeps only the changes
+// of the given kinds. An empty kinds slice keeps all kinds.
+func filterContainerChanges(
+	changes []docker.ContainerChange,
+	kinds []string,
+) []docker.ContainerChange {
+	filtered := []docker.ContainerChange{}
+	for _, change := range changes {
+		if len(kinds) > 0 && !slices.ContainsFunc(kinds, func(kind string) bool {
+			return strings.EqualFold(kind, change.Kind)
+		}) {
+			continue
+		}
+
+		if isDiffIgnoredPath(change.Path) {
+			continue
+		}
+
+		filtered = append(filtered, change)
+	}
+
+	return filtered
+}
+
+func isDiffIgnoredPath(path string) bool {
+	for _, ignored := range diffIgnoredPaths {
+		if path == ignored || strings.HasPrefix(path, ignored+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/compress"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// DiffFeaturesCmd holds the cmd flags.
+type DiffFeaturesCmd struct {
+	*flags.GlobalFlags
+
+	ID              string
+	DesiredFeatures string
+}
+
+// NewDiffFeaturesCmd creates a new command.
+func NewDiffFeaturesCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DiffFeaturesCmd{
+		GlobalFlags: flags,
+	}
+	diffFeaturesCmd := &cobra.Command{
+		Use:   "diff-features",
+		Short: "Prints the Dockerfile diff between the current and the desired features",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	diffFeaturesCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	_ = diffFeaturesCmd.MarkFlagRequired("id")
+	diffFeaturesCmd.Flags().StringVar(&cmd.DesiredFeatures, "desired-features", "",
+		"The compressed JSON of the desired features")
+	_ = diffFeaturesCmd.MarkFlagRequired("desired-features")
+	return diffFeaturesCmd
+}
+
+func (cmd *DiffFeaturesCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	desiredFeatures, err := decodeFeatures(cmd.DesiredFeatures)
+	if err != nil {
+		return err
+	}
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	runner, err := CreateRunner(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	diff, err := runner.DiffFeatures(ctx, workspaceInfo.CLIOptions, desiredFeatures)
+	if err != nil {
+		return fmt.Errorf("diff features: %w", err)
+	}
+
+	_, err = fmt.Fprint(os.Stdout, diff)
+	return err
+}
+
+func decodeFeatures(compressed string) (map[string]any, error) {
+	decompressed, err := compress.Decompress(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("decompress desired features: %w", err)
+	}
+
+	features := map[string]any{}
+	err = json.Unmarshal([]byte(decompressed), &features)
+	if err != nil {
+		return nil, fmt.Errorf("parse desired features: %w", err)
+	}
+
+	return features, nil
+}
@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/compress"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffTestSuite struct {
+	suite.Suite
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffTestSuite))
+}
+
+func (s *DiffTestSuite) TestFilterContainerChanges() {
+	changes := []docker.ContainerChange{
+		{Kind: "C", Path: "/etc"},
+		{Kind: "C", Path: "/etc/passwd"},
+		{Kind: "A", Path: "/tmp/devpod.activity"},
+		{Kind: "A", Path: "/var/devpod/agent.json"},
+		{Kind: "A", Path: "/usr/local/bin/devpod"},
+		{Kind: "A", Path: "/usr/local/bin/mytool"},
+		{Kind: "D", Path: "/opt/cache"},
+	}
+
+	s.Equal([]docker.ContainerChange{
+		{Kind: "C", Path: "/etc"},
+		{Kind: "A", Path: "/usr/local/bin/mytool"},
+		{Kind: "D", Path: "/opt/cache"},
+	}, filterContainerChanges(changes, nil))
+
+	s.Equal([]docker.ContainerChange{
+		{Kind: "A", Path: "/usr/local/bin/mytool"},
+		{Kind: "D", Path: "/opt/cache"},
+	}, filterContainerChanges(changes, []string{"a", "D"}))
+}
+
+func (s *DiffTestSuite) TestSealViolations() {
+	changes := []docker.ContainerChange{
+		{Kind: "A", Path: "/workspaces"},
+		{Kind: "A", Path: "/workspaces/project/main.go"},
+		{Kind: "A", Path: "/workspacesfoo"},
+		{Kind: "C", Path: "/etc/passwd"},
+		{Kind: "C", Path: "/etc/motd"},
+	}
+
+	s.Equal([]string{"A /workspacesfoo", "C /etc/motd"}, sealViolations(changes, "/workspaces"))
+	s.Equal([]string{
+		"A /workspaces/project/main.go",
+		"A /workspacesfoo",
+		"C /etc/motd",
+	}, sealViolations(changes, "/workspaces/other/"))
+}
+
+func (s *DiffTestSuite) TestDecodeFeatures() {
+	compressed, err := compress.Compress(
+		`{"ghcr.io/devcontainers/features/go:1": {"version": "1.25"}}`,
+	)
+	s.Require().NoError(err)
+
+	features, err := decodeFeatures(compressed)
+	s.Require().NoError(err)
+	s.Equal(map[string]any{
+		"ghcr.io/devcontainers/features/go:1": map[string]any{"version": "1.25"},
+	}, features)
+
+	invalid, err := compress.Compress(`["go"]`)
+	s.Require().NoError(err)
+	_, err = decodeFeatures(invalid)
+	s.Error(err)
+}
@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"context"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// EnvCmd holds the cmd flags.
+type EnvCmd struct {
+	*flags.GlobalFlags
+
+	ID   string
+	User string
+}
+
+// NewEnvCmd creates a new command.
+func NewEnvCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &EnvCmd{
+		GlobalFlags: flags,
+	}
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Prints the environment of the workspace container",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	envCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	envCmd.Flags().StringVar(&cmd.User, "user", "root", "The user to print the environment of")
+	_ = envCmd.MarkFlagRequired("id")
+	return envCmd
+}
+
+func (cmd *EnvCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	return workspaceDriver.CommandDevContainer(
+		ctx,
+		devcontainer.GetRunnerIDFromWorkspace(workspaceInfo.Workspace),
+		cmd.User,
+		"env",
+		nil,
+		os.Stdout,
+		os.Stderr,
+	)
+}
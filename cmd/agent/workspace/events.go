@@ -0,0 +1,72 @@
+package workspace
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// EventsCmd holds the cmd flags.
+type EventsCmd struct {
+	*flags.GlobalFlags
+
+	ID string
+}
+
+// NewEventsCmd creates a new command.
+func NewEventsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &EventsCmd{
+		GlobalFlags: flags,
+	}
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Prints the events the agent recorded during the last up as json lines",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return cmd.Run()
+		},
+	}
+	eventsCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	_ = eventsCmd.MarkFlagRequired("id")
+	return eventsCmd
+}
+
+func (cmd *EventsCmd) Run() error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	events, err := provider2.LoadWorkspaceEvents(
+		filepath.Join(workspaceInfo.Origin, provider2.WorkspaceEventsFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, event := range events {
+		err = encoder.Encode(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// IPCmd holds the cmd flags.
+type IPCmd struct {
+	*flags.GlobalFlags
+
+	ID      string
+	Network string
+}
+
+// NewIPCmd creates a new command.
+func NewIPCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &IPCmd{
+		GlobalFlags: flags,
+	}
+	ipCmd := &cobra.Command{
+		Use:   "ip",
+		Short: "Prints the ip addresses of the workspace containers",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	ipCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	ipCmd.Flags().StringVar(&cmd.Network, "network", "", "The docker network to print the ip addresses of")
+	_ = ipCmd.MarkFlagRequired("id")
+	return ipCmd
+}
+
+func (cmd *IPCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	dockerHelper, containerIDs, err := findWorkspaceContainers(ctx, workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	ips, err := dockerHelper.ContainerIPs(ctx, containerIDs, cmd.Network)
+	if err != nil {
+		return err
+	}
+
+	for _, ip := range ips {
+		fmt.Println(ip)
+	}
+
+	return nil
+}
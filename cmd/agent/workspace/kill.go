@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"context"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// KillCmd holds the cmd flags.
+type KillCmd struct {
+	*flags.GlobalFlags
+
+	ID        string
+	Container bool
+}
+
+// NewKillCmd creates a new command.
+func NewKillCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &KillCmd{
+		GlobalFlags: flags,
+	}
+	killCmd := &cobra.Command{
+		Use:   "kill",
+		Short: "Removes the busy marker of the workspace and optionally kills its containers",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	killCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	killCmd.Flags().BoolVar(&cmd.Container, "container", false, "If true, kill the workspace containers")
+	_ = killCmd.MarkFlagRequired("id")
+	return killCmd
+}
+
+func (cmd *KillCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	agent.DeleteWorkspaceBusyFile(workspaceInfo.Origin)
+	if !cmd.Container {
+		return nil
+	}
+
+	dockerHelper, containerIDs, err := findWorkspaceContainers(ctx, workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	for _, containerID := range containerIDs {
+		logger.Debugf("killing container %s", containerID)
+		err = dockerHelper.Kill(ctx, containerID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/version"
+	"github.com/skevetter/log"
+)
+
+// WorkspaceMarkerFile is written into the container workspace folder so tools can
+// detect that they are running inside a DevPod workspace.
+const WorkspaceMarkerFile = ".devpod-workspace.json"
+
+// WorkspaceMarker is the content of the WorkspaceMarkerFile.
+type WorkspaceMarker struct {
+	WorkspaceID   string `json:"workspaceId"`
+	Provider      string `json:"provider"`
+	Context       string `json:"context"`
+	RemoteUser    string `json:"remoteUser"`
+	DevPodVersion string `json:"devpodVersion"`
+}
+
+// writeWorkspaceMarker writes the marker file into the workspace folder of the running container.
+func writeWorkspaceMarker(
+	ctx context.Context,
+	runner devcontainer.Runner,
+	workspaceInfo *provider.AgentWorkspaceInfo,
+	result *config2.Result,
+) error {
+	if result == nil || result.SubstitutionContext == nil ||
+		result.SubstitutionContext.ContainerWorkspaceFolder == "" {
+		return nil
+	}
+
+	remoteUser := config2.GetRemoteUser(result)
+	marker, err := json.MarshalIndent(&WorkspaceMarker{
+		WorkspaceID:   workspaceInfo.Workspace.ID,
+		Provider:      workspaceInfo.Workspace.Provider.Name,
+		Context:       workspaceInfo.Workspace.Context,
+		RemoteUser:    remoteUser,
+		DevPodVersion: version.GetVersion(),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// the marker file should not show up as untracked file in git repositories
+	folder := result.SubstitutionContext.ContainerWorkspaceFolder
+	markerPath := shellescape.Quote(path.Join(folder, WorkspaceMarkerFile))
+	exclude := shellescape.Quote(path.Join(folder, ".git", "info", "exclude"))
+	command := fmt.Sprintf(
+		"cat > %[1]s && if [ -d %[2]s ]; then grep -qxF %[3]s %[4]s 2>/dev/null || echo %[3]s >> %[4]s; fi",
+		markerPath,
+		shellescape.Quote(path.Join(folder, ".git", "info")),
+		WorkspaceMarkerFile,
+		exclude,
+	)
+
+	stderr := &bytes.Buffer{}
+	err = runner.Command(ctx, remoteUser, command, bytes.NewReader(marker), nil, stderr)
+	if err != nil {
+		return fmt.Errorf("write %s: %s: %w", WorkspaceMarkerFile, stderr.String(), err)
+	}
+
+	return nil
+}
+
+// removeWorkspaceMarker removes the marker file from the workspace content folder on the host,
+// which is where it ends up if the workspace folder is bind mounted into the container.
+func removeWorkspaceMarker(workspaceInfo *provider.AgentWorkspaceInfo, log log.Logger) {
+	if workspaceInfo.ContentFolder == "" {
+		return
+	}
+
+	markerPath := filepath.Join(workspaceInfo.ContentFolder, WorkspaceMarkerFile)
+	err := os.Remove(markerPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Debugf("error removing %s: %v", markerPath, err)
+	}
+}
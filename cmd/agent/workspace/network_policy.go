@@ -0,0 +1,119 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// NetworkPolicyCmd holds the cmd flags.
+type NetworkPolicyCmd struct {
+	*flags.GlobalFlags
+
+	ID    string
+	Rules []string
+	List  bool
+	Clear bool
+}
+
+// NewNetworkPolicyCmd creates a new command.
+func NewNetworkPolicyCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &NetworkPolicyCmd{
+		GlobalFlags: flags,
+	}
+	networkPolicyCmd := &cobra.Command{
+		Use:   "network-policy",
+		Short: "Applies, lists or clears the egress rules of the workspace container",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	networkPolicyCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	networkPolicyCmd.Flags().StringArrayVar(&cmd.Rules, "rule", []string{},
+		"An egress rule in the form allow=CIDR or deny=CIDR, replaces all existing rules")
+	networkPolicyCmd.Flags().BoolVar(&cmd.List, "list", false, "Print the applied rules")
+	networkPolicyCmd.Flags().BoolVar(&cmd.Clear, "clear", false, "Remove all applied rules")
+	_ = networkPolicyCmd.MarkFlagRequired("id")
+	return networkPolicyCmd
+}
+
+func (cmd *NetworkPolicyCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	runner, err := CreateRunner(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case cmd.List:
+		script := devcontainer.ListNetworkPolicyScript()
+		return runner.Command(ctx, "root", script, nil, os.Stdout, os.Stderr)
+	case cmd.Clear:
+		script := devcontainer.ClearNetworkPolicyScript()
+		return runner.Command(ctx, "root", script, nil, os.Stdout, os.Stderr)
+	}
+
+	policies := []provider.NetworkPolicy{}
+	for _, rule := range cmd.Rules {
+		action, cidr, _ := strings.Cut(rule, "=")
+		policy, err := devcontainer.ParseNetworkPolicy(action, cidr)
+		if err != nil {
+			return err
+		}
+		policies = append(policies, policy)
+	}
+
+	return applyNetworkPolicies(ctx, runner, policies)
+}
+
+// applyNetworkPolicies replaces the egress rules of the workspace container as root.
+func applyNetworkPolicies(
+	ctx context.Context,
+	runner devcontainer.Runner,
+	policies []provider.NetworkPolicy,
+) error {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	script, err := devcontainer.ApplyNetworkPolicyScript(policies)
+	if err != nil {
+		return err
+	}
+
+	stderr := &bytes.Buffer{}
+	err = runner.Command(ctx, "root", script, nil, nil, stderr)
+	if err != nil {
+		return fmt.Errorf(
+			"apply network policies, the container needs iptables and NET_ADMIN: %s: %w",
+			strings.TrimSpace(stderr.String()),
+			err,
+		)
+	}
+
+	return nil
+}
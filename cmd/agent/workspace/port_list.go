@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// PortListCmd holds the cmd flags.
+type PortListCmd struct {
+	*flags.GlobalFlags
+
+	ID string
+}
+
+// NewPortListCmd creates a new command.
+func NewPortListCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PortListCmd{
+		GlobalFlags: flags,
+	}
+	portListCmd := &cobra.Command{
+		Use:   "port-list",
+		Short: "Prints the exposed and published ports of the workspace containers as json",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	portListCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	_ = portListCmd.MarkFlagRequired("id")
+	return portListCmd
+}
+
+func (cmd *PortListCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	dockerHelper, containerIDs, err := findWorkspaceContainers(ctx, workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	bindings, err := dockerHelper.PortBindings(ctx, containerIDs)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(bindings)
+}
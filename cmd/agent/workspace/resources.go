@@ -0,0 +1,140 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/compose"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ResourcesCmd holds the cmd flags.
+type ResourcesCmd struct {
+	*flags.GlobalFlags
+
+	ID    string
+	Watch bool
+}
+
+// NewResourcesCmd creates a new command.
+func NewResourcesCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ResourcesCmd{
+		GlobalFlags: flags,
+	}
+	resourcesCmd := &cobra.Command{
+		Use:   "resources",
+		Short: "Prints the resource usage of the workspace containers as json lines",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	resourcesCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	resourcesCmd.Flags().BoolVar(&cmd.Watch, "watch", false, "Print a new sample every second")
+	_ = resourcesCmd.MarkFlagRequired("id")
+	return resourcesCmd
+}
+
+func (cmd *ResourcesCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	dockerHelper, containerIDs, err := findWorkspaceContainers(ctx, workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		stats, err := dockerHelper.Stats(ctx, containerIDs)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(stats)
+		if err != nil {
+			return err
+		}
+		if !cmd.Watch {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// findWorkspaceContainers returns the workspace container or all containers of its compose project.
+func findWorkspaceContainers(
+	ctx context.Context,
+	workspaceInfo *provider2.AgentWorkspaceInfo,
+	log log.Logger,
+) (*docker.DockerHelper, []string, error) {
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return nil, nil, fmt.Errorf("this command is only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lastConfig := workspaceInfo.LastDevContainerConfig
+	if lastConfig != nil && lastConfig.Config != nil && len(lastConfig.Config.DockerComposeFile) > 0 {
+		composeHelper, err := dockerDriver.ComposeHelper()
+		if err != nil {
+			return nil, nil, fmt.Errorf("find docker compose: %w", err)
+		}
+
+		projectName := composeHelper.GetProjectName(devcontainer.GetRunnerIDFromWorkspace(workspaceInfo.Workspace))
+		containerIDs, err := dockerHelper.FindContainer(ctx, []string{compose.ProjectLabel + "=" + projectName})
+		if err != nil {
+			return nil, nil, err
+		} else if len(containerIDs) == 0 {
+			return nil, nil, fmt.Errorf("couldn't find containers of compose project %s", projectName)
+		}
+
+		return dockerHelper, containerIDs, nil
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return nil, nil, err
+	} else if containerDetails == nil {
+		return nil, nil, fmt.Errorf("couldn't find workspace container")
+	}
+
+	return dockerHelper, []string{containerDetails.ID}, nil
+}
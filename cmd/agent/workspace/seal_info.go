@@ -0,0 +1,130 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// defaultSealWorkspaceFolder is the workspace folder of workspaces without a stored result.
+const defaultSealWorkspaceFolder = "/workspaces"
+
+// SealInfoCmd holds the cmd flags.
+type SealInfoCmd struct {
+	*flags.GlobalFlags
+
+	ID              string
+	WorkspaceFolder string
+}
+
+// NewSealInfoCmd creates a new command.
+func NewSealInfoCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SealInfoCmd{
+		GlobalFlags: flags,
+	}
+	sealInfoCmd := &cobra.Command{
+		Use:   "seal-info",
+		Short: "Prints the image digest and the changes outside of the workspace folder",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	sealInfoCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	sealInfoCmd.Flags().StringVar(&cmd.WorkspaceFolder, "workspace-folder",
+		defaultSealWorkspaceFolder, "The workspace folder in the container changes are allowed in")
+	_ = sealInfoCmd.MarkFlagRequired("id")
+	return sealInfoCmd
+}
+
+func (cmd *SealInfoCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	sealInfo, err := containerSealInfo(ctx, workspaceInfo, cmd.WorkspaceFolder, logger)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(sealInfo)
+}
+
+func containerSealInfo(
+	ctx context.Context,
+	workspaceInfo *provider2.AgentWorkspaceInfo,
+	workspaceFolder string,
+	log log.Logger,
+) (*provider2.SealInfo, error) {
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, log)
+	if err != nil {
+		return nil, err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return nil, fmt.Errorf("workspace seal is only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return nil, err
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return nil, err
+	} else if containerDetails == nil {
+		return nil, fmt.Errorf("couldn't find workspace container")
+	}
+
+	changes, err := dockerHelper.Diff(ctx, containerDetails.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provider2.SealInfo{
+		ImageDigest: containerDetails.ImageID,
+		Changes:     sealViolations(changes, workspaceFolder),
+	}, nil
+}
+
+// sealViolations returns the changes outside of the workspace folder that DevPod didn't make
+// itself. The parent folders of the workspace folder change along with it.
+func sealViolations(changes []docker.ContainerChange, workspaceFolder string) []string {
+	workspaceFolder = path.Clean(workspaceFolder)
+	violations := []string{}
+	for _, change := range filterContainerChanges(changes, nil) {
+		if change.Path == workspaceFolder || strings.HasPrefix(change.Path, workspaceFolder+"/") ||
+			strings.HasPrefix(workspaceFolder, strings.TrimSuffix(change.Path, "/")+"/") {
+			continue
+		}
+
+		violations = append(violations, change.Kind+" "+change.Path)
+	}
+
+	return violations
+}
@@ -0,0 +1,106 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetResourceLimitsCmd holds the cmd flags.
+type SetResourceLimitsCmd struct {
+	*flags.GlobalFlags
+
+	ID     string
+	CPUs   string
+	Memory string
+}
+
+// NewSetResourceLimitsCmd creates a new command.
+func NewSetResourceLimitsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetResourceLimitsCmd{
+		GlobalFlags: flags,
+	}
+	setResourceLimitsCmd := &cobra.Command{
+		Use:   "set-resource-limits",
+		Short: "Updates the cpu and memory limits of the running workspace container",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	setResourceLimitsCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	setResourceLimitsCmd.Flags().StringVar(&cmd.CPUs, "cpus", "", "The cpu limit, e.g. 1.5")
+	setResourceLimitsCmd.Flags().StringVar(&cmd.Memory, "memory", "", "The memory limit, e.g. 4g")
+	_ = setResourceLimitsCmd.MarkFlagRequired("id")
+	return setResourceLimitsCmd
+}
+
+func (cmd *SetResourceLimitsCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	return applyResourceLimits(ctx, workspaceInfo, &provider2.ResourceLimits{
+		CPUs:   cmd.CPUs,
+		Memory: cmd.Memory,
+	}, logger)
+}
+
+// applyResourceLimits updates the limits of the workspace container with docker update.
+func applyResourceLimits(
+	ctx context.Context,
+	workspaceInfo *provider2.AgentWorkspaceInfo,
+	limits *provider2.ResourceLimits,
+	log log.Logger,
+) error {
+	if limits == nil || (limits.CPUs == "" && limits.Memory == "") {
+		return nil
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, log)
+	if err != nil {
+		return err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return fmt.Errorf("resource limits are only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return err
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return err
+	} else if containerDetails == nil {
+		return fmt.Errorf("couldn't find the workspace container")
+	}
+
+	err = dockerHelper.UpdateResources(ctx, containerDetails.ID, limits.CPUs, limits.Memory)
+	if err != nil {
+		return fmt.Errorf("update resource limits: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// TopCmd holds the cmd flags.
+type TopCmd struct {
+	*flags.GlobalFlags
+
+	ID       string
+	Interval time.Duration
+}
+
+// NewTopCmd creates a new command.
+func NewTopCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TopCmd{
+		GlobalFlags: flags,
+	}
+	topCmd := &cobra.Command{
+		Use:   "top",
+		Short: "Prints the processes of the workspace container as json lines",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	topCmd.Flags().StringVar(&cmd.ID, "id", "", "The workspace id")
+	topCmd.Flags().DurationVar(&cmd.Interval, "interval", 0,
+		"Print a new sample after the interval, if zero only a single sample is printed")
+	_ = topCmd.MarkFlagRequired("id")
+	return topCmd
+}
+
+func (cmd *TopCmd) Run(ctx context.Context) error {
+	logger := log.Default.ErrorStreamOnly()
+
+	// get workspace info
+	shouldExit, workspaceInfo, err := agent.ReadAgentWorkspaceInfo(
+		cmd.AgentDir,
+		cmd.Context,
+		cmd.ID,
+		logger,
+	)
+	if err != nil {
+		return err
+	} else if shouldExit {
+		return nil
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(workspaceInfo, logger)
+	if err != nil {
+		return err
+	}
+
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return fmt.Errorf("workspace top is only supported for the docker driver")
+	}
+
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return err
+	}
+
+	containerDetails, err := findWorkspaceContainer(ctx, dockerDriver, workspaceInfo)
+	if err != nil {
+		return err
+	} else if containerDetails == nil {
+		return fmt.Errorf("couldn't find workspace container")
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for {
+		processes, err := dockerHelper.Top(ctx, containerDetails.ID)
+		if err != nil {
+			return err
+		}
+
+		err = encoder.Encode(processes)
+		if err != nil {
+			return err
+		}
+		if cmd.Interval <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(cmd.Interval):
+		}
+	}
+}
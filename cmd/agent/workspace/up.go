@@ -23,10 +23,13 @@ import (
 	"github.com/skevetter/devpod/pkg/devcontainer"
 	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/crane"
+	"github.com/skevetter/devpod/pkg/docker"
 	"github.com/skevetter/devpod/pkg/dockercredentials"
 	"github.com/skevetter/devpod/pkg/dockerinstall"
 	"github.com/skevetter/devpod/pkg/extract"
+	"github.com/skevetter/devpod/pkg/gitcredentials"
 	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/telemetry"
 	"github.com/skevetter/devpod/pkg/util"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
@@ -65,6 +68,8 @@ func (cmd *UpCmd) Run(ctx context.Context) error {
 	if workspaceInfo == nil {
 		return nil
 	}
+	ctx, tracing := telemetry.StartAgentTracing(ctx, workspaceInfo.CLIOptions.Tracing)
+	defer tracing.Shutdown(context.WithoutCancel(ctx))
 
 	if cmd.shouldPreventDaemonShutdown(workspaceInfo) {
 		agent.CreateWorkspaceBusyFile(workspaceInfo.Origin)
@@ -74,6 +79,9 @@ func (cmd *UpCmd) Run(ctx context.Context) error {
 	cancelCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	events := provider.NewEventWriter(workspaceInfo.Origin, log.Default.ErrorStreamOnly())
+	events.Reset()
+	events.Record("agent:init", provider.EventLevelInfo, "Preparing workspace content")
 	tunnelClient, logger, credentialsDir, err := initWorkspace(initWorkspaceParams{
 		ctx:                 cancelCtx,
 		workspaceInfo:       workspaceInfo,
@@ -81,17 +89,41 @@ func (cmd *UpCmd) Run(ctx context.Context) error {
 		shouldInstallDaemon: cmd.shouldInstallDaemon(workspaceInfo),
 	})
 	defer cmd.cleanupCredentials(credentialsDir)
+	if tunnelClient != nil {
+		defer sendTraces(ctx, tracing, tunnelClient, logger)
+	}
 	if err != nil {
+		events.Fail("agent:init", err)
 		return cmd.handleInitError(err, workspaceInfo, logger)
 	}
 
+	events.Record("agent:devcontainer", provider.EventLevelInfo, "Starting devcontainer")
 	if err := cmd.up(ctx, workspaceInfo, tunnelClient, logger); err != nil {
+		events.Fail("agent:devcontainer", err)
 		return fmt.Errorf("devcontainer up: %w", err)
 	}
+	events.Record("agent:devcontainer", provider.EventLevelInfo, "Devcontainer is running")
 
 	return nil
 }
 
+// sendTraces sends the spans of the agent to the client, which exports them. Errors are only
+// logged as tracing must never fail the up.
+func sendTraces(
+	ctx context.Context,
+	tracing *telemetry.AgentTracing,
+	tunnelClient tunnel.TunnelClient,
+	logger log.Logger,
+) {
+	err := tracing.Send(context.WithoutCancel(ctx), func(ctx context.Context, payload string) error {
+		_, err := tunnelClient.ExportTraces(ctx, &tunnel.Message{Message: payload})
+		return err
+	})
+	if err != nil {
+		logger.Warnf("send traces: %v", err)
+	}
+}
+
 func (cmd *UpCmd) loadWorkspaceInfo(ctx context.Context) (*provider.AgentWorkspaceInfo, error) {
 	shouldExit, workspaceInfo, err := agent.WriteWorkspaceInfoAndDeleteOld(
 		cmd.WorkspaceInfo,
@@ -188,10 +220,30 @@ func (cmd *UpCmd) devPodUp(
 		return nil, err
 	}
 
-	return runner.Up(ctx, devcontainer.UpOptions{
+	result, err := runner.Up(ctx, devcontainer.UpOptions{
 		CLIOptions:    workspaceInfo.CLIOptions,
 		RegistryCache: workspaceInfo.RegistryCache,
 	}, workspaceInfo.InjectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeWorkspaceMarker(ctx, runner, workspaceInfo, result)
+	if err != nil {
+		log.Warnf("Error writing workspace marker file: %v", err)
+	}
+
+	err = applyNetworkPolicies(ctx, runner, workspaceInfo.NetworkPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyResourceLimits(ctx, workspaceInfo, workspaceInfo.Workspace.ResourceLimits, log)
+	if err != nil {
+		log.Warnf("Error applying resource limits: %v", err)
+	}
+
+	return result, nil
 }
 
 func CreateRunner(
@@ -307,7 +359,7 @@ func initWorkspace(params initWorkspaceParams) (tunnel.TunnelClient, log.Logger,
 	}
 
 	if err := init.initialize(); err != nil {
-		return nil, init.logger, init.dockerCredentialsDir, err
+		return init.tunnelClient, init.logger, init.dockerCredentialsDir, err
 	}
 
 	return init.tunnelClient, init.logger, init.dockerCredentialsDir, nil
@@ -347,11 +399,19 @@ func (w *workspaceInitializer) setupDaemonIfNeeded() {
 }
 
 func (w *workspaceInitializer) tryConfigureDockerDaemon() {
+	registryMirror := w.workspaceInfo.CLIOptions.RegistryMirror
 	if !w.shouldConfigureDockerDaemon() {
+		if registryMirror != "" {
+			w.logger.Warnf(
+				"registry mirror %s is only configured for remote docker hosts, "+
+					"please add it to the registry-mirrors of your docker daemon",
+				registryMirror,
+			)
+		}
 		w.logger.Debug("skipping configuring docker daemon")
 		return
 	}
-	if err := configureDockerDaemon(w.ctx, w.logger); err != nil {
+	if err := configureDockerDaemon(w.ctx, registryMirror, w.logger); err != nil {
 		w.logger.Warn(
 			"could not find docker daemon config file, if using the registry cache, " +
 				"please ensure the daemon is configured with containerd-snapshotter=true, " +
@@ -580,8 +640,9 @@ func prepareGitWorkspace(params prepareGitWorkspaceParams) error {
 		return nil
 	}
 
-	return agent.CloneRepositoryForWorkspace(
-		params.ctx,
+	ctx, span := telemetry.StartSpan(params.ctx, telemetry.SpanCloneRepository)
+	err := agent.CloneRepositoryForWorkspace(
+		ctx,
 		&params.workspaceInfo.Workspace.Source,
 		&params.workspaceInfo.Agent,
 		params.workspaceInfo.ContentFolder,
@@ -590,6 +651,8 @@ func prepareGitWorkspace(params prepareGitWorkspaceParams) error {
 		false,
 		params.log,
 	)
+	telemetry.EndSpan(span, err)
+	return err
 }
 
 func prepareLocalWorkspace(
@@ -679,11 +742,7 @@ func configureCredentials(cfg credentialsConfig) (string, string, error) {
 
 	gitCredentials := ""
 	if cfg.workspaceInfo.Agent.InjectGitCredentials == config.BoolTrue {
-		gitCredentials = fmt.Sprintf(
-			"!'%s' agent git-credentials --port %d",
-			binaryPath,
-			serverPort,
-		)
+		gitCredentials = gitcredentials.HelperCommand(binaryPath, serverPort)
 		_ = os.Setenv(config.EnvGitHelperPort, strconv.Itoa(serverPort))
 	}
 
@@ -738,23 +797,23 @@ func installDocker(log log.Logger) (dockerPath string, err error) {
 	return dockerinstall.Install(writer, writer)
 }
 
-func configureDockerDaemon(ctx context.Context, log log.Logger) error {
+func configureDockerDaemon(ctx context.Context, registryMirror string, log log.Logger) error {
 	log.Info("configuring docker daemon")
 
-	if err := mergeDockerDaemonConfig(); err != nil {
+	if err := mergeDockerDaemonConfig(registryMirror); err != nil {
 		return err
 	}
 
-	return reloadDockerDaemon(ctx)
+	return docker.ReloadDaemon(ctx)
 }
 
-func mergeDockerDaemonConfig() error {
-	rootlessErr := tryMergeRootlessDockerConfig()
+func mergeDockerDaemonConfig(registryMirror string) error {
+	rootlessErr := tryMergeRootlessDockerConfig(registryMirror)
 	if rootlessErr == nil {
 		return nil
 	}
 
-	rootErr := tryMergeRootDockerConfig()
+	rootErr := mergeDaemonConfig(docker.DaemonConfigFile, registryMirror)
 	if rootErr == nil {
 		return nil
 	}
@@ -766,7 +825,7 @@ func mergeDockerDaemonConfig() error {
 	)
 }
 
-func tryMergeRootlessDockerConfig() error {
+func tryMergeRootlessDockerConfig(registryMirror string) error {
 	homeDir, err := util.UserHomeDir()
 	if err != nil {
 		return err
@@ -778,39 +837,20 @@ func tryMergeRootlessDockerConfig() error {
 	}
 
 	configPath := filepath.Join(dockerConfigDir, "daemon.json")
-	return mergeContainerdSnapshotterConfig(configPath)
+	return mergeDaemonConfig(configPath, registryMirror)
 }
 
-func tryMergeRootDockerConfig() error {
-	return mergeContainerdSnapshotterConfig("/etc/docker/daemon.json")
-}
+// mergeDaemonConfig enables the containerd snapshotter and adds the registry mirror, if any.
+// The mirror is used for image pulls as well as for builds with the docker buildx driver.
+func mergeDaemonConfig(configPath, registryMirror string) error {
+	return docker.UpdateDaemonConfig(configPath, func(config map[string]any) {
+		features := ensureFeaturesMap(config)
+		features["containerd-snapshotter"] = true
 
-func mergeContainerdSnapshotterConfig(configPath string) error {
-	existingConfig, err := readExistingConfig(configPath)
-	if err != nil {
-		return err
-	}
-
-	features := ensureFeaturesMap(existingConfig)
-	features["containerd-snapshotter"] = true
-
-	return writeConfig(configPath, existingConfig)
-}
-
-func readExistingConfig(configPath string) (map[string]any, error) {
-	existingConfig := make(map[string]any)
-	// #nosec G304 -- configPath is controlled by the application
-	data, err := os.ReadFile(configPath)
-	if err != nil && !errors.Is(err, os.ErrNotExist) {
-		return nil, fmt.Errorf("read existing config: %w", err)
-	}
-
-	if len(data) > 0 {
-		if err := json.Unmarshal(data, &existingConfig); err != nil {
-			return nil, fmt.Errorf("parse existing config: %w", err)
+		if registryMirror != "" {
+			docker.AddRegistryMirror(config, registryMirror)
 		}
-	}
-	return existingConfig, nil
+	})
 }
 
 func ensureFeaturesMap(config map[string]any) map[string]any {
@@ -821,35 +861,3 @@ func ensureFeaturesMap(config map[string]any) map[string]any {
 	}
 	return features
 }
-
-func writeConfig(configPath string, config map[string]any) error {
-	mergedData, err := json.MarshalIndent(config, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal config: %w", err)
-	}
-
-	// #nosec G301 -- directory needs to be accessible by docker daemon
-	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
-		return fmt.Errorf("create config directory: %w", err)
-	}
-
-	// #nosec G306 -- daemon.json needs to be readable by docker daemon
-	if err := os.WriteFile(configPath, mergedData, 0o644); err != nil {
-		return fmt.Errorf("write config: %w", err)
-	}
-
-	return nil
-}
-
-func reloadDockerDaemon(ctx context.Context) error {
-	err := exec.CommandContext(ctx, "pkill", "-HUP", "dockerd").Run()
-	if err != nil {
-		// pkill returns exit code 1 if no processes matched
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
-			return nil // No dockerd process found, nothing to reload
-		}
-		return err
-	}
-	return nil
-}
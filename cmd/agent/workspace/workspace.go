@@ -22,5 +22,19 @@ func NewWorkspaceCmd(flags *flags.GlobalFlags) *cobra.Command {
 	workspaceCmd.AddCommand(NewInstallDotfilesCmd(flags))
 	workspaceCmd.AddCommand(NewSetupGPGCmd(flags))
 	workspaceCmd.AddCommand(NewLogsCmd(flags))
+	workspaceCmd.AddCommand(NewDiffCmd(flags))
+	workspaceCmd.AddCommand(NewResourcesCmd(flags))
+	workspaceCmd.AddCommand(NewIPCmd(flags))
+	workspaceCmd.AddCommand(NewTopCmd(flags))
+	workspaceCmd.AddCommand(NewEnvCmd(flags))
+	workspaceCmd.AddCommand(NewEventsCmd(flags))
+	workspaceCmd.AddCommand(NewKillCmd(flags))
+	workspaceCmd.AddCommand(NewConvertImageCmd(flags))
+	workspaceCmd.AddCommand(NewNetworkPolicyCmd(flags))
+	workspaceCmd.AddCommand(NewConfigDiffCmd(flags))
+	workspaceCmd.AddCommand(NewSetResourceLimitsCmd(flags))
+	workspaceCmd.AddCommand(NewSealInfoCmd(flags))
+	workspaceCmd.AddCommand(NewDiffFeaturesCmd(flags))
+	workspaceCmd.AddCommand(NewPortListCmd(flags))
 	return workspaceCmd
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/spf13/cobra"
+)
+
+// CompletionCmd holds the completion cmd flags.
+type CompletionCmd struct {
+	NoDescriptions bool
+}
+
+// NewCompletionCmd creates a new completion command.
+func NewCompletionCmd() *cobra.Command {
+	cmd := &CompletionCmd{}
+	completionCmd := &cobra.Command{
+		Use:   "completion",
+		Short: "Generate the autocompletion script for the specified shell",
+		Long: `Generate the autocompletion script for devpod for the specified shell.
+
+Bash:
+  source <(devpod completion bash)
+
+Zsh:
+  devpod completion zsh > "${fpath[1]}/_devpod"
+
+Fish:
+  devpod completion fish > ~/.config/fish/completions/devpod.fish
+
+PowerShell:
+  devpod completion powershell | Out-String | Invoke-Expression
+
+Nushell:
+  devpod completion nushell | save --force ~/.cache/devpod.nu
+  source ~/.cache/devpod.nu
+
+See each sub-command's help for details on how to use the generated script.`,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+	}
+	completionCmd.PersistentFlags().BoolVar(&cmd.NoDescriptions, "no-descriptions", false,
+		"Disable completion descriptions")
+
+	completionCmd.AddCommand(cmd.newShellCmd("bash",
+		"To load completions in your current shell session:\n\n  source <(devpod completion bash)",
+		func(root *cobra.Command) error {
+			return root.GenBashCompletionV2(os.Stdout, !cmd.NoDescriptions)
+		}))
+	completionCmd.AddCommand(cmd.newShellCmd("zsh",
+		"To load completions for every new session:\n\n  devpod completion zsh > \"${fpath[1]}/_devpod\"",
+		func(root *cobra.Command) error {
+			if cmd.NoDescriptions {
+				return root.GenZshCompletionNoDesc(os.Stdout)
+			}
+			return root.GenZshCompletion(os.Stdout)
+		}))
+	completionCmd.AddCommand(cmd.newShellCmd("fish",
+		"To load completions for every new session:\n\n  devpod completion fish > ~/.config/fish/completions/devpod.fish",
+		func(root *cobra.Command) error {
+			return root.GenFishCompletion(os.Stdout, !cmd.NoDescriptions)
+		}))
+	completionCmd.AddCommand(cmd.newShellCmd("powershell",
+		"To load completions in your current shell session:\n\n  devpod completion powershell | Out-String | Invoke-Expression",
+		func(root *cobra.Command) error {
+			if cmd.NoDescriptions {
+				return root.GenPowerShellCompletion(os.Stdout)
+			}
+			return root.GenPowerShellCompletionWithDesc(os.Stdout)
+		}))
+	completionCmd.AddCommand(cmd.newShellCmd("nushell",
+		"To load completions for every new session, save the script and source it from your config.nu:\n\n"+
+			"  devpod completion nushell | save --force ~/.cache/devpod.nu\n"+
+			"  source ~/.cache/devpod.nu",
+		func(root *cobra.Command) error {
+			return completion.GenNushellCompletion(root, os.Stdout, !cmd.NoDescriptions)
+		}))
+
+	return completionCmd
+}
+
+func (cmd *CompletionCmd) newShellCmd(
+	shell string,
+	usage string,
+	generate func(root *cobra.Command) error,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:               shell,
+		Short:             "Generate the autocompletion script for " + shell,
+		Long:              "Generate the autocompletion script for " + shell + ".\n\n" + usage,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return generate(cobraCmd.Root())
+		},
+	}
+}
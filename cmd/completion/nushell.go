@@ -0,0 +1,76 @@
+package completion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// nushellTemplate registers an external completer that asks the cobra __complete
+// command for suggestions. Completers configured before are kept for other commands.
+const nushellTemplate = `# nushell completion for %[1]s
+#
+# To load completions in your current shell session:
+#   %[1]s completion nushell | save --force ~/.cache/%[1]s.nu
+#   source ~/.cache/%[1]s.nu
+#
+# To load completions for every new session, add the source line to your config.nu.
+
+let %[1]s_completer = {|spans: list<string>|
+    let output = (^%[1]s %[2]s ...($spans | skip 1) | complete | get stdout | lines)
+    if ($output | is-empty) {
+        return null
+    }
+
+    let directive = ($output | last | str replace ':' '' | into int)
+    if ($directive | bits and %[3]d) != 0 {
+        return null
+    }
+
+    let suggestions = ($output | drop 1 | where {|line| not ($line | str starts-with ':') } | each {|line|
+        let parts = ($line | split row "\t")
+        if ($parts | length) > 1 {
+            {value: ($parts | first), description: ($parts | get 1)}
+        } else {
+            {value: ($parts | first)}
+        }
+    })
+
+    if ($suggestions | is-empty) and ($directive | bits and %[4]d) == 0 {
+        return null
+    }
+
+    $suggestions
+}
+
+let %[1]s_previous_completer = $env.config.completions?.external?.completer?
+$env.config.completions.external.enable = true
+$env.config.completions.external.completer = {|spans: list<string>|
+    if ($spans | first) == "%[1]s" {
+        do $%[1]s_completer $spans
+    } else if $%[1]s_previous_completer != null {
+        do $%[1]s_previous_completer $spans
+    } else {
+        null
+    }
+}
+`
+
+// GenNushellCompletion writes a nushell completion script for the given root command to w.
+func GenNushellCompletion(rootCmd *cobra.Command, w io.Writer, includeDesc bool) error {
+	completeCmd := cobra.ShellCompRequestCmd
+	if !includeDesc {
+		completeCmd = cobra.ShellCompNoDescRequestCmd
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		nushellTemplate,
+		rootCmd.Name(),
+		completeCmd,
+		cobra.ShellCompDirectiveError,
+		cobra.ShellCompDirectiveNoFileComp,
+	)
+	return err
+}
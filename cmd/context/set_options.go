@@ -24,6 +24,9 @@ func NewSetOptionsCmd(flags *flags.GlobalFlags) *cobra.Command {
 	setOptionsCmd := &cobra.Command{
 		Use:   "set-options",
 		Short: "Set options for a DevPod context",
+		Example: `  # Restrict the DevPod ssh server to FIPS-140 approved algorithms
+  devpod context set-options -o SSHD_ALLOWED_CIPHERS=aes256-gcm@openssh.com,aes128-gcm@openssh.com \
+    -o SSHD_ALLOWED_KEY_EXCHANGES=ecdh-sha2-nistp256,ecdh-sha2-nistp384`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if len(args) > 1 {
 				return fmt.Errorf("please specify the context")
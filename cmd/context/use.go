@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/skevetter/devpod/cmd/completion"
 	"github.com/skevetter/devpod/cmd/flags"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
 )
 
@@ -22,8 +24,9 @@ func NewUseCmd(flags *flags.GlobalFlags) *cobra.Command {
 		GlobalFlags: flags,
 	}
 	useCmd := &cobra.Command{
-		Use:   "use",
-		Short: "Set a DevPod context as the default",
+		Use:     "use",
+		Aliases: []string{"switch"},
+		Short:   "Set a DevPod context as the default",
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			if len(args) != 1 {
 				return fmt.Errorf("please specify the context to use")
@@ -31,6 +34,21 @@ func NewUseCmd(flags *flags.GlobalFlags) *cobra.Command {
 
 			return cmd.Run(cobraCmd.Context(), args[0])
 		},
+		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetContextSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
 	}
 
 	useCmd.Flags().
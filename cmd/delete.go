@@ -2,22 +2,32 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/skevetter/devpod/cmd/completion"
 	"github.com/skevetter/devpod/cmd/flags"
 	client2 "github.com/skevetter/devpod/pkg/client"
 	"github.com/skevetter/devpod/pkg/client/clientimplementation"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 // DeleteCmd holds the delete cmd flags.
 type DeleteCmd struct {
 	*flags.GlobalFlags
 	client2.DeleteOptions
+
+	All      bool
+	DryRun   bool
+	Filter   []string
+	Parallel int
 }
 
 // NewDeleteCmd creates a new command.
@@ -29,7 +39,10 @@ func NewDeleteCmd(flags *flags.GlobalFlags) *cobra.Command {
 		Use:   "delete [flags] [workspace-path|workspace-name]",
 		Short: "Deletes an existing workspace",
 		Long: `Deletes an existing workspace. You can specify the workspace by its path or name.
-If the workspace is not found, you can use the --ignore-not-found flag to treat it as a successful delete.`,
+If the workspace is not found, you can use the --ignore-not-found flag to treat it as a successful delete.
+
+With --all every workspace matching the --filter flags is deleted, pinned workspaces are skipped
+unless --force is set. Use --dry-run to print the workspaces that would be deleted.`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd, args)
 		},
@@ -53,7 +66,15 @@ If the workspace is not found, you can use the --ignore-not-found flag to treat
 	deleteCmd.Flags().
 		StringVar(&cmd.GracePeriod, "grace-period", "", "The amount of time to give the command to delete the workspace")
 	deleteCmd.Flags().
-		BoolVar(&cmd.Force, "force", false, "Delete workspace even if it is not found remotely anymore")
+		BoolVar(&cmd.Force, "force", false, "Delete workspace even if it is not found remotely anymore and remove leftover temporary files")
+	deleteCmd.Flags().BoolVar(&cmd.All, "all", false, "Delete all workspaces")
+	deleteCmd.Flags().
+		BoolVar(&cmd.DryRun, "dry-run", false, "Only print the workspaces that would be deleted with --all")
+	deleteCmd.Flags().
+		StringArrayVar(&cmd.Filter, "filter", []string{},
+			"Only delete workspaces matching the filter with --all, e.g. status=stopped or tag=frontend")
+	deleteCmd.Flags().
+		IntVar(&cmd.Parallel, "parallel", 4, "The number of workspaces to delete at the same time with --all")
 	return deleteCmd
 }
 
@@ -65,6 +86,16 @@ func (cmd *DeleteCmd) Run(cobraCmd *cobra.Command, args []string) error {
 	}
 
 	ctx := cobraCmd.Context()
+	if cmd.All {
+		if len(args) > 0 {
+			return errors.New("cannot specify a workspace together with --all")
+		}
+
+		return cmd.deleteAll(ctx, devPodConfig)
+	} else if cmd.DryRun || len(cmd.Filter) > 0 {
+		return errors.New("--dry-run and --filter can only be used together with --all")
+	}
+
 	if len(args) <= 1 {
 		return cmd.deleteSingle(ctx, devPodConfig, args)
 	}
@@ -131,12 +162,131 @@ func (cmd *DeleteCmd) deleteMultiple(
 	return nil
 }
 
+// deleteAll deletes all workspaces matching the filters and reports the ones that failed.
+func (cmd *DeleteCmd) deleteAll(ctx context.Context, devPodConfig *config.Config) error {
+	statusFilter, filters, err := splitStatusFilter(cmd.Filter)
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := workspace.List(ctx, devPodConfig, false, cmd.Owner, log.Default)
+	if err != nil {
+		return fmt.Errorf("list workspaces: %w", err)
+	}
+	workspaces, err = filterWorkspaces(workspaces, filters)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Force {
+		workspaces = slices.DeleteFunc(workspaces, func(workspace *provider.Workspace) bool {
+			if workspace.Pinned {
+				log.Default.Infof("Skipping pinned workspace %s, use --force to delete it", workspace.ID)
+			}
+			return workspace.Pinned
+		})
+	}
+
+	parallel := max(cmd.Parallel, 1)
+	errs := make([]error, len(workspaces))
+	matched := make([]bool, len(workspaces))
+	group := errgroup.Group{}
+	group.SetLimit(parallel)
+	for i, workspace := range workspaces {
+		group.Go(func() error {
+			matched[i], errs[i] = cmd.deleteIfMatches(ctx, devPodConfig, workspace.ID, statusFilter)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	failed := 0
+	deleted := 0
+	for i, workspace := range workspaces {
+		switch {
+		case errs[i] != nil:
+			failed++
+			log.Default.Errorf("Failed to delete workspace %s: %v", workspace.ID, errs[i])
+		case matched[i] && cmd.DryRun:
+			deleted++
+			log.Default.Infof("Would delete workspace %s", workspace.ID)
+		case matched[i]:
+			deleted++
+			log.Default.Donef("deleted workspace %s", workspace.ID)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d workspaces", failed, failed+deleted)
+	} else if deleted == 0 {
+		log.Default.Info("No matching workspaces found")
+	}
+
+	return nil
+}
+
+// deleteIfMatches deletes the workspace if its status matches statusFilter, an empty
+// filter matches all workspaces. Nothing is deleted with --dry-run.
+func (cmd *DeleteCmd) deleteIfMatches(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	workspaceID string,
+	statusFilter client2.Status,
+) (bool, error) {
+	if statusFilter != "" {
+		client, err := workspace.Get(ctx, workspace.GetOptions{
+			DevPodConfig: devPodConfig,
+			Args:         []string{workspaceID},
+			Owner:        cmd.Owner,
+			Log:          log.Default,
+		})
+		if err != nil {
+			return false, err
+		}
+
+		status, err := client.Status(ctx, client2.StatusOptions{})
+		if err != nil {
+			return false, fmt.Errorf("get status: %w", err)
+		} else if status != statusFilter {
+			return false, nil
+		}
+	}
+
+	if cmd.DryRun {
+		return true, nil
+	}
+
+	_, err := cmd.deleteWorkspace(ctx, devPodConfig, []string{workspaceID})
+	return true, err
+}
+
+// splitStatusFilter separates the status filter, which requires the status of each workspace,
+// from the filters applied to the workspace list.
+func splitStatusFilter(filters []string) (client2.Status, []string, error) {
+	var statusFilter client2.Status
+	otherFilters := []string{}
+	for _, filter := range filters {
+		value, ok := strings.CutPrefix(filter, "status=")
+		if !ok {
+			otherFilters = append(otherFilters, filter)
+			continue
+		}
+
+		status, err := client2.ParseStatus(value)
+		if err != nil {
+			return "", nil, err
+		}
+		statusFilter = status
+	}
+
+	return statusFilter, otherFilters, nil
+}
+
 func (cmd *DeleteCmd) deleteWorkspace(
 	ctx context.Context,
 	devPodConfig *config.Config,
 	args []string,
 ) (string, error) {
-	return workspace.Delete(ctx, workspace.DeleteOptions{
+	name, err := workspace.Delete(ctx, workspace.DeleteOptions{
 		DevPodConfig:   devPodConfig,
 		Args:           args,
 		IgnoreNotFound: cmd.IgnoreNotFound,
@@ -145,4 +295,27 @@ func (cmd *DeleteCmd) deleteWorkspace(
 		Owner:          cmd.Owner,
 		Log:            log.Default,
 	})
+	if err != nil {
+		return "", err
+	}
+
+	if cmd.Force && name != "" {
+		cleanupTempFolders(devPodConfig.DefaultContext, name)
+	}
+
+	return name, nil
+}
+
+// cleanupTempFolders removes leftover .docker-compose folders that survive a forced delete.
+func cleanupTempFolders(contextName, workspaceID string) {
+	folders, err := workspace.FindTempFolders(contextName, workspaceID)
+	if err != nil {
+		log.Default.Debugf("error finding temporary folders of workspace %s: %v", workspaceID, err)
+		return
+	}
+
+	err = workspace.RemoveTempFolders(folders)
+	if err != nil {
+		log.Default.Warnf("error removing temporary folders of workspace %s: %v", workspaceID, err)
+	}
 }
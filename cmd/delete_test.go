@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	client2 "github.com/skevetter/devpod/pkg/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatusFilter(t *testing.T) {
+	status, filters, err := splitStatusFilter([]string{"tag=frontend", "status=stopped"})
+	require.NoError(t, err)
+	require.Equal(t, client2.Status(client2.StatusStopped), status)
+	require.Equal(t, []string{"tag=frontend"}, filters)
+
+	status, filters, err = splitStatusFilter(nil)
+	require.NoError(t, err)
+	require.Empty(t, status)
+	require.Empty(t, filters)
+
+	_, _, err = splitStatusFilter([]string{"status=paused"})
+	require.Error(t, err)
+}
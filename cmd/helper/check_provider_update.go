@@ -1,24 +1,17 @@
 package helper
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"strings"
 
-	"github.com/blang/semver/v4"
 	"github.com/skevetter/devpod/cmd/flags"
 	"github.com/skevetter/devpod/pkg/config"
-	"github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
 )
 
-var errProviderNotFound = errors.New("provider not found")
-
 type CheckProviderUpdateCmd struct {
 	*flags.GlobalFlags
 	log log.Logger
@@ -60,43 +53,14 @@ func (cmd *CheckProviderUpdateCmd) Run(
 	}
 	providerName := args[0]
 
-	providerSourceRaw, err := workspace.ResolveProviderSource(devPodConfig, providerName, cmd.log)
-	if err != nil {
-		return fmt.Errorf("provider %s doesn't exist", providerName)
-	}
-
-	// retrieve current config for provider
-	allProviders, err := workspace.LoadAllProviders(devPodConfig, cmd.log)
-	if err != nil {
-		return err
-	}
-	currentProvider, ok := allProviders[providerName]
-	if !ok {
-		return errProviderNotFound
-	}
-
-	latestProviderConfig, err := loadLatestProvider(providerSourceRaw, cmd.log)
-	if err != nil {
-		return err
-	}
-	currentProviderVersion, err := semver.Parse(
-		strings.TrimPrefix(currentProvider.Config.Version, "v"),
-	)
-	if err != nil {
-		return err
-	}
-	latestProviderVersion, err := semver.Parse(
-		strings.TrimPrefix(latestProviderConfig.Version, "v"),
-	)
+	check, err := workspace.CheckProviderVersion(devPodConfig, providerName, cmd.log)
 	if err != nil {
 		return err
 	}
 
-	versionCheck := providerVersionCheck{UpdateAvailable: false}
-	// check if new version is newer
-	if latestProviderVersion.GT(currentProviderVersion) {
-		versionCheck.UpdateAvailable = true
-		versionCheck.LatestVersion = latestProviderConfig.Version
+	versionCheck := providerVersionCheck{UpdateAvailable: check.UpdateAvailable}
+	if check.UpdateAvailable {
+		versionCheck.LatestVersion = check.LatestVersion
 	}
 	out, err := json.Marshal(versionCheck)
 	if err != nil {
@@ -106,19 +70,3 @@ func (cmd *CheckProviderUpdateCmd) Run(
 
 	return nil
 }
-
-func loadLatestProvider(
-	providerSourceRaw string,
-	log log.Logger,
-) (*provider.ProviderConfig, error) {
-	providerRaw, _, err := workspace.ResolveProvider(providerSourceRaw, log)
-	if err != nil {
-		return nil, fmt.Errorf("resolve provider: %w", err)
-	}
-	providerConfig, err := provider.ParseProvider(bytes.NewReader(providerRaw))
-	if err != nil {
-		return nil, fmt.Errorf("parse provider: %w", err)
-	}
-
-	return providerConfig, nil
-}
@@ -0,0 +1,79 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/daemon/host"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ScheduleDaemonCmd holds the cmd flags.
+type ScheduleDaemonCmd struct {
+	*flags.GlobalFlags
+
+	Interval time.Duration
+}
+
+// NewScheduleDaemonCmd creates a new command.
+func NewScheduleDaemonCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ScheduleDaemonCmd{
+		GlobalFlags: flags,
+	}
+	scheduleDaemonCmd := &cobra.Command{
+		Use:   "schedule-daemon",
+		Short: "Runs the scheduled workspace actions until none are pending",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, _ []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+	scheduleDaemonCmd.Flags().DurationVar(&cmd.Interval, "interval", 30*time.Second,
+		"How often the schedules are checked")
+	return scheduleDaemonCmd
+}
+
+func (cmd *ScheduleDaemonCmd) Run(ctx context.Context) error {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return err
+	}
+
+	logger := log.NewFileLogger(filepath.Join(configDir, "schedules.log"), logrus.InfoLevel)
+	return host.RunDaemon(ctx, cmd.Interval, runSchedule, logger)
+}
+
+// runSchedule runs the scheduled action through the regular DevPod command.
+func runSchedule(ctx context.Context, schedule host.Schedule) error {
+	if schedule.Action != host.ActionStop {
+		return fmt.Errorf("unknown scheduled action %s", schedule.Action)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // executable is from os.Executable()
+	out, err := exec.CommandContext(
+		ctx,
+		executable,
+		"stop",
+		"--context",
+		schedule.Context,
+		schedule.Workspace,
+	).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", string(out), err)
+	}
+
+	return nil
+}
@@ -27,6 +27,9 @@ type SSHServerCmd struct {
 	TrackActivity    bool
 	ReuseSSHAuthSock string
 	Workdir          string
+	Ciphers          string
+	KeyExchanges     string
+	AcceptEnv        string
 }
 
 // NewSSHServerCmd creates a new ssh command.
@@ -52,9 +55,16 @@ func NewSSHServerCmd(flags *flags.GlobalFlags) *cobra.Command {
 			"If set, the SSH_AUTH_SOCK is expected to already be available in the workspace "+
 				"(under /tmp using the key provided) and the connection reuses this instead of creating a new one")
 	_ = sshCmd.Flags().MarkHidden("reuse-ssh-auth-sock")
-	sshCmd.Flags().StringVar(&cmd.Token, "token", "", "Base64 encoded token to use")
+	sshCmd.Flags().StringVar(&cmd.Token, "token", "",
+		"Base64 encoded token to use, read from "+helperssh.TokenEnv+" if not set")
 	sshCmd.Flags().
 		StringVar(&cmd.Workdir, "workdir", "", "Directory where commands will run on the host")
+	sshCmd.Flags().
+		StringVar(&cmd.Ciphers, "ciphers", "", "Comma separated list of ciphers the server allows")
+	sshCmd.Flags().
+		StringVar(&cmd.KeyExchanges, "key-exchanges", "", "Comma separated list of key exchange algorithms the server allows")
+	sshCmd.Flags().StringVar(&cmd.AcceptEnv, "accept-env", "",
+		"Comma separated list of env variable patterns the server accepts. Defaults to all")
 	return sshCmd
 }
 
@@ -65,28 +75,23 @@ func (cmd *SSHServerCmd) Run(_ *cobra.Command, _ []string) error {
 		hostKey []byte
 		err     error
 	)
-	if cmd.Token != "" {
+	// the token is passed in the env to keep it out of the process list, don't hand it down
+	// to the sessions of the server
+	encodedToken := cmd.Token
+	if encodedToken == "" {
+		encodedToken = os.Getenv(helperssh.TokenEnv)
+	}
+	_ = os.Unsetenv(helperssh.TokenEnv)
+	if encodedToken != "" {
 		// parse token
-		t, err := token.ParseToken(cmd.Token)
+		t, err := token.ParseToken(encodedToken)
 		if err != nil {
 			return fmt.Errorf("parse token: %w", err)
 		}
 
-		if t.AuthorizedKeys != "" {
-			keyBytes, err := base64.StdEncoding.DecodeString(t.AuthorizedKeys)
-			if err != nil {
-				return fmt.Errorf("seems like the provided encoded string is not base64 encoded")
-			}
-
-			for len(keyBytes) > 0 {
-				key, _, _, rest, err := ssh.ParseAuthorizedKey(keyBytes)
-				if err != nil {
-					return fmt.Errorf("parse authorized key: %w", err)
-				}
-
-				keys = append(keys, key)
-				keyBytes = rest
-			}
+		keys, err = t.PublicKeys()
+		if err != nil {
+			return err
 		}
 
 		if len(t.HostKey) > 0 {
@@ -97,6 +102,11 @@ func (cmd *SSHServerCmd) Run(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	algorithms, err := helperssh.ParseAlgorithms(cmd.Ciphers, cmd.KeyExchanges)
+	if err != nil {
+		return err
+	}
+
 	// start the server
 	server, err := helperssh.NewServer(
 		cmd.Address,
@@ -104,6 +114,8 @@ func (cmd *SSHServerCmd) Run(_ *cobra.Command, _ []string) error {
 		keys,
 		cmd.Workdir,
 		cmd.ReuseSSHAuthSock,
+		algorithms,
+		helperssh.ParseAcceptEnv(cmd.AcceptEnv),
 		log.Default.ErrorStreamOnly(),
 	)
 	if err != nil {
@@ -4,15 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/skevetter/devpod/cmd/flags"
+	client2 "github.com/skevetter/devpod/pkg/client"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/devpod/pkg/table"
 	"github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/yaml"
 )
 
 // ListCmd holds the configuration.
@@ -20,7 +26,33 @@ type ListCmd struct {
 	*flags.GlobalFlags
 
 	Output  string
+	Sort    string
+	Filter  []string
 	SkipPro bool
+	Status  bool
+}
+
+const (
+	sortLastUsed = "last-used"
+	sortName     = "name"
+	sortCreated  = "created"
+
+	// listOutputVersion is increased on incompatible changes of the json and yaml output
+	listOutputVersion  = 1
+	listStatusParallel = 4
+	statusUnknown      = "Unknown"
+)
+
+// listOutput is the json and yaml output.
+type listOutput struct {
+	Version    int         `json:"version"`
+	Workspaces []listEntry `json:"workspaces"`
+}
+
+// listEntry is a workspace in the json and yaml output.
+type listEntry struct {
+	*provider.Workspace
+	Status string `json:"status,omitempty"`
 }
 
 // NewListCmd creates a new destroy command.
@@ -39,8 +71,18 @@ func NewListCmd(flags *flags.GlobalFlags) *cobra.Command {
 	}
 
 	listCmd.Flags().
-		StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+		StringVar(&cmd.Output, "output", "plain",
+			"The output format to use. Can be json, yaml or plain. json and yaml include a "+
+				"version field")
+	listCmd.Flags().BoolVar(&cmd.Status, "status", false,
+		"If true, fetches the status of every workspace from its provider for the json and "+
+			"yaml output")
 	listCmd.Flags().BoolVar(&cmd.SkipPro, "skip-pro", false, "Don't list pro workspaces")
+	listCmd.Flags().
+		StringVar(&cmd.Sort, "sort", sortLastUsed, "The order to list workspaces in. Can be last-used, name or created")
+	listCmd.Flags().
+		StringArrayVar(&cmd.Filter, "filter", []string{},
+			"Only list workspaces matching the filter, e.g. tag=frontend or annotation.cost-center=engineering")
 	return listCmd
 }
 
@@ -56,54 +98,184 @@ func (cmd *ListCmd) Run(ctx context.Context) error {
 		return err
 	}
 
+	workspaces, err = filterWorkspaces(workspaces, cmd.Filter)
+	if err != nil {
+		return err
+	}
+
+	err = sortWorkspaces(workspaces, cmd.Sort)
+	if err != nil {
+		return err
+	}
+
 	switch cmd.Output {
-	case "json":
-		sort.SliceStable(workspaces, func(i, j int) bool {
-			return workspaces[i].LastUsedTimestamp.Unix() > workspaces[j].LastUsedTimestamp.Unix()
+	case "json", "yaml":
+		out, err := json.Marshal(listOutput{
+			Version:    listOutputVersion,
+			Workspaces: cmd.listEntries(ctx, devPodConfig, workspaces),
 		})
-		out, err := json.Marshal(workspaces)
 		if err != nil {
 			return err
 		}
+		if cmd.Output == "yaml" {
+			out, err = yaml.JSONToYAML(out)
+			if err != nil {
+				return err
+			}
+		}
 		fmt.Print(string(out))
 	case "plain":
-		tableEntries := [][]string{}
-		sort.SliceStable(workspaces, func(i, j int) bool {
-			return workspaces[i].LastUsedTimestamp.Unix() > workspaces[j].LastUsedTimestamp.Unix()
-		})
-		for _, entry := range workspaces {
-			name := entry.ID
-			if entry.IsPro() && entry.Pro.DisplayName != "" && entry.ID != entry.Pro.DisplayName {
-				name = fmt.Sprintf("%s (%s)", entry.Pro.DisplayName, entry.ID)
+		printWorkspaceTable(workspaces)
+	default:
+		return fmt.Errorf(
+			"unexpected output format, choose either json, yaml or plain. Got %s",
+			cmd.Output,
+		)
+	}
+
+	return nil
+}
+
+// listEntries returns the output entries of the workspaces. The status is only fetched with
+// --status, as it contacts the provider of every workspace.
+func (cmd *ListCmd) listEntries(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	workspaces []*provider.Workspace,
+) []listEntry {
+	entries := make([]listEntry, len(workspaces))
+	if !cmd.Status {
+		for i, workspace := range workspaces {
+			entries[i] = listEntry{Workspace: workspace}
+		}
+		return entries
+	}
+
+	group := errgroup.Group{}
+	group.SetLimit(listStatusParallel)
+	for i, workspace := range workspaces {
+		group.Go(func() error {
+			entries[i] = listEntry{
+				Workspace: workspace,
+				Status:    cmd.workspaceStatus(ctx, devPodConfig, workspace.ID),
 			}
-			tableEntries = append(tableEntries, []string{
-				name,
-				entry.Source.String(),
-				entry.Machine.ID,
-				entry.Provider.Name,
-				entry.IDE.Name,
-				time.Since(entry.LastUsedTimestamp.Time).Round(1 * time.Second).String(),
-				time.Since(entry.CreationTimestamp.Time).Round(1 * time.Second).String(),
-				fmt.Sprintf("%t", entry.IsPro()),
-			})
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	return entries
+}
+
+// workspaceStatus returns the status of the workspace without the container status, errors
+// are reported as unknown status.
+func (cmd *ListCmd) workspaceStatus(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	workspaceID string,
+) string {
+	client, err := workspace.Get(ctx, workspace.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceID},
+		Owner:        cmd.Owner,
+		Log:          log.Default.ErrorStreamOnly(),
+	})
+	if err != nil {
+		log.Default.Debugf("get workspace %s: %v", workspaceID, err)
+		return statusUnknown
+	}
+
+	status, err := client.Status(ctx, client2.StatusOptions{})
+	if err != nil {
+		log.Default.Debugf("get status of workspace %s: %v", workspaceID, err)
+		return statusUnknown
+	}
+
+	return string(status)
+}
+
+func printWorkspaceTable(workspaces []*provider.Workspace) {
+	tableEntries := [][]string{}
+	for _, entry := range workspaces {
+		name := entry.ID
+		if entry.IsPro() && entry.Pro.DisplayName != "" && entry.ID != entry.Pro.DisplayName {
+			name = fmt.Sprintf("%s (%s)", entry.Pro.DisplayName, entry.ID)
 		}
+		if entry.Pinned {
+			name += " 📌"
+		}
+		tableEntries = append(tableEntries, []string{
+			name,
+			entry.Source.String(),
+			entry.Machine.ID,
+			entry.Provider.Name,
+			entry.IDE.Name,
+			time.Since(entry.LastUsedTimestamp.Time).Round(1 * time.Second).String(),
+			time.Since(entry.CreationTimestamp.Time).Round(1 * time.Second).String(),
+			fmt.Sprintf("%t", entry.IsPro()),
+		})
+	}
+
+	table.Print([]string{
+		"Name",
+		"Source",
+		"Machine",
+		"Provider",
+		"IDE",
+		"Last Used",
+		"Age",
+		"Pro",
+	}, tableEntries)
+}
 
-		table.Print([]string{
-			"Name",
-			"Source",
-			"Machine",
-			"Provider",
-			"IDE",
-			"Last Used",
-			"Age",
-			"Pro",
-		}, tableEntries)
+func sortWorkspaces(workspaces []*provider.Workspace, sortBy string) error {
+	switch sortBy {
+	case sortLastUsed:
+		sort.SliceStable(workspaces, func(i, j int) bool {
+			return workspaces[i].LastUsedTimestamp.Unix() > workspaces[j].LastUsedTimestamp.Unix()
+		})
+	case sortName:
+		sort.SliceStable(workspaces, func(i, j int) bool {
+			return workspaces[i].ID < workspaces[j].ID
+		})
+	case sortCreated:
+		sort.SliceStable(workspaces, func(i, j int) bool {
+			return workspaces[i].CreationTimestamp.Unix() > workspaces[j].CreationTimestamp.Unix()
+		})
 	default:
 		return fmt.Errorf(
-			"unexpected output format, choose either json or plain. Got %s",
-			cmd.Output,
+			"unexpected sort order, choose either last-used, name or created. Got %s",
+			sortBy,
 		)
 	}
 
 	return nil
 }
+
+// filterWorkspaces returns the workspaces matching all given key=value filters.
+func filterWorkspaces(workspaces []*provider.Workspace, filters []string) ([]*provider.Workspace, error) {
+	workspaces = slices.Clone(workspaces)
+	for _, filter := range filters {
+		key, value, ok := strings.Cut(filter, "=")
+		if !ok {
+			return nil, fmt.Errorf("unexpected filter %s, expected the form key=value", filter)
+		}
+
+		annotation, isAnnotation := strings.CutPrefix(key, "annotation.")
+		switch {
+		case key == "tag":
+			workspaces = slices.DeleteFunc(workspaces, func(workspace *provider.Workspace) bool {
+				return !slices.Contains(workspace.Tags, value)
+			})
+		case isAnnotation && annotation != "":
+			workspaces = slices.DeleteFunc(workspaces, func(workspace *provider.Workspace) bool {
+				annotationValue, ok := workspace.Annotations[annotation]
+				return !ok || annotationValue != value
+			})
+		default:
+			return nil, fmt.Errorf("unexpected filter key %s, choose tag or annotation.<key>", key)
+		}
+	}
+
+	return workspaces, nil
+}
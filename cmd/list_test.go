@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortWorkspaces(t *testing.T) {
+	now := time.Now()
+	newWorkspaces := func() []*provider.Workspace {
+		return []*provider.Workspace{
+			{
+				ID:                "beta",
+				CreationTimestamp: types.NewTime(now.Add(-3 * time.Hour)),
+				LastUsedTimestamp: types.NewTime(now.Add(-1 * time.Hour)),
+			},
+			{
+				ID:                "alpha",
+				CreationTimestamp: types.NewTime(now.Add(-1 * time.Hour)),
+				LastUsedTimestamp: types.NewTime(now.Add(-2 * time.Hour)),
+			},
+			{
+				ID:                "gamma",
+				CreationTimestamp: types.NewTime(now.Add(-2 * time.Hour)),
+				LastUsedTimestamp: types.NewTime(now),
+			},
+		}
+	}
+	ids := func(workspaces []*provider.Workspace) []string {
+		result := []string{}
+		for _, workspace := range workspaces {
+			result = append(result, workspace.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: sortLastUsed, expected: []string{"gamma", "beta", "alpha"}},
+		{sortBy: sortName, expected: []string{"alpha", "beta", "gamma"}},
+		{sortBy: sortCreated, expected: []string{"alpha", "gamma", "beta"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			workspaces := newWorkspaces()
+			require.NoError(t, sortWorkspaces(workspaces, tt.sortBy))
+			require.Equal(t, tt.expected, ids(workspaces))
+		})
+	}
+
+	require.Error(t, sortWorkspaces(newWorkspaces(), "size"))
+}
+
+func TestFilterWorkspaces(t *testing.T) {
+	workspaces := []*provider.Workspace{
+		{ID: "alpha", Tags: []string{"frontend", "team-a"}},
+		{ID: "beta", Tags: []string{"backend"}, Annotations: map[string]string{"cost-center": "engineering"}},
+		{ID: "gamma", Annotations: map[string]string{"cost-center": "sales"}},
+	}
+
+	filtered, err := filterWorkspaces(workspaces, []string{"tag=frontend"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "alpha", filtered[0].ID)
+
+	filtered, err = filterWorkspaces(workspaces, []string{"annotation.cost-center=engineering"})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	require.Equal(t, "beta", filtered[0].ID)
+
+	_, err = filterWorkspaces(workspaces, []string{"annotation.=engineering"})
+	require.Error(t, err)
+
+	_, err = filterWorkspaces(workspaces, []string{"name=alpha"})
+	require.Error(t, err)
+
+	_, err = filterWorkspaces(workspaces, []string{"frontend"})
+	require.Error(t, err)
+}
+
+func TestListOutputJSON(t *testing.T) {
+	out, err := json.Marshal(listOutput{
+		Version: listOutputVersion,
+		Workspaces: []listEntry{{
+			Workspace: &provider.Workspace{
+				ID:          "alpha",
+				Annotations: map[string]string{"b": "2", "a": "1"},
+			},
+			Status: "Running",
+		}, {
+			Workspace: &provider.Workspace{ID: "beta"},
+		}},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(out), `{"version":1,"workspaces":[{"id":"alpha",`))
+	require.Contains(t, string(out), `"annotations":{"a":"1","b":"2"}`)
+	require.Contains(t, string(out), `"status":"Running"}`)
+	require.Equal(t, 1, strings.Count(string(out), `"version"`))
+	require.Equal(t, 1, strings.Count(string(out), `"status"`))
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/skevetter/devpod/pkg/pty"
 	devssh "github.com/skevetter/devpod/pkg/ssh"
 	devsshagent "github.com/skevetter/devpod/pkg/ssh/agent"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/term"
 )
@@ -33,6 +35,11 @@ type SSHCmd struct {
 	AgentForwarding bool
 	TermMode        string
 	InstallTerminfo bool
+
+	Direct bool
+	Host   string
+	User   string
+	Port   int
 }
 
 const (
@@ -65,6 +72,15 @@ type StartSSHSessionOptions struct {
 	SessionOptions  SSHSessionOptions
 	Exec            ExecFunc
 	Stderr          io.Writer
+
+	// AgentForwardingFingerprint restricts agent forwarding to the key with this fingerprint
+	AgentForwardingFingerprint string
+
+	// HostKeyCallback verifies the host key of the session, host keys are not verified if nil
+	HostKeyCallback ssh.HostKeyCallback
+
+	// KeyBytes is the private key the session authenticates with, if any
+	KeyBytes []byte
 }
 
 type RunSSHSessionOptions struct {
@@ -72,6 +88,9 @@ type RunSSHSessionOptions struct {
 	AgentForwarding bool
 	SessionOptions  SSHSessionOptions
 	Stderr          io.Writer
+
+	// AgentForwardingFingerprint restricts agent forwarding to the key with this fingerprint
+	AgentForwardingFingerprint string
 }
 
 // NewSSHCmd creates a new ssh command.
@@ -82,6 +101,10 @@ func NewSSHCmd(flags *flags.GlobalFlags) *cobra.Command {
 	sshCmd := &cobra.Command{
 		Use:   "ssh [name]",
 		Short: "SSH into the machine",
+		Long: `SSH into the machine. By default the connection is established through the provider.
+With --direct DevPod connects to the machine address with the machine ssh key instead, which is
+useful to debug providers. The address passed via --host is recorded for subsequent calls,
+without it the HOST, ADDRESS, IP or PUBLIC_IP option of the machine is used.`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd.Context(), args)
 		},
@@ -90,7 +113,21 @@ func NewSSHCmd(flags *flags.GlobalFlags) *cobra.Command {
 	sshCmd.Flags().
 		StringVar(&cmd.Command, "command", "", "The command to execute on the remote machine")
 	sshCmd.Flags().
-		BoolVar(&cmd.AgentForwarding, "agent-forwarding", false, "If true, will forward the local ssh keys")
+		BoolVar(&cmd.AgentForwarding, "agent-forwarding", false,
+			"If true, will forward the local ssh keys. Alias --forward-agent")
+	sshCmd.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "forward-agent" {
+			name = "agent-forwarding"
+		}
+		return pflag.NormalizedName(name)
+	})
+	sshCmd.Flags().
+		BoolVar(&cmd.Direct, "direct", false, "If true, will connect to the machine address directly instead of through the provider")
+	sshCmd.Flags().
+		StringVar(&cmd.Host, "host", "", "The ip or hostname of the machine to use with --direct")
+	sshCmd.Flags().
+		StringVar(&cmd.User, "user", "", "The user to use with --direct, defaults to the recorded user or root")
+	sshCmd.Flags().IntVar(&cmd.Port, "port", 22, "The ssh port to use with --direct")
 	sshCmd.Flags().StringVar(&cmd.TermMode, "term-mode", TermModeAuto, termModeUsage)
 	sshCmd.Flags().BoolVar(&cmd.InstallTerminfo, "install-terminfo", false, installUsage)
 	_ = sshCmd.RegisterFlagCompletionFunc(
@@ -125,12 +162,21 @@ func (cmd *SSHCmd) Run(ctx context.Context, args []string) error {
 		return err
 	}
 
+	if cmd.Direct {
+		return cmd.runDirect(ctx, devPodConfig, machineClient.MachineConfig())
+	}
+
 	writer := log.Default.ErrorStreamOnly().Writer(logrus.InfoLevel, false)
 	defer func() { _ = writer.Close() }()
 
 	// Get the timeout from the context options
 	timeout := config.ParseTimeOption(devPodConfig, config.ContextOptionAgentInjectTimeout)
 
+	algorithms, err := helperssh.AlgorithmsFromContext(devPodConfig)
+	if err != nil {
+		return err
+	}
+
 	// start the ssh session
 	return StartSSHSession(ctx, StartSSHSessionOptions{
 		Command:         cmd.Command,
@@ -140,7 +186,9 @@ func (cmd *SSHCmd) Run(ctx context.Context, args []string) error {
 			InstallTerminfo: cmd.InstallTerminfo,
 		},
 		Exec: func(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
-			command := fmt.Sprintf("'%s' helper ssh-server --stdio", machineClient.AgentPath())
+			command := algorithms.Command(
+				fmt.Sprintf("'%s' helper ssh-server --stdio", machineClient.AgentPath()),
+			)
 			if cmd.Debug {
 				command += " --debug"
 			}
@@ -192,7 +240,25 @@ func StartSSHSession(ctx context.Context, options StartSSHSessionOptions) error
 		errChan <- options.Exec(ctx, stdinReader, stdoutWriter, options.Stderr)
 	}()
 
-	sshClient, err := devssh.StdioClientWithUser(stdoutReader, stdinWriter, options.User, false)
+	var sshClient *ssh.Client
+	if options.HostKeyCallback != nil {
+		sshClient, err = devssh.StdioClientWithHostKeyCallback(
+			options.KeyBytes,
+			stdoutReader,
+			stdinWriter,
+			options.User,
+			false,
+			options.HostKeyCallback,
+		)
+	} else {
+		sshClient, err = devssh.StdioClientFromKeyBytesWithUser(
+			options.KeyBytes,
+			stdoutReader,
+			stdinWriter,
+			options.User,
+			false,
+		)
+	}
 	if err != nil {
 		return err
 	}
@@ -203,6 +269,8 @@ func StartSSHSession(ctx context.Context, options StartSSHSessionOptions) error
 		AgentForwarding: options.AgentForwarding,
 		SessionOptions:  options.SessionOptions,
 		Stderr:          options.Stderr,
+
+		AgentForwardingFingerprint: options.AgentForwardingFingerprint,
 	})
 }
 
@@ -220,7 +288,13 @@ func RunSSHSession(ctx context.Context, sshClient *ssh.Client, options RunSSHSes
 	}
 	defer func() { _ = session.Close() }()
 
-	if err := configureAgentForwarding(sshClient, session, options.AgentForwarding); err != nil {
+	err = configureAgentForwarding(
+		sshClient,
+		session,
+		options.AgentForwarding,
+		options.AgentForwardingFingerprint,
+	)
+	if err != nil {
 		return err
 	}
 
@@ -259,13 +333,19 @@ func configureAgentForwarding(
 	sshClient *ssh.Client,
 	session *ssh.Session,
 	shouldForward bool,
+	fingerprint string,
 ) error {
 	authSock := devsshagent.GetSSHAuthSocket()
 	if !shouldForward || authSock == "" {
 		return nil
 	}
 
-	err := devsshagent.ForwardToRemote(sshClient, authSock)
+	var err error
+	if fingerprint != "" {
+		err = devsshagent.ForwardIdentityToRemote(sshClient, authSock, fingerprint)
+	} else {
+		err = devsshagent.ForwardToRemote(sshClient, authSock)
+	}
 	if err != nil {
 		return fmt.Errorf("forward agent: %w", err)
 	}
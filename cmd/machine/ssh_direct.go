@@ -0,0 +1,162 @@
+package machine
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	"github.com/skevetter/log"
+)
+
+const defaultDirectSSHUser = "root"
+
+// machineAddressOptions are the provider options that hold the machine address.
+var machineAddressOptions = []string{"HOST", "ADDRESS", "IP", "PUBLIC_IP"}
+
+// directSSHOptions are the options to build the OpenSSH arguments for a direct connection.
+type directSSHOptions struct {
+	Host            string
+	User            string
+	Port            int
+	KeyFile         string
+	KnownHostsFile  string
+	AgentForwarding bool
+	TTY             bool
+	Command         string
+}
+
+// runDirect connects to the recorded machine address with the OpenSSH client and the machine key.
+func (cmd *SSHCmd) runDirect(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	machine *provider.Machine,
+) error {
+	sshBinary, err := exec.LookPath("ssh")
+	if err != nil {
+		return fmt.Errorf("find ssh binary, --direct requires an OpenSSH client: %w", err)
+	}
+
+	err = cmd.recordAddress(machine)
+	if err != nil {
+		return err
+	}
+	address, user := machineAddress(machine)
+	if address == "" {
+		return fmt.Errorf(
+			"machine %s has no recorded address, pass --host to record it",
+			machine.ID,
+		)
+	}
+
+	machineDir, err := provider.GetMachineDir(machine.Context, machine.ID)
+	if err != nil {
+		return err
+	}
+	keyFile := filepath.Join(machineDir, devssh.DevPodSSHPrivateKeyFile)
+	_, err = os.Stat(keyFile)
+	if err != nil {
+		return fmt.Errorf("find machine ssh key: %w", err)
+	}
+
+	knownHostsFile, err := devssh.ResolveKnownHostsFile(
+		devPodConfig.ContextOption(config.ContextOptionSSHKnownHostsFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	args := directSSHArgs(directSSHOptions{
+		Host:            address,
+		User:            user,
+		Port:            cmd.Port,
+		KeyFile:         keyFile,
+		KnownHostsFile:  knownHostsFile,
+		AgentForwarding: cmd.AgentForwarding,
+		TTY:             cmd.Command == "" && hasInteractiveTerminal(os.Stdin, os.Stdout),
+		Command:         cmd.Command,
+	})
+	log.Default.Debugf("Connecting directly to %s@%s", user, address)
+
+	// #nosec G204 -- arguments are built from the machine configuration
+	sshCmd := exec.CommandContext(ctx, sshBinary, args...)
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stdout = os.Stdout
+	sshCmd.Stderr = os.Stderr
+	return sshCmd.Run()
+}
+
+// recordAddress saves the host and user flags in the machine config.
+func (cmd *SSHCmd) recordAddress(machine *provider.Machine) error {
+	changed := false
+	if cmd.Host != "" && cmd.Host != machine.Address {
+		machine.Address = cmd.Host
+		changed = true
+	}
+	if cmd.User != "" && cmd.User != machine.SSHUser {
+		machine.SSHUser = cmd.User
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	err := provider.SaveMachineConfig(machine)
+	if err != nil {
+		return fmt.Errorf("save machine config: %w", err)
+	}
+
+	return nil
+}
+
+// machineAddress returns the address and user to connect to. Without a recorded address it
+// is derived from the host option the machine was created with, e.g. HOST=user@10.0.0.1.
+func machineAddress(machine *provider.Machine) (string, string) {
+	address, user := machine.Address, machine.SSHUser
+	if address == "" {
+		for _, name := range machineAddressOptions {
+			if option, ok := machine.Provider.Options[name]; ok && option.Value != "" {
+				address = option.Value
+				break
+			}
+		}
+		if optionUser, host, ok := strings.Cut(address, "@"); ok {
+			address = host
+			user = cmp.Or(user, optionUser)
+		}
+	}
+
+	return address, cmp.Or(user, defaultDirectSSHUser)
+}
+
+func directSSHArgs(options directSSHOptions) []string {
+	args := []string{
+		"-i", options.KeyFile,
+		"-p", strconv.Itoa(options.Port),
+		"-o", "IdentitiesOnly=yes",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "UserKnownHostsFile=" + options.KnownHostsFile,
+		"-l", options.User,
+	}
+	if options.AgentForwarding {
+		args = append(args, "-A")
+	}
+	if options.TTY {
+		args = append(args, "-t")
+	}
+
+	// host and command must not be read as ssh options
+	args = append(args, "--", options.Host)
+	if options.Command != "" {
+		args = append(args, options.Command)
+	}
+
+	return args
+}
@@ -3,8 +3,12 @@ package machine
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/devpod/pkg/pty"
 )
 
@@ -259,3 +263,62 @@ func assertCallsEqual(t *testing.T, got, want []string) {
 		}
 	}
 }
+
+func TestDirectSSHArgs(t *testing.T) {
+	args := directSSHArgs(directSSHOptions{
+		Host:            "10.0.0.1",
+		User:            "devpod",
+		Port:            2222,
+		KeyFile:         "/machines/test/id_devpod_rsa",
+		KnownHostsFile:  "/devpod/known_hosts",
+		AgentForwarding: true,
+		Command:         "uptime",
+	})
+
+	expected := []string{
+		"-i", "/machines/test/id_devpod_rsa",
+		"-p", "2222",
+		"-o", "IdentitiesOnly=yes",
+		"-o", "StrictHostKeyChecking=accept-new",
+		"-o", "UserKnownHostsFile=/devpod/known_hosts",
+		"-l", "devpod",
+		"-A",
+		"--",
+		"10.0.0.1",
+		"uptime",
+	}
+	if strings.Join(args, " ") != strings.Join(expected, " ") {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestMachineAddress(t *testing.T) {
+	machine := &provider.Machine{
+		Provider: provider.MachineProviderConfig{
+			Options: map[string]config.OptionValue{"HOST": {Value: "devpod@10.0.0.1"}},
+		},
+	}
+	if address, user := machineAddress(machine); address != "10.0.0.1" || user != "devpod" {
+		t.Fatalf("unexpected address %s@%s", user, address)
+	}
+
+	machine.Address = "10.0.0.2"
+	machine.SSHUser = ""
+	if address, user := machineAddress(machine); address != "10.0.0.2" || user != "root" {
+		t.Fatalf("unexpected address %s@%s", user, address)
+	}
+
+	if address, _ := machineAddress(&provider.Machine{}); address != "" {
+		t.Fatalf("unexpected address %s", address)
+	}
+}
+
+func TestForwardAgentAlias(t *testing.T) {
+	sshCmd := NewSSHCmd(&flags.GlobalFlags{})
+	if err := sshCmd.ParseFlags([]string{"--forward-agent"}); err != nil {
+		t.Fatal(err)
+	}
+	if forward, _ := sshCmd.Flags().GetBool("agent-forwarding"); !forward {
+		t.Fatal("expected --forward-agent to set --agent-forwarding")
+	}
+}
@@ -19,11 +19,13 @@ type AddCmd struct {
 	*flags.GlobalFlags
 
 	Use           bool
+	SetAsDefault  bool
 	SingleMachine bool
 	Options       []string
 
-	Name         string
-	FromExisting string
+	Name           string
+	FromExisting   string
+	VerifyChecksum string
 }
 
 // NewAddCmd creates a new command.
@@ -36,6 +38,9 @@ func NewAddCmd(f *flags.GlobalFlags) *cobra.Command {
 		Short: "Adds a new provider to DevPod",
 		Args:  cobra.MaximumNArgs(1),
 		PreRunE: func(cobraCommand *cobra.Command, args []string) error {
+			if cobraCommand.Flags().Changed("set-as-default") && cmd.SetAsDefault && !cmd.Use {
+				return fmt.Errorf("--set-as-default requires --use")
+			}
 			if cmd.FromExisting != "" {
 				return cobraCommand.MarkFlagRequired("name")
 			}
@@ -62,8 +67,15 @@ func NewAddCmd(f *flags.GlobalFlags) *cobra.Command {
 			"The name of an existing provider to use as a template. Needs to be used in conjunction with the --name flag")
 	addCmd.Flags().
 		BoolVar(&cmd.Use, "use", true, "If enabled will automatically activate the provider")
+	addCmd.Flags().
+		BoolVar(&cmd.SetAsDefault, "set-as-default", true,
+			"If enabled will set the activated provider as the default provider of the context")
 	addCmd.Flags().
 		StringArrayVarP(&cmd.Options, "option", "o", []string{}, "Provider option in the form KEY=VALUE")
+	addCmd.Flags().
+		StringVar(&cmd.VerifyChecksum, "verify-checksum", "",
+			"The expected sha256 of the provider binary, the provider isn't added on a mismatch")
+	addCmd.MarkFlagsMutuallyExclusive("verify-checksum", "from-existing")
 
 	return addCmd
 }
@@ -110,7 +122,19 @@ func (cmd *AddCmd) Run(ctx context.Context, devPodConfig *config.Config, args []
 			return fmt.Errorf("please specify either a URL or path, " +
 				"e.g. devpod provider add https://path/to/my/provider.yaml")
 		}
-		c, err := workspace.AddProvider(devPodConfig, providerName, args[0], log.Default)
+		providerRaw, providerSource, err := workspace.ResolveProvider(args[0], log.Default)
+		if err != nil {
+			return err
+		}
+
+		c, err := workspace.AddProviderRaw(workspace.ProviderParams{
+			DevPodConfig:   devPodConfig,
+			ProviderName:   providerName,
+			Source:         providerSource,
+			Raw:            providerRaw,
+			Log:            log.Default,
+			VerifyChecksum: cmd.VerifyChecksum,
+		})
 		if err != nil {
 			return err
 		}
@@ -121,15 +145,16 @@ func (cmd *AddCmd) Run(ctx context.Context, devPodConfig *config.Config, args []
 	log.Default.Donef("installed provider: providerName=%s", providerConfig.Name)
 	if cmd.Use {
 		configureErr := ConfigureProvider(ctx, ProviderOptionsConfig{
-			Provider:       providerConfig,
-			Context:        devPodConfig.DefaultContext,
-			UserOptions:    options,
-			Reconfigure:    true,
-			SkipRequired:   false,
-			SkipInit:       false,
-			SkipSubOptions: false,
-			SingleMachine:  &cmd.SingleMachine,
-			Log:            log.Default,
+			Provider:            providerConfig,
+			Context:             devPodConfig.DefaultContext,
+			UserOptions:         options,
+			Reconfigure:         true,
+			SkipRequired:        false,
+			SkipInit:            false,
+			SkipSubOptions:      false,
+			SingleMachine:       &cmd.SingleMachine,
+			KeepDefaultProvider: !cmd.SetAsDefault,
+			Log:                 log.Default,
 		})
 		if configureErr != nil {
 			devPodConfig, err := config.LoadConfig(cmd.Context, "")
@@ -144,6 +169,9 @@ func (cmd *AddCmd) Run(ctx context.Context, devPodConfig *config.Config, args []
 
 			return fmt.Errorf("configure provider: %w", configureErr)
 		}
+		if cmd.SetAsDefault {
+			log.Default.Donef("switched default provider: providerName=%s", providerConfig.Name)
+		}
 
 		return nil
 	}
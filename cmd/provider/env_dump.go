@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const redactedValue = "********"
+
+// redactedSuffixes are the suffixes of environment variables whose values are not printed.
+var redactedSuffixes = []string{"_TOKEN", "_SECRET", "_KEY", "_PASSWORD"}
+
+// EnvDumpCmd holds the env-dump cmd flags.
+type EnvDumpCmd struct {
+	*flags.GlobalFlags
+
+	Workspace string
+}
+
+// NewEnvDumpCmd creates a new command.
+func NewEnvDumpCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &EnvDumpCmd{
+		GlobalFlags: flags,
+	}
+	envDumpCmd := &cobra.Command{
+		Use:   "env-dump [provider]",
+		Short: "Print the environment passed to provider commands",
+		Long: `Prints the environment variables DevPod passes to the commands of a provider as KEY=VALUE,
+one per line. Values of variables ending in _TOKEN, _SECRET, _KEY or _PASSWORD and of provider
+options marked as password are redacted.
+Without --workspace a placeholder workspace is used, with --workspace the options resolved for
+that workspace are used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args, os.Stdout)
+		},
+		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.GetProviderSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	envDumpCmd.Flags().StringVar(&cmd.Workspace, "workspace", "",
+		"The id of a workspace to use the resolved options of")
+	return envDumpCmd
+}
+
+// Run runs the command logic.
+func (cmd *EnvDumpCmd) Run(ctx context.Context, args []string, w io.Writer) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	var (
+		workspaceConfig *provider.Workspace
+		machineConfig   *provider.Machine
+	)
+	providerName := devPodConfig.Current().DefaultProvider
+	if cmd.Workspace != "" {
+		workspaceConfig, err = provider.LoadWorkspaceConfig(devPodConfig.DefaultContext, cmd.Workspace)
+		if err != nil {
+			return fmt.Errorf("load workspace %s: %w", cmd.Workspace, err)
+		}
+		if workspaceConfig.Machine.ID != "" {
+			machineConfig, err = provider.LoadMachineConfig(devPodConfig.DefaultContext, workspaceConfig.Machine.ID)
+			if err != nil {
+				return fmt.Errorf("load machine %s: %w", workspaceConfig.Machine.ID, err)
+			}
+		}
+
+		providerName = workspaceConfig.Provider.Name
+		if len(args) > 0 && args[0] != providerName {
+			return fmt.Errorf("workspace %s uses provider %s, not %s", cmd.Workspace, providerName, args[0])
+		}
+	} else if len(args) > 0 {
+		providerName = args[0]
+	}
+	if providerName == "" {
+		return fmt.Errorf("please specify a provider")
+	}
+
+	providerWithOptions, err := workspace.FindProvider(devPodConfig, providerName, log.Default)
+	if err != nil {
+		return err
+	}
+	if workspaceConfig == nil {
+		workspaceConfig = &provider.Workspace{
+			ID:       "env-dump",
+			UID:      "env-dump",
+			Context:  devPodConfig.DefaultContext,
+			Provider: provider.WorkspaceProviderConfig{Name: providerName},
+		}
+	}
+
+	environ, err := provider.ToEnvironmentWithBinaries(provider.EnvironmentOptions{
+		Context:   devPodConfig.DefaultContext,
+		Workspace: workspaceConfig,
+		Machine:   machineConfig,
+		Options:   devPodConfig.ProviderOptions(providerName),
+		Config:    providerWithOptions.Config,
+		Log:       log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, env := range redactEnvironment(environ, passwordOptions(providerWithOptions.Config)) {
+		_, err = fmt.Fprintln(w, env)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// passwordOptions returns the names of the provider options marked as password.
+func passwordOptions(providerConfig *provider.ProviderConfig) []string {
+	names := []string{}
+	for name, option := range providerConfig.Options {
+		if option != nil && option.Password {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// redactEnvironment returns the sorted KEY=VALUE pairs of environ with the values of
+// sensitive keys and of the password options replaced.
+func redactEnvironment(environ []string, passwordOptions []string) []string {
+	retEnv := make([]string, 0, len(environ))
+	for _, env := range environ {
+		key, _, found := strings.Cut(env, "=")
+		if found && (isSensitiveKey(key) || slices.Contains(passwordOptions, key)) {
+			env = key + "=" + redactedValue
+		}
+		retEnv = append(retEnv, env)
+	}
+
+	slices.Sort(retEnv)
+	return retEnv
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToUpper(key)
+	return slices.ContainsFunc(redactedSuffixes, func(suffix string) bool {
+		return strings.HasSuffix(key, suffix)
+	})
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactEnvironment(t *testing.T) {
+	environ := []string{
+		"GITHUB_TOKEN=ghp_abc",
+		"AWS_SECRET=secret",
+		"PATH=/usr/bin",
+		"ssh_key=private",
+		"DB_PASSWORD=hunter2",
+		"KEYRING=plain",
+		"VAULT_CREDENTIAL=s.abc",
+	}
+
+	assert.Equal(t, []string{
+		"AWS_SECRET=********",
+		"DB_PASSWORD=********",
+		"GITHUB_TOKEN=********",
+		"KEYRING=plain",
+		"PATH=/usr/bin",
+		"VAULT_CREDENTIAL=********",
+		"ssh_key=********",
+	}, redactEnvironment(environ, []string{"VAULT_CREDENTIAL"}))
+}
+
+func TestPasswordOptions(t *testing.T) {
+	providerConfig := &provider.ProviderConfig{
+		Options: map[string]*types.Option{
+			"VAULT_CREDENTIAL": {Password: true},
+			"REGION":           {},
+		},
+	}
+
+	assert.Equal(t, []string{"VAULT_CREDENTIAL"}, passwordOptions(providerConfig))
+}
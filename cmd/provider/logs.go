@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// LogsCmd holds the logs cmd flags.
+type LogsCmd struct {
+	*flags.GlobalFlags
+
+	Last   int
+	Follow bool
+}
+
+// NewLogsCmd creates a new command.
+func NewLogsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &LogsCmd{
+		GlobalFlags: flags,
+	}
+	logsCmd := &cobra.Command{
+		Use:   "logs [provider]",
+		Short: "Show the output of provider commands",
+		Long: `Shows the output of the commands DevPod ran for the provider. Provider commands
+are only logged if the PROVIDER_LOG context option or DEVPOD_PROVIDER_LOG=true is set.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.GetProviderSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	logsCmd.Flags().IntVar(&cmd.Last, "last", 0, "Only print the last N lines of the log")
+	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Keep streaming new output of provider commands")
+	return logsCmd
+}
+
+// Run runs the command logic.
+func (cmd *LogsCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	providerName := devPodConfig.Current().DefaultProvider
+	if len(args) > 0 {
+		providerName = args[0]
+	} else if providerName == "" {
+		return fmt.Errorf("please specify a provider")
+	}
+	if devPodConfig.Current().Providers[providerName] == nil {
+		return fmt.Errorf("provider %s doesn't exist", providerName)
+	}
+
+	logFile, err := provider.GetProviderCommandLogFile(devPodConfig.DefaultContext, providerName)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G304 -- path is built from the provider directory
+	file, err := os.Open(logFile)
+	if errors.Is(err, os.ErrNotExist) {
+		if !cmd.Follow {
+			log.Default.Infof(
+				"No provider log found for %s, enable it with 'devpod context set-options -o %s=true'",
+				providerName,
+				config.ContextOptionProviderLog,
+			)
+			return nil
+		}
+
+		file, err = waitForLogFile(ctx, logFile)
+	}
+	if err != nil {
+		return fmt.Errorf("open provider log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	err = printLastLines(file, os.Stdout, cmd.Last)
+	if err != nil {
+		return err
+	}
+	if !cmd.Follow {
+		return nil
+	}
+
+	return followLog(ctx, file, os.Stdout)
+}
+
+// printLastLines copies the file to w, or only its last n lines if n is greater than zero.
+func printLastLines(file io.Reader, w io.Writer, n int) error {
+	if n <= 0 {
+		_, err := io.Copy(w, file)
+		return err
+	}
+
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read provider log: %w", err)
+	}
+
+	for _, line := range lines {
+		_, err := fmt.Fprintln(w, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func waitForLogFile(ctx context.Context, logFile string) (*os.File, error) {
+	for {
+		// #nosec G304 -- path is built from the provider directory
+		file, err := os.Open(logFile)
+		if !errors.Is(err, os.ErrNotExist) {
+			return file, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// followLog polls the file for appended output until the context is cancelled.
+func followLog(ctx context.Context, file *os.File, w io.Writer) error {
+	for {
+		_, err := io.Copy(w, file)
+		if err != nil {
+			return fmt.Errorf("read provider log: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
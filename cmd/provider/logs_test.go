@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintLastLines(t *testing.T) {
+	input := "one\ntwo\nthree\n"
+
+	out := &bytes.Buffer{}
+	if err := printLastLines(strings.NewReader(input), out, 2); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "two\nthree\n" {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+
+	out.Reset()
+	if err := printLastLines(strings.NewReader(input), out, 0); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != input {
+		t.Fatalf("unexpected output %q", out.String())
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// MetricsCmd holds the metrics cmd flags.
+type MetricsCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// NewMetricsCmd creates a new command.
+func NewMetricsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &MetricsCmd{
+		GlobalFlags: flags,
+	}
+	metricsCmd := &cobra.Command{
+		Use:   "metrics [provider]",
+		Short: "Show startup statistics of a provider",
+		Long: `Shows how often workspaces of the provider were started with devpod up, the share of
+successful starts and the min, max and average startup time of the successful ones.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.GetProviderSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	metricsCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return metricsCmd
+}
+
+// Run runs the command logic.
+func (cmd *MetricsCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	providerName := devPodConfig.Current().DefaultProvider
+	if len(args) > 0 {
+		providerName = args[0]
+	} else if providerName == "" {
+		return fmt.Errorf("please specify a provider")
+	}
+
+	metricsFile, err := provider.GetUpMetricsFile()
+	if err != nil {
+		return err
+	}
+	metrics, err := provider.LoadUpMetrics(metricsFile)
+	if err != nil {
+		return err
+	}
+
+	summary := provider.SummarizeUpMetrics(metrics, providerName)
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	} else if summary.Count == 0 {
+		log.Default.Infof("No metrics found for provider %s, metrics are recorded on devpod up", providerName)
+		return nil
+	}
+
+	table.Print([]string{
+		"Provider",
+		"Runs",
+		"Success Rate",
+		"Min",
+		"Max",
+		"Avg",
+	}, [][]string{{
+		summary.Provider,
+		strconv.Itoa(summary.Count),
+		fmt.Sprintf("%.0f%%", summary.SuccessRate()*100),
+		formatSeconds(summary.MinSeconds),
+		formatSeconds(summary.MaxSeconds),
+		formatSeconds(summary.AvgSeconds),
+	}})
+	return nil
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 1, 64) + "s"
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// OptionSchemaCmd holds the option-schema cmd flags.
+type OptionSchemaCmd struct {
+	*flags.GlobalFlags
+
+	Hidden bool
+	Output string
+}
+
+// NewOptionSchemaCmd creates a new command.
+func NewOptionSchemaCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &OptionSchemaCmd{
+		GlobalFlags: flags,
+	}
+	optionSchemaCmd := &cobra.Command{
+		Use:   "option-schema [provider]",
+		Short: "Prints a JSON Schema of the options of a provider",
+		Long: `Prints a JSON Schema document describing the type, description, default value and
+allowed values of every option of a provider, e.g. to generate forms for the options.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return completion.GetProviderSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	optionSchemaCmd.Flags().
+		BoolVar(&cmd.Hidden, "hidden", false, "If true, will also include hidden options.")
+	optionSchemaCmd.Flags().
+		StringVar(&cmd.Output, "output", "", "The file to write the schema to. Defaults to stdout")
+	return optionSchemaCmd
+}
+
+// Run runs the command logic.
+func (cmd *OptionSchemaCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	providerName := devPodConfig.Current().DefaultProvider
+	if len(args) > 0 {
+		providerName = args[0]
+	} else if providerName == "" {
+		return fmt.Errorf("please specify a provider")
+	}
+
+	providerWithOptions, err := workspace.FindProvider(
+		devPodConfig,
+		providerName,
+		log.Default.ErrorStreamOnly(),
+	)
+	if err != nil {
+		return err
+	}
+
+	options := MergeDynamicOptions(
+		providerWithOptions.Config.Options,
+		devPodConfig.DynamicProviderOptionDefinitions(providerWithOptions.Config.Name),
+	)
+	schema := provider2.NewOptionSchema(providerWithOptions.Config, options, cmd.Hidden)
+	out, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if cmd.Output == "" {
+		fmt.Println(string(out))
+		return nil
+	}
+
+	err = os.WriteFile(cmd.Output, append(out, '\n'), 0o600)
+	if err != nil {
+		return fmt.Errorf("write schema: %w", err)
+	}
+
+	log.Default.Donef("Wrote option schema of provider %s to %s", providerWithOptions.Config.Name, cmd.Output)
+	return nil
+}
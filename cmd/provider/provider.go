@@ -16,10 +16,15 @@ func NewProviderCmd(flags *flags.GlobalFlags) *cobra.Command {
 	providerCmd.AddCommand(NewListAvailableCmd(flags))
 	providerCmd.AddCommand(NewUseCmd(flags))
 	providerCmd.AddCommand(NewOptionsCmd(flags))
+	providerCmd.AddCommand(NewOptionSchemaCmd(flags))
 	providerCmd.AddCommand(NewDeleteCmd(flags))
 	providerCmd.AddCommand(NewAddCmd(flags))
 	providerCmd.AddCommand(NewUpdateCmd(flags))
 	providerCmd.AddCommand(NewSetOptionsCmd(flags))
 	providerCmd.AddCommand(NewRenameCmd(flags))
+	providerCmd.AddCommand(NewLogsCmd(flags))
+	providerCmd.AddCommand(NewMetricsCmd(flags))
+	providerCmd.AddCommand(NewEnvDumpCmd(flags))
+	providerCmd.AddCommand(NewUpgradeWorkspaceCmd(flags))
 	return providerCmd
 }
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/version"
+	workspace "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// UpgradeWorkspaceCmd holds the cmd flags.
+type UpgradeWorkspaceCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewUpgradeWorkspaceCmd creates a new command.
+func NewUpgradeWorkspaceCmd(globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &UpgradeWorkspaceCmd{
+		GlobalFlags: globalFlags,
+	}
+
+	return &cobra.Command{
+		Use:   "upgrade-workspace <workspace-name>",
+		Short: "Upgrades the agent of a running workspace to the local DevPod version",
+		Long: `Compares the version of the agent on the workspace machine with the local DevPod
+version and reinstalls the agent if they differ. Only the agent binary is replaced, the dev
+container is not rebuilt or restarted.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *UpgradeWorkspaceCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace.Get(ctx, workspace.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	} else if client.AgentLocal() {
+		log.Default.Infof(
+			"Workspace %s runs the agent of the local DevPod binary, nothing to upgrade",
+			client.Workspace(),
+		)
+		return nil
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status != clientpkg.StatusRunning {
+		return fmt.Errorf(
+			"workspace %s is %s, start it with devpod up first",
+			client.Workspace(),
+			strings.ToLower(string(status)),
+		)
+	}
+
+	localVersion := version.GetVersion()
+	remoteVersion := remoteAgentVersion(ctx, client)
+	if remoteVersion == localVersion {
+		log.Default.Donef(
+			"Agent of workspace %s is up to date (%s)",
+			client.Workspace(),
+			localVersion,
+		)
+		return nil
+	} else if remoteVersion == "" {
+		remoteVersion = "unknown"
+	}
+
+	log.Default.Infof(
+		"Upgrading agent of workspace %s from %s to %s",
+		client.Workspace(),
+		remoteVersion,
+		localVersion,
+	)
+	timeout := config.ParseTimeOption(devPodConfig, config.ContextOptionAgentInjectTimeout)
+	err = agent.InjectAgent(&agent.InjectOptions{
+		Ctx: ctx,
+		Exec: func(
+			ctx context.Context,
+			command string,
+			stdin io.Reader,
+			stdout io.Writer,
+			stderr io.Writer,
+		) error {
+			return client.Command(ctx, clientpkg.CommandOptions{
+				Command: command,
+				Stdin:   stdin,
+				Stdout:  stdout,
+				Stderr:  stderr,
+			})
+		},
+		RemoteAgentPath: client.AgentPath(),
+		DownloadURL:     client.AgentURL(),
+		Log:             log.Default.ErrorStreamOnly(),
+		Timeout:         timeout,
+		ForceReinstall:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("inject agent: %w", err)
+	}
+
+	log.Default.Donef("Upgraded agent of workspace %s to %s", client.Workspace(), localVersion)
+	return nil
+}
+
+// remoteAgentVersion returns the version of the agent on the workspace machine or an empty
+// string if it isn't installed.
+func remoteAgentVersion(ctx context.Context, client clientpkg.WorkspaceClient) string {
+	stdout := &bytes.Buffer{}
+	err := client.Command(ctx, clientpkg.CommandOptions{
+		Command: fmt.Sprintf("'%s' version", client.AgentPath()),
+		Stdout:  stdout,
+		Stderr:  io.Discard,
+	})
+	if err != nil {
+		log.Default.Debugf("get remote agent version: %v", err)
+		return ""
+	}
+
+	return strings.TrimSpace(stdout.String())
+}
@@ -133,7 +133,9 @@ type ProviderOptionsConfig struct {
 	SkipInit       bool
 	SkipSubOptions bool
 	SingleMachine  *bool
-	Log            log.Logger
+	// KeepDefaultProvider leaves the default provider of the context unchanged
+	KeepDefaultProvider bool
+	Log                 log.Logger
 }
 
 func ConfigureProvider(ctx context.Context, cfg ProviderOptionsConfig) error {
@@ -143,8 +145,10 @@ func ConfigureProvider(ctx context.Context, cfg ProviderOptionsConfig) error {
 	}
 
 	// set options
-	defaultContext := devPodConfig.Current()
-	defaultContext.DefaultProvider = cfg.Provider.Name
+	if !cfg.KeepDefaultProvider {
+		defaultContext := devPodConfig.Current()
+		defaultContext.DefaultProvider = cfg.Provider.Name
+	}
 
 	// save provider config
 	err = config.SaveConfig(devPodConfig)
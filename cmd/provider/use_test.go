@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configureTestProvider(t *testing.T, keepDefaultProvider bool) string {
+	t.Helper()
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	devPodConfig, err := config.LoadConfig("", "")
+	require.NoError(t, err)
+	devPodConfig.Current().DefaultProvider = "docker"
+	require.NoError(t, config.SaveConfig(devPodConfig))
+
+	err = ConfigureProvider(context.Background(), ProviderOptionsConfig{
+		Provider:            &provider2.ProviderConfig{Name: "ssh"},
+		Context:             devPodConfig.DefaultContext,
+		Reconfigure:         true,
+		SkipInit:            true,
+		KeepDefaultProvider: keepDefaultProvider,
+		Log:                 log.Discard,
+	})
+	require.NoError(t, err)
+
+	devPodConfig, err = config.LoadConfig("", "")
+	require.NoError(t, err)
+	return devPodConfig.Current().DefaultProvider
+}
+
+func TestConfigureProvider_SetsDefaultProvider(t *testing.T) {
+	assert.Equal(t, "ssh", configureTestProvider(t, false))
+}
+
+func TestConfigureProvider_KeepDefaultProvider(t *testing.T) {
+	assert.Equal(t, "docker", configureTestProvider(t, true))
+}
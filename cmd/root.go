@@ -17,7 +17,9 @@ import (
 	"github.com/skevetter/devpod/cmd/pro"
 	"github.com/skevetter/devpod/cmd/provider"
 	"github.com/skevetter/devpod/cmd/use"
+	"github.com/skevetter/devpod/cmd/workspace"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/daemon/host"
 	"github.com/skevetter/devpod/pkg/telemetry"
 	log2 "github.com/skevetter/log"
 	"github.com/skevetter/log/terminal"
@@ -64,6 +66,7 @@ func NewRootCmd() *cobra.Command {
 				telemetry.StartCLI(devPodConfig, cobraCmd)
 			}
 
+			resumeScheduleDaemon(cobraCmd)
 			return nil
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -97,6 +100,11 @@ func Execute() {
 			os.Exit(execExitErr.ExitCode())
 		}
 
+		if waitTimeoutErr, ok := err.(*workspace.WaitTimeoutError); ok {
+			log2.Default.Error(waitTimeoutErr)
+			os.Exit(workspace.WaitTimeoutExitCode)
+		}
+
 		if globalFlags.Debug {
 			log2.Default.Fatalf("%+v", err)
 		} else {
@@ -128,6 +136,9 @@ func BuildRoot() *cobra.Command {
 	rootCmd.AddCommand(helper.NewHelperCmd(globalFlags))
 	rootCmd.AddCommand(ide.NewIDECmd(globalFlags))
 	rootCmd.AddCommand(machine.NewMachineCmd(globalFlags))
+	workspaceCmd := workspace.NewWorkspaceCmd(globalFlags)
+	workspaceCmd.AddCommand(NewWorkspaceAttachCmd(globalFlags))
+	rootCmd.AddCommand(workspaceCmd)
 	rootCmd.AddCommand(context.NewContextCmd(globalFlags))
 	rootCmd.AddCommand(pro.NewProCmd(globalFlags, log2.Default))
 	rootCmd.AddCommand(NewUpCmd(globalFlags))
@@ -145,12 +156,30 @@ func BuildRoot() *cobra.Command {
 	rootCmd.AddCommand(NewUpgradeCmd())
 	rootCmd.AddCommand(NewTroubleshootCmd(globalFlags))
 	rootCmd.AddCommand(NewPingCmd(globalFlags))
+	rootCmd.AddCommand(NewCompletionCmd())
 
 	inheritCommandFlagsFromEnvironment(rootCmd)
 
 	return rootCmd
 }
 
+// resumeScheduleDaemon restarts the schedule daemon if schedules are pending, e.g. after a
+// reboot. Agent and helper commands are skipped, they run in workspaces or are the daemon.
+func resumeScheduleDaemon(cobraCmd *cobra.Command) {
+	topLevel := cobraCmd
+	for topLevel.HasParent() && topLevel.Parent().HasParent() {
+		topLevel = topLevel.Parent()
+	}
+	if topLevel.Name() == "agent" || topLevel.Name() == "helper" {
+		return
+	}
+
+	err := host.ResumeDaemon()
+	if err != nil {
+		log2.Default.Debugf("resume schedule daemon: %v", err)
+	}
+}
+
 func inheritCommandFlagsFromEnvironment(cmd *cobra.Command) {
 	inheritFlagsFromEnvironment(cmd.Flags())
 	inheritFlagsFromEnvironment(cmd.PersistentFlags())
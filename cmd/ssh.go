@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -22,21 +24,42 @@ import (
 	client2 "github.com/skevetter/devpod/pkg/client"
 	"github.com/skevetter/devpod/pkg/client/clientimplementation"
 	"github.com/skevetter/devpod/pkg/config"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/gpg"
+	devpodlog "github.com/skevetter/devpod/pkg/log"
 	"github.com/skevetter/devpod/pkg/port"
 	"github.com/skevetter/devpod/pkg/provider"
 	devssh "github.com/skevetter/devpod/pkg/ssh"
+	devsshagent "github.com/skevetter/devpod/pkg/ssh/agent"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
+	"github.com/skevetter/devpod/pkg/token"
 	"github.com/skevetter/devpod/pkg/tunnel"
+	"github.com/skevetter/devpod/pkg/util"
 	workspace2 "github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
 )
 
 const (
 	DisableSSHKeepAlive time.Duration = 0 * time.Second
+
+	// defaultKeepAliveInterval is shorter than the 60s idle timeout of common NATs and load
+	// balancers
+	defaultKeepAliveInterval = 30 * time.Second
+
+	// escapeCharNone disables the escape character of the OpenSSH client
+	escapeCharNone = "none"
 )
 
+// verboseTunnelPackages are the packages whose debug output is printed with --verbose-tunnel.
+var verboseTunnelPackages = []string{
+	"github.com/skevetter/devpod/pkg/ssh",
+	"github.com/skevetter/devpod/pkg/tunnel",
+	"github.com/skevetter/devpod/pkg/devcontainer/sshtunnel",
+}
+
 // SSHCmd holds the ssh cmd flags.
 type SSHCmd struct {
 	*flags.GlobalFlags
@@ -48,15 +71,27 @@ type SSHCmd struct {
 	SetEnvVars          []string
 
 	Stdio                     bool
+	Multiplexed               bool
+	Tmux                      bool
 	JumpContainer             bool
 	ReuseSSHAuthSock          string
 	AgentForwarding           bool
+	AgentForwardingIdentity   string
 	GPGAgentForwarding        bool
 	GitSSHSignatureForwarding bool
 	GitSSHSigningKey          string
+	KnownHostsFile            string
+	VerboseTunnel             bool
+	ProxyCommand              string
+	ConfigureSSH              bool
+	EscapeChar                string
+	Compress                  bool
+	X11Forwarding             bool
+	TrustedX11                bool
 
 	// ssh keepalive options
 	SSHKeepAliveInterval time.Duration `json:"sshKeepAliveInterval,omitempty"`
+	NoKeepAlive          bool          `json:"noKeepAlive,omitempty"`
 
 	StartServices   bool
 	TermMode        string
@@ -65,6 +100,9 @@ type SSHCmd struct {
 	Command string
 	User    string
 	WorkDir string
+
+	agentForwardingFingerprint string
+	startServicesChanged       bool
 }
 
 // NewSSHCmd creates a new ssh command.
@@ -82,6 +120,7 @@ func NewSSHCmd(f *flags.GlobalFlags) *cobra.Command {
 			}
 
 			localOnly := cmd.Stdio
+			cmd.startServicesChanged = cobraCmd.Flags().Changed("start-services")
 
 			ctx := cobraCmd.Context()
 			client, err := workspace2.Get(ctx, workspace2.GetOptions{
@@ -96,7 +135,16 @@ func NewSSHCmd(f *flags.GlobalFlags) *cobra.Command {
 				return err
 			}
 
-			return cmd.Run(ctx, devPodConfig, client, log.Default.ErrorStreamOnly())
+			logger := log.Default.ErrorStreamOnly()
+			if cmd.VerboseTunnel {
+				logger = devpodlog.NewPackageDebugLogger(
+					logger,
+					log.NewStreamLogger(os.Stderr, os.Stderr, logrus.DebugLevel),
+					verboseTunnelPackages...,
+				)
+			}
+
+			return cmd.Run(ctx, devPodConfig, client, logger)
 		},
 		ValidArgsFunction: func(rootCmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			return completion.GetWorkspaceSuggestions(
@@ -121,7 +169,8 @@ func NewSSHCmd(f *flags.GlobalFlags) *cobra.Command {
 				"are to be reverse forwarded to the given local host, service name, and port, or Unix socket.")
 	sshCmd.Flags().
 		StringArrayVarP(&cmd.SendEnvVars, "send-env", "", []string{},
-			"Specifies which local env variables shall be sent to the container.")
+			"Specifies which local env variables shall be sent to the container. Only variables "+
+				"matching the SSH_ACCEPT_ENV_PATTERN context option are accepted")
 	sshCmd.Flags().
 		StringArrayVarP(&cmd.SetEnvVars, "set-env", "", []string{}, "Specifies env variables to be set in the container.")
 	sshCmd.Flags().
@@ -133,6 +182,9 @@ func NewSSHCmd(f *flags.GlobalFlags) *cobra.Command {
 	sshCmd.Flags().StringVar(&cmd.WorkDir, "workdir", "", "The working directory in the container")
 	sshCmd.Flags().
 		BoolVar(&cmd.AgentForwarding, "agent-forwarding", true, "If true forward the local ssh keys to the remote machine")
+	sshCmd.Flags().
+		StringVar(&cmd.AgentForwardingIdentity, "agent-forwarding-identity", "",
+			"Path to a ssh key, if set only this key of the local ssh agent is forwarded to the remote machine")
 	sshCmd.Flags().
 		StringVar(&cmd.ReuseSSHAuthSock, "reuse-ssh-auth-sock", "",
 			"If set, the SSH_AUTH_SOCK is expected to already be available in the workspace "+
@@ -143,12 +195,53 @@ func NewSSHCmd(f *flags.GlobalFlags) *cobra.Command {
 			"If true forward the local gpg-agent to the remote machine")
 	sshCmd.Flags().
 		BoolVar(&cmd.Stdio, "stdio", false, "If true will tunnel connection through stdout and stdin")
+	sshCmd.Flags().
+		BoolVar(&cmd.Multiplexed, "multiplexed", false,
+			"If true will share a single ssh connection between sessions via an OpenSSH ControlMaster socket")
+	sshCmd.Flags().
+		StringVar(&cmd.KnownHostsFile, "known-hosts-file", "",
+			"The known hosts file used to verify the workspace host key. Defaults to ~/.devpod/known_hosts")
+	sshCmd.Flags().
+		StringVar(&cmd.ProxyCommand, "proxy-command", "",
+			"Connects through the OpenSSH client with the given ProxyCommand, e.g. 'ssh -W %h:%p bastion'. "+
+				"%h and %p are replaced with the workspace host and port")
+	sshCmd.Flags().
+		BoolVar(&cmd.ConfigureSSH, "configure-ssh", false,
+			"If true will also write the --proxy-command into the ssh config entry of the workspace")
+	sshCmd.Flags().
+		StringVar(&cmd.EscapeChar, "escape-char", "",
+			"The escape character of the OpenSSH client used with --multiplexed or --proxy-command, "+
+				"a single character or none to disable it. The built-in ssh client has no escape character")
+	sshCmd.Flags().
+		BoolVarP(&cmd.Compress, "compress", "C", false,
+			"If true enables ssh compression of the OpenSSH client used with --multiplexed or --proxy-command. "+
+				"Helps on slow networks but hurts performance on fast local networks. Has no effect with --stdio")
+	sshCmd.Flags().
+		BoolVar(&cmd.X11Forwarding, "x11-forwarding", false,
+			"If true forwards the local X11 display to the workspace through the OpenSSH client")
+	sshCmd.Flags().
+		BoolVar(&cmd.TrustedX11, "trusted-x11", false,
+			"If true forwards the local X11 display as trusted client, like ssh -Y. Implies --x11-forwarding")
+	sshCmd.Flags().
+		BoolVar(&cmd.VerboseTunnel, "verbose-tunnel", false,
+			"If true prints the debug output of the ssh tunnel to stderr without enabling --debug for everything else")
+	sshCmd.Flags().
+		BoolVar(&cmd.Tmux, "tmux", false,
+			"If true and a tmux session is active will open the ssh session in a new tmux window")
 	sshCmd.Flags().
 		BoolVar(&cmd.StartServices, "start-services", true,
 			"If false will not start any port-forwarding or git / docker credentials helper")
 	sshCmd.Flags().
-		DurationVar(&cmd.SSHKeepAliveInterval, "ssh-keepalive-interval", 55*time.Second,
-			"How often should keepalive request be made (55s)")
+		DurationVar(&cmd.SSHKeepAliveInterval, "keep-alive-interval", defaultKeepAliveInterval,
+			"How often keepalive requests are sent, which keeps the connection open and the "+
+				"workspace from being stopped as idle while the session is open")
+	sshCmd.Flags().
+		DurationVar(&cmd.SSHKeepAliveInterval, "ssh-keepalive-interval", defaultKeepAliveInterval,
+			"How often keepalive requests are sent")
+	_ = sshCmd.Flags().
+		MarkDeprecated("ssh-keepalive-interval", "use --keep-alive-interval instead")
+	sshCmd.Flags().
+		BoolVar(&cmd.NoKeepAlive, "no-keep-alive", false, "If true no keepalive requests are sent")
 	sshCmd.Flags().
 		StringVar(&cmd.GitSSHSigningKey, "git-ssh-signing-key", "",
 			"The SSH signing key to use for git commit signing inside the workspace")
@@ -205,6 +298,63 @@ func (cmd *SSHCmd) Run(
 		cmd.Context = devPodConfig.DefaultContext
 	}
 
+	if cmd.Tmux && !cmd.Stdio {
+		if tmuxActive() {
+			return cmd.runInTmux(ctx, client.Workspace(), log)
+		}
+
+		log.Info("tmux is not running, connecting directly")
+	}
+
+	cmd.X11Forwarding = cmd.X11Forwarding || cmd.TrustedX11
+	if cmd.ConfigureSSH && (cmd.ProxyCommand != "" || cmd.X11Forwarding) {
+		err := cmd.saveProxyCommand(devPodConfig, client, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	useOpenSSH := (cmd.Multiplexed || cmd.ProxyCommand != "" || cmd.X11Forwarding) && !cmd.Stdio
+	if cmd.X11Forwarding && !cmd.Stdio {
+		err := checkX11Display(log)
+		if err != nil {
+			return err
+		}
+	}
+	if cmd.EscapeChar != "" {
+		err := validateEscapeChar(cmd.EscapeChar)
+		if err != nil {
+			return err
+		} else if !useOpenSSH && cmd.EscapeChar != escapeCharNone {
+			log.Warnf("--escape-char is only used with --multiplexed or --proxy-command")
+		}
+	}
+	if cmd.Compress && !useOpenSSH {
+		log.Warnf("--compress is only used with --multiplexed or --proxy-command, " +
+			"the built-in ssh client does not support compression")
+	}
+	if cmd.AgentForwardingIdentity != "" {
+		err := cmd.validateAgentForwardingIdentity(useOpenSSH)
+		if err != nil {
+			return err
+		}
+
+		fingerprint, err := devsshagent.IdentityFingerprint(util.ExpandTilde(cmd.AgentForwardingIdentity))
+		if err != nil {
+			return err
+		}
+		cmd.agentForwardingFingerprint = fingerprint
+	}
+
+	if useOpenSSH {
+		err := cmd.validateOpenSSHFlags(devPodConfig, log)
+		if err != nil {
+			return err
+		}
+
+		return cmd.runOpenSSH(ctx, devPodConfig, client, log)
+	}
+
 	workspaceClient, ok := client.(client2.WorkspaceClient)
 	if ok {
 		return cmd.jumpContainer(ctx, devPodConfig, workspaceClient, log)
@@ -221,6 +371,271 @@ func (cmd *SSHCmd) Run(
 	return nil
 }
 
+// runOpenSSH connects through the OpenSSH client. With --multiplexed it establishes a
+// ControlMaster on the first connection and reuses it for all subsequent sessions to the workspace.
+func (cmd *SSHCmd) runOpenSSH(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	client client2.BaseWorkspaceClient,
+	log log.Logger,
+) error {
+	sshBinary, err := exec.LookPath("ssh")
+	if err != nil {
+		return fmt.Errorf(
+			"find ssh binary, --multiplexed and --proxy-command require an OpenSSH client: %w",
+			err,
+		)
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	controlPath := "none"
+	if cmd.Multiplexed {
+		controlPath, err = devssh.ResolveControlPath(
+			devPodConfig.ContextOption(config.ContextOptionSSHControlPath),
+			client.Workspace(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	knownHostsFile, err := cmd.knownHostsFile(devPodConfig)
+	if err != nil {
+		return err
+	}
+
+	args := devssh.MultiplexArgs(devssh.MultiplexOptions{
+		ExecPath:        execPath,
+		Context:         cmd.Context,
+		Workspace:       client.Workspace(),
+		User:            cmd.User,
+		Workdir:         cmd.WorkDir,
+		DevPodHome:      cmd.DevPodHome,
+		ControlPath:     controlPath,
+		AgentForwarding: cmd.AgentForwarding,
+		TTY:             cmd.Command == "" && term.IsTerminal(int(os.Stdin.Fd())), // #nosec G115 -- fd is always a valid file descriptor
+		Command:         cmd.Command,
+		KnownHostsFile:  knownHostsFile,
+		ProxyCommand:    cmd.ProxyCommand,
+		EscapeChar:      cmd.EscapeChar,
+		Compress: cmd.Compress ||
+			devPodConfig.ContextOption(config.ContextOptionSSHCompress) == config.BoolTrue,
+		X11Forwarding: cmd.X11Forwarding,
+		X11Trusted:    cmd.TrustedX11,
+		SendEnv:       cmd.SendEnvVars,
+		SetEnv:        cmd.SetEnvVars,
+
+		KeepAliveInterval: cmd.keepAliveInterval(),
+	})
+	if cmd.Multiplexed {
+		log.Debugf("Connecting via ControlMaster socket %s", controlPath)
+	}
+
+	// #nosec G204 -- arguments are built from the workspace configuration
+	sshCmd := exec.CommandContext(ctx, sshBinary, args...)
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stdout = os.Stdout
+	sshCmd.Stderr = os.Stderr
+	return sshCmd.Run()
+}
+
+// validateAgentForwardingIdentity returns an error if the identity filter cannot be applied,
+// only the built-in ssh client forwards the agent itself.
+func (cmd *SSHCmd) validateAgentForwardingIdentity(useOpenSSH bool) error {
+	if cmd.Stdio {
+		return errors.New("--agent-forwarding-identity cannot be used with --stdio")
+	} else if useOpenSSH {
+		return errors.New(
+			"--agent-forwarding-identity cannot be used with --multiplexed or --proxy-command",
+		)
+	}
+
+	return nil
+}
+
+// validateOpenSSHFlags returns an error for flags the OpenSSH client used with --multiplexed or
+// --proxy-command can't honor. Port forwarding, gpg agent forwarding and the credentials
+// services need the built-in ssh client.
+func (cmd *SSHCmd) validateOpenSSHFlags(devPodConfig *config.Config, log log.Logger) error {
+	unsupported := []string{}
+	if len(cmd.ForwardPorts) > 0 {
+		unsupported = append(unsupported, "--forward-ports")
+	}
+	if len(cmd.ReverseForwardPorts) > 0 {
+		unsupported = append(unsupported, "--reverse-forward-ports")
+	}
+	if cmd.ForwardPortsTimeout != "" {
+		unsupported = append(unsupported, "--forward-ports-timeout")
+	}
+	if cmd.GPGAgentForwarding {
+		unsupported = append(unsupported, "--gpg-agent-forwarding")
+	} else if devPodConfig.ContextOption(config.ContextOptionGPGAgentForwarding) == config.BoolTrue {
+		log.Warnf("the gpg agent is not forwarded with --multiplexed or --proxy-command")
+	}
+	if cmd.StartServices && cmd.startServicesChanged {
+		unsupported = append(unsupported, "--start-services")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf(
+			"%s cannot be used with --multiplexed, --proxy-command or --x11-forwarding",
+			strings.Join(unsupported, ", "),
+		)
+	}
+
+	// only validates the format of --set-env, the variables are passed to OpenSSH as SetEnv
+	_, err := cmd.retrieveEnVars()
+	return err
+}
+
+// validateEscapeChar returns an error if char is neither a single printable ASCII character nor none.
+func validateEscapeChar(char string) error {
+	if char == escapeCharNone || (len(char) == 1 && char[0] > ' ' && char[0] < 0x7f) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid --escape-char %q, expected a single ASCII character or %s", char, escapeCharNone)
+}
+
+// checkX11Display verifies that there is a local X server the OpenSSH client can forward.
+func checkX11Display(log log.Logger) error {
+	switch runtime.GOOS {
+	case "darwin":
+		log.Info("X11 forwarding requires XQuartz to be installed and running")
+	case "windows":
+		if os.Getenv("DISPLAY") == "" {
+			return errors.New("--x11-forwarding requires a running X server, e.g. VcXsrv, and DISPLAY to be set")
+		}
+	default:
+		if os.Getenv("DISPLAY") == "" {
+			return errors.New("--x11-forwarding requires the DISPLAY environment variable to be set")
+		}
+	}
+
+	return nil
+}
+
+// saveProxyCommand writes the --proxy-command and X11 forwarding into the ssh config entry of the
+// workspace, so IDEs connecting via the ssh config use it as well.
+func (cmd *SSHCmd) saveProxyCommand(
+	devPodConfig *config.Config,
+	client client2.BaseWorkspaceClient,
+	log log.Logger,
+) error {
+	workspaceConfig := client.WorkspaceConfig()
+	sshConfigPath, sshConfigIncludePath, err := devssh.ResolveSSHConfigPaths(
+		devPodConfig,
+		workspaceConfig.SSHConfigPath,
+		workspaceConfig.SSHConfigIncludePath,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	}
+	user, workdir := "root", ""
+	gpgAgent := devPodConfig.ContextOption(config.ContextOptionGPGAgentForwarding) == config.BoolTrue
+	if result != nil {
+		user = config2.GetRemoteUser(result)
+		workdir = resultWorkdir(result, workspaceConfig.Source.GitSubPath)
+	}
+
+	return devssh.UpdateSSHConfig(devssh.SSHConfigParams{
+		SSHConfigPath:        sshConfigPath,
+		SSHConfigIncludePath: sshConfigIncludePath,
+		Context:              client.Context(),
+		Workspace:            client.Workspace(),
+		User:                 user,
+		Workdir:              workdir,
+		GPGAgent:             gpgAgent,
+		DevPodHome:           os.Getenv(config.EnvHome),
+		Provider:             client.Provider(),
+		IdentityFile:         devssh.GetWorkspaceIdentityFile(client.Context(), client.Workspace()),
+		Log:                  log,
+	}, func(params *devssh.SSHConfigParams) {
+		params.User = cmp.Or(cmd.User, params.User)
+		params.Command = cmp.Or(cmd.ProxyCommand, params.Command)
+		params.GPGAgent = params.GPGAgent || cmd.GPGAgentForwarding
+		params.X11Forwarding = params.X11Forwarding || cmd.X11Forwarding
+		params.X11Trusted = params.X11Trusted || cmd.TrustedX11
+	})
+}
+
+// knownHostsFile returns the known hosts file from the flag, the context option or the default.
+func (cmd *SSHCmd) knownHostsFile(devPodConfig *config.Config) (string, error) {
+	knownHostsFile := cmd.KnownHostsFile
+	if knownHostsFile == "" {
+		knownHostsFile = devPodConfig.ContextOption(config.ContextOptionSSHKnownHostsFile)
+	}
+
+	return devssh.ResolveKnownHostsFile(knownHostsFile)
+}
+
+// tmuxActive returns true if tmux is installed and we are running inside a tmux session.
+func tmuxActive() bool {
+	if os.Getenv("TMUX") == "" {
+		return false
+	}
+
+	_, err := exec.LookPath("tmux")
+	return err == nil
+}
+
+// runInTmux runs the current devpod ssh invocation with tmux disabled in a new tmux window.
+func (cmd *SSHCmd) runInTmux(ctx context.Context, workspace string, log log.Logger) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	workdir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{execPath}, disableTmuxFlag(os.Args[1:])...)
+	log.Debugf("Opening ssh session in new tmux window")
+
+	// #nosec G204 -- the command is the devpod binary itself
+	tmuxCmd := exec.CommandContext(
+		ctx,
+		"tmux",
+		"new-window",
+		"-n", "devpod:"+workspace,
+		"-c", workdir,
+		shellescape.QuoteCommand(args),
+	)
+	out, err := tmuxCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("open tmux window: %s: %w", strings.TrimSpace(string(out)), err)
+	}
+
+	return nil
+}
+
+// disableTmuxFlag replaces the --tmux flags of args with --tmux=false, so the command neither
+// wraps itself again nor picks up DEVPOD_TMUX.
+func disableTmuxFlag(args []string) []string {
+	filtered := []string{}
+	for i, arg := range args {
+		if arg == "--" {
+			filtered = append(filtered, "--tmux=false")
+			return append(filtered, args[i:]...)
+		} else if arg == "--tmux" || strings.HasPrefix(arg, "--tmux=") {
+			continue
+		}
+		filtered = append(filtered, arg)
+	}
+
+	return append(filtered, "--tmux=false")
+}
+
 func (cmd *SSHCmd) jumpContainerTailscale(
 	ctx context.Context,
 	devPodConfig *config.Config,
@@ -283,8 +698,8 @@ func (cmd *SSHCmd) jumpContainerTailscale(
 
 	// Handle ssh stdio mode
 	if cmd.Stdio {
-		if cmd.SSHKeepAliveInterval != DisableSSHKeepAlive {
-			go startSSHKeepAlive(ctx, toolSSHClient, cmd.SSHKeepAliveInterval, log)
+		if interval := cmd.keepAliveInterval(); interval != DisableSSHKeepAlive {
+			go startSSHKeepAlive(ctx, toolSSHClient, interval, log)
 		}
 
 		return client.DirectTunnel(ctx, os.Stdin, os.Stdout)
@@ -302,6 +717,8 @@ func (cmd *SSHCmd) jumpContainerTailscale(
 				InstallTerminfo: cmd.InstallTerminfo,
 			},
 			Stderr: os.Stderr,
+
+			AgentForwardingFingerprint: cmd.agentForwardingFingerprint,
 		},
 	)
 }
@@ -594,6 +1011,15 @@ func (cmd *SSHCmd) startTunnel(
 		"--workdir",
 		workdir,
 	}
+	algorithms, err := helperssh.AlgorithmsFromContext(devPodConfig)
+	if err != nil {
+		return err
+	}
+	commandArgs = append(commandArgs, algorithms.Args()...)
+	commandArgs = append(
+		commandArgs,
+		helperssh.AcceptEnvArgs(helperssh.AcceptEnvFromContext(devPodConfig))...,
+	)
 	if cmd.ReuseSSHAuthSock != "" {
 		log.Debug("Reusing SSH_AUTH_SOCK")
 		commandArgs = append(commandArgs, "--reuse-ssh-auth-sock", cmd.ReuseSSHAuthSock)
@@ -610,6 +1036,16 @@ func (cmd *SSHCmd) startTunnel(
 	if err != nil {
 		return err
 	}
+	// the token is sent in the session env so it doesn't show up in the process list
+	workspaceToken, err := token.GetWorkspaceToken(cmd.Context, workspaceClient.Workspace())
+	if err != nil {
+		return err
+	}
+	envVars[helperssh.TokenEnv] = workspaceToken
+	// the shell of devpod up --shell is applied to every new session
+	if shell := workspaceClient.WorkspaceConfig().IDE.Shell; shell != "" {
+		envVars[config.EnvDefaultShell] = shell
+	}
 
 	// Traffic is coming in from the outside, we need to forward it to the container
 	if cmd.Stdio {
@@ -623,18 +1059,37 @@ func (cmd *SSHCmd) startTunnel(
 		})
 	}
 
+	knownHostsFile, err := cmd.knownHostsFile(devPodConfig)
+	if err != nil {
+		return err
+	}
+	hostKeyCallback, err := devssh.KnownHostsCallback(
+		knownHostsFile,
+		workspaceClient.Workspace()+config.SSHHostSuffix,
+	)
+	if err != nil {
+		return err
+	}
+	keyBytes, err := devssh.GetWorkspacePrivateKeyRaw(cmd.Context, workspaceClient.Workspace())
+	if err != nil {
+		return err
+	}
+
 	return machine.StartSSHSession(ctx, machine.StartSSHSessionOptions{
-		User:    cmd.User,
-		Command: cmd.Command,
+		User:            cmd.User,
+		Command:         cmd.Command,
+		HostKeyCallback: hostKeyCallback,
+		KeyBytes:        keyBytes,
 		AgentForwarding: cmd.AgentForwarding &&
 			devPodConfig.ContextOption(config.ContextOptionSSHAgentForwarding) == config.BoolTrue,
 		SessionOptions: machine.SSHSessionOptions{
 			TermMode:        cmd.TermMode,
 			InstallTerminfo: cmd.InstallTerminfo,
 		},
+		AgentForwardingFingerprint: cmd.agentForwardingFingerprint,
 		Exec: func(ctx context.Context, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
-			if cmd.SSHKeepAliveInterval != DisableSSHKeepAlive {
-				go startSSHKeepAlive(ctx, containerClient, cmd.SSHKeepAliveInterval, log)
+			if interval := cmd.keepAliveInterval(); interval != DisableSSHKeepAlive {
+				go startSSHKeepAlive(ctx, containerClient, interval, log)
 			}
 			return devssh.Run(ctx, devssh.RunOptions{
 				Client:  containerClient,
@@ -668,6 +1123,17 @@ func resolveWorkdir(
 	return path.Join("/workspaces", workspaceClient.Workspace())
 }
 
+// resultWorkdir returns the workspace folder of the container, including the git sub path.
+func resultWorkdir(result *config2.Result, gitSubPath string) string {
+	if gitSubPath != "" && result.SubstitutionContext != nil {
+		return path.Join(result.SubstitutionContext.ContainerWorkspaceFolder, gitSubPath)
+	} else if result.MergedConfig != nil {
+		return result.MergedConfig.WorkspaceFolder
+	}
+
+	return ""
+}
+
 func resolveMergedWorkspaceFolder(
 	workspaceClient client2.BaseWorkspaceClient,
 	log log.Logger,
@@ -841,6 +1307,16 @@ func gpgSigningKey(log log.Logger) string {
 	return result
 }
 
+// keepAliveInterval returns the interval of the keepalive requests or DisableSSHKeepAlive if
+// they are turned off.
+func (cmd *SSHCmd) keepAliveInterval() time.Duration {
+	if cmd.NoKeepAlive || cmd.SSHKeepAliveInterval < 0 {
+		return DisableSSHKeepAlive
+	}
+
+	return cmd.SSHKeepAliveInterval
+}
+
 func startSSHKeepAlive(
 	ctx context.Context,
 	client *ssh.Client,
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/skevetter/devpod/pkg/config"
 	"github.com/skevetter/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -245,3 +246,69 @@ func TestRunPortForwards_MultipleMappingsReturnError(t *testing.T) {
 	assert.ErrorContains(t, err, "error forwarding 8081:81: boom")
 	assert.Equal(t, int32(2), calls)
 }
+
+func TestDisableTmuxFlag(t *testing.T) {
+	args := disableTmuxFlag(
+		[]string{"ssh", "--tmux", "my-workspace", "--tmux=true", "--user", "vscode"},
+	)
+	assert.Equal(t, []string{"ssh", "my-workspace", "--user", "vscode", "--tmux=false"}, args)
+
+	args = disableTmuxFlag([]string{"ssh", "my-workspace", "--", "--tmux"})
+	assert.Equal(t, []string{"ssh", "my-workspace", "--tmux=false", "--", "--tmux"}, args)
+}
+
+func TestTmuxActive_NoSession(t *testing.T) {
+	t.Setenv("TMUX", "")
+	assert.False(t, tmuxActive())
+}
+
+func TestValidateEscapeChar(t *testing.T) {
+	for _, char := range []string{"none", "~", "%", "a"} {
+		assert.NoError(t, validateEscapeChar(char), char)
+	}
+	for _, char := range []string{"", "~~", " ", "\t", "é", "None"} {
+		assert.Error(t, validateEscapeChar(char), char)
+	}
+}
+
+func TestKeepAliveInterval(t *testing.T) {
+	sshCmd := NewSSHCmd(nil)
+	require.NoError(t, sshCmd.ParseFlags(nil))
+	interval, err := sshCmd.Flags().GetDuration("keep-alive-interval")
+	require.NoError(t, err)
+	assert.Equal(t, defaultKeepAliveInterval, interval)
+
+	cmd := &SSHCmd{SSHKeepAliveInterval: 10 * time.Second}
+	assert.Equal(t, 10*time.Second, cmd.keepAliveInterval())
+
+	cmd.NoKeepAlive = true
+	assert.Equal(t, DisableSSHKeepAlive, cmd.keepAliveInterval())
+
+	cmd = &SSHCmd{SSHKeepAliveInterval: -time.Second}
+	assert.Equal(t, DisableSSHKeepAlive, cmd.keepAliveInterval())
+}
+
+func TestValidateOpenSSHFlags(t *testing.T) {
+	devPodConfig := &config.Config{}
+	cmd := &SSHCmd{StartServices: true, SetEnvVars: []string{"FOO=bar"}}
+	assert.NoError(t, cmd.validateOpenSSHFlags(devPodConfig, log.Discard))
+
+	cmd.startServicesChanged = true
+	cmd.ForwardPorts = []string{httpPort}
+	cmd.GPGAgentForwarding = true
+	err := cmd.validateOpenSSHFlags(devPodConfig, log.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--forward-ports, --gpg-agent-forwarding, --start-services")
+
+	cmd = &SSHCmd{SetEnvVars: []string{"FOO"}}
+	assert.Error(t, cmd.validateOpenSSHFlags(devPodConfig, log.Discard))
+}
+
+func TestValidateAgentForwardingIdentity(t *testing.T) {
+	cmd := &SSHCmd{AgentForwardingIdentity: "~/.ssh/id_ed25519"}
+	assert.NoError(t, cmd.validateAgentForwardingIdentity(false))
+	assert.ErrorContains(t, cmd.validateAgentForwardingIdentity(true), "--multiplexed")
+
+	cmd.Stdio = true
+	assert.ErrorContains(t, cmd.validateAgentForwardingIdentity(false), "--stdio")
+}
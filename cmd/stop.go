@@ -2,22 +2,32 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/skevetter/devpod/cmd/completion"
 	"github.com/skevetter/devpod/cmd/flags"
 	client2 "github.com/skevetter/devpod/pkg/client"
 	"github.com/skevetter/devpod/pkg/client/clientimplementation"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
 	workspace2 "github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
 // StopCmd holds the destroy cmd flags.
 type StopCmd struct {
 	*flags.GlobalFlags
 	client2.StopOptions
+
+	All         bool
+	Force       bool
+	Parallel    int
+	GracePeriod string
 }
 
 // NewStopCmd creates a new destroy command.
@@ -41,6 +51,19 @@ func NewStopCmd(flags *flags.GlobalFlags) *cobra.Command {
 				return fmt.Errorf("decode platform options: %w", err)
 			}
 
+			if cmd.GracePeriod != "" {
+				if _, err := time.ParseDuration(cmd.GracePeriod); err != nil {
+					return fmt.Errorf("parse grace period: %w", err)
+				}
+			}
+			if cmd.All {
+				if len(args) > 0 {
+					return fmt.Errorf("cannot specify a workspace together with --all")
+				}
+
+				return cmd.RunAll(ctx, devPodConfig)
+			}
+
 			client, err := workspace2.Get(ctx, workspace2.GetOptions{
 				DevPodConfig: devPodConfig,
 				Args:         args,
@@ -66,15 +89,141 @@ func NewStopCmd(flags *flags.GlobalFlags) *cobra.Command {
 		},
 	}
 
+	stopCmd.Flags().BoolVar(&cmd.All, "all", false, "Stop all running workspaces")
+	stopCmd.Flags().BoolVar(&cmd.Force, "force", false, "Also stop pinned workspaces with --all")
+	stopCmd.Flags().IntVar(&cmd.Parallel, "parallel", 4, "The number of workspaces to stop at the same time with --all")
+	stopCmd.Flags().
+		StringVar(&cmd.GracePeriod, "grace-period", "", "The amount of time to give the command to stop the workspace")
 	return stopCmd
 }
 
+// RunAll stops all running workspaces and reports the ones that failed.
+func (cmd *StopCmd) RunAll(ctx context.Context, devPodConfig *config.Config) error {
+	workspaces, err := workspace2.List(ctx, devPodConfig, false, cmd.Owner, log.Default)
+	if err != nil {
+		return fmt.Errorf("list workspaces: %w", err)
+	}
+
+	parallel := cmd.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	if !cmd.Force {
+		workspaces = slices.DeleteFunc(workspaces, func(workspace *provider.Workspace) bool {
+			if workspace.Pinned {
+				log.Default.Infof("Skipping pinned workspace %s, use --force to stop it", workspace.ID)
+			}
+			return workspace.Pinned
+		})
+	}
+
+	results := make([]stopAllResult, len(workspaces))
+	group := errgroup.Group{}
+	group.SetLimit(parallel)
+	for i, workspace := range workspaces {
+		group.Go(func() error {
+			results[i] = cmd.stopIfRunning(ctx, devPodConfig, workspace.ID)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	return summarizeStopAll(workspaces, results, log.Default)
+}
+
+// stopAllResult is the outcome of stopping a single workspace with --all.
+type stopAllResult struct {
+	stopped   bool
+	err       error
+	statusErr error
+}
+
+// summarizeStopAll logs the outcome of every workspace. Workspaces whose status couldn't be
+// fetched are reported separately from the ones that failed to stop.
+func summarizeStopAll(
+	workspaces []*provider.Workspace,
+	results []stopAllResult,
+	log log.Logger,
+) error {
+	failed, stopped, unknown := 0, 0, 0
+	for i, workspace := range workspaces {
+		switch result := results[i]; {
+		case result.statusErr != nil:
+			unknown++
+			log.Errorf(
+				"Failed to get the status of workspace %s: %v",
+				workspace.ID,
+				result.statusErr,
+			)
+		case result.err != nil:
+			failed++
+			log.Errorf("Failed to stop workspace %s: %v", workspace.ID, result.err)
+		case result.stopped:
+			stopped++
+			log.Donef("Stopped workspace %s", workspace.ID)
+		}
+	}
+
+	errs := []error{}
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf(
+			"failed to stop %d of %d running workspaces", failed, failed+stopped,
+		))
+	}
+	if unknown > 0 {
+		errs = append(errs, fmt.Errorf("failed to get the status of %d workspaces", unknown))
+	}
+	if len(errs) == 0 && stopped == 0 {
+		log.Info("No running workspaces found")
+	}
+
+	return errors.Join(errs...)
+}
+
+func (cmd *StopCmd) stopIfRunning(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	workspaceID string,
+) stopAllResult {
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceID},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return stopAllResult{statusErr: err}
+	}
+
+	status, err := client.Status(ctx, client2.StatusOptions{})
+	if err != nil {
+		return stopAllResult{statusErr: err}
+	} else if status != client2.StatusRunning {
+		return stopAllResult{}
+	}
+
+	return stopAllResult{stopped: true, err: cmd.Run(ctx, devPodConfig, client)}
+}
+
 // Run runs the command logic.
 func (cmd *StopCmd) Run(
 	ctx context.Context,
 	devPodConfig *config.Config,
 	client client2.BaseWorkspaceClient,
 ) error {
+	// kill the stop after the grace period
+	if cmd.GracePeriod != "" {
+		gracePeriod, err := time.ParseDuration(cmd.GracePeriod)
+		if err != nil {
+			return fmt.Errorf("parse grace period: %w", err)
+		}
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, gracePeriod)
+		defer cancel()
+	}
+
 	// lock workspace
 	if !cmd.Platform.Enabled {
 		err := client.Lock(ctx)
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeStopAll(t *testing.T) {
+	workspaces := []*provider.Workspace{{ID: "a"}, {ID: "b"}, {ID: "c"}, {ID: "d"}}
+	results := []stopAllResult{
+		{stopped: true},
+		{stopped: true, err: errors.New("stop")},
+		{statusErr: errors.New("status")},
+		{},
+	}
+
+	err := summarizeStopAll(workspaces, results, log.Discard)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to stop 1 of 2 running workspaces")
+	assert.Contains(t, err.Error(), "failed to get the status of 1 workspaces")
+
+	err = summarizeStopAll(workspaces[2:3], results[2:3], log.Discard)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "failed to stop")
+
+	assert.NoError(t, summarizeStopAll(workspaces[3:], results[3:], log.Discard))
+}
@@ -1,15 +1,19 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/skevetter/devpod/cmd/flags"
@@ -19,20 +23,44 @@ import (
 	"github.com/skevetter/devpod/pkg/client/clientimplementation"
 	"github.com/skevetter/devpod/pkg/config"
 	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/devcontainer/feature"
 	"github.com/skevetter/devpod/pkg/devcontainer/sshtunnel"
+	"github.com/skevetter/devpod/pkg/docker"
 	"github.com/skevetter/devpod/pkg/dotfiles"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/devpod/pkg/git"
 	"github.com/skevetter/devpod/pkg/ide"
+	"github.com/skevetter/devpod/pkg/ide/jetbrains"
 	"github.com/skevetter/devpod/pkg/ide/opener"
 	options2 "github.com/skevetter/devpod/pkg/options"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/secrets"
+	"github.com/skevetter/devpod/pkg/shell"
 	devssh "github.com/skevetter/devpod/pkg/ssh"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/devpod/pkg/telemetry"
 	"github.com/skevetter/devpod/pkg/util"
 	workspace2 "github.com/skevetter/devpod/pkg/workspace"
 	"github.com/skevetter/log"
+	"github.com/skevetter/log/survey"
+	"github.com/skevetter/log/terminal"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+const (
+	checkProviderVersionOff  = "off"
+	checkProviderVersionWarn = "warn"
+	checkProviderVersionFail = "fail"
+)
+
+var checkProviderVersionModes = []string{
+	checkProviderVersionOff,
+	checkProviderVersionWarn,
+	checkProviderVersionFail,
+}
+
 // UpCmd holds the up cmd flags.
 type UpCmd struct {
 	provider2.CLIOptions
@@ -46,13 +74,31 @@ type UpCmd struct {
 	GPGAgentForwarding bool
 	OpenIDE            bool
 	Reconfigure        bool
+	CheckImageUpdate   bool
+	ForceReprovision   bool
+	Yes                bool
+
+	CheckProviderVersion string
+	IDEVersion           string
 
-	SSHConfigPath string
+	SSHConfigPath  string
+	SSHAgentSocket string
+	Shell          string
 
 	DotfilesSource        string
 	DotfilesScript        string
 	DotfilesScriptEnv     []string // Key=Value to pass to install script
 	DotfilesScriptEnvFile []string // Paths to files containing Key=Value pairs to pass to install script
+
+	PostUpCommand     string
+	FailOnPostUpError bool
+
+	WorkspaceEnvSecrets []string
+
+	events *provider2.EventWriter
+
+	// storedWorkspaceEnv is the workspace env without secrets that is stored with the workspace
+	storedWorkspaceEnv []string
 }
 
 // NewUpCmd creates a new up command.
@@ -71,6 +117,12 @@ func (cmd *UpCmd) execute(cobraCmd *cobra.Command, args []string) error {
 	if err := cmd.validate(); err != nil {
 		return err
 	}
+	if cmd.SSHAgentSocket != "" {
+		// all ssh subprocesses of this invocation inherit the agent socket
+		if err := os.Setenv("SSH_AUTH_SOCK", cmd.SSHAgentSocket); err != nil {
+			return err
+		}
+	}
 	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
 	if err != nil {
 		return err
@@ -78,9 +130,28 @@ func (cmd *UpCmd) execute(cobraCmd *cobra.Command, args []string) error {
 	if devPodConfig.ContextOption(config.ContextOptionSSHStrictHostKeyChecking) == config.BoolTrue {
 		cmd.StrictHostKeyChecking = true
 	}
+	// the ssh servers started by the agent restrict their algorithms as well
+	cmd.SSHDAllowedCiphers = devPodConfig.ContextOption(config.ContextOptionSSHDAllowedCiphers)
+	cmd.SSHDAllowedKeyExchanges = devPodConfig.ContextOption(
+		config.ContextOptionSSHDAllowedKeyExchanges,
+	)
+	if cmd.ImagePullPolicy == "" {
+		cmd.ImagePullPolicy = devPodConfig.ContextOption(config.ContextOptionImagePullPolicy)
+	}
+	if err := driver.ValidateImagePullPolicy(cmd.ImagePullPolicy); err != nil {
+		return err
+	}
+	if cmd.RegistryMirror == "" {
+		cmd.RegistryMirror = devPodConfig.ContextOption(config.ContextOptionRegistryMirror)
+	}
+	if err := docker.ValidateRegistryMirror(cmd.RegistryMirror); err != nil {
+		return err
+	}
 
 	ctx, cancel := WithSignals(cobraCmd.Context())
 	defer cancel()
+	shutdownTracing := telemetry.StartTracing(ctx, log.Default)
+	defer shutdownTracing()
 
 	client, logger, err := cmd.prepareClient(ctx, devPodConfig, args)
 	if err != nil {
@@ -98,6 +169,21 @@ func (cmd *UpCmd) validate() error {
 	if err := validatePodmanFlags(cmd); err != nil {
 		return err
 	}
+	if cmd.CreateNetwork && cmd.Network == "" {
+		return fmt.Errorf("--create-network requires --network")
+	}
+	if !slices.Contains(checkProviderVersionModes, cmd.CheckProviderVersion) {
+		return fmt.Errorf(
+			"unexpected --check-provider-version %s, choose one of %s",
+			cmd.CheckProviderVersion,
+			strings.Join(checkProviderVersionModes, ", "),
+		)
+	}
+	if cmd.GitCloneDepth < 0 {
+		return fmt.Errorf("--clone-depth must be a positive number")
+	} else if cmd.GitCloneDepth > 0 && cmd.GitCloneStrategy != git.ShallowCloneStrategy {
+		return fmt.Errorf("--clone-depth requires --git-clone-strategy shallow")
+	}
 	if cmd.ExtraDevContainerPath != "" {
 		absPath, err := filepath.Abs(cmd.ExtraDevContainerPath)
 		if err != nil {
@@ -105,9 +191,42 @@ func (cmd *UpCmd) validate() error {
 		}
 		cmd.ExtraDevContainerPath = absPath
 	}
+	if cmd.AdditionalFeatures != "" {
+		if err := feature.ValidateFeatures(cmd.AdditionalFeatures); err != nil {
+			return fmt.Errorf("invalid devcontainer features JSON: %w", err)
+		}
+	}
+	if cmd.IDEVersion != "" {
+		cmd.IDEOptions = append(cmd.IDEOptions, jetbrains.VersionOption+"="+cmd.IDEVersion)
+	}
+	if cmd.SSHAgentSocket != "" {
+		socketPath, err := validateSSHAgentSocket(cmd.SSHAgentSocket)
+		if err != nil {
+			return err
+		}
+		cmd.SSHAgentSocket = socketPath
+	}
 	return nil
 }
 
+// validateSSHAgentSocket returns the absolute path of the ssh agent socket or an error if
+// the path does not exist or is not a socket.
+func validateSSHAgentSocket(socketPath string) (string, error) {
+	absPath, err := filepath.Abs(util.ExpandTilde(socketPath))
+	if err != nil {
+		return "", err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid --ssh-agent-socket: %w", err)
+	} else if info.Mode()&(os.ModeSocket|os.ModeNamedPipe) == 0 {
+		return "", fmt.Errorf("invalid --ssh-agent-socket: %s is not a socket", absPath)
+	}
+
+	return absPath, nil
+}
+
 func (cmd *UpCmd) registerFlags(upCmd *cobra.Command) {
 	cmd.registerSSHFlags(upCmd)
 	cmd.registerDotfilesFlags(upCmd)
@@ -129,6 +248,9 @@ func (cmd *UpCmd) registerSSHFlags(upCmd *cobra.Command) {
 	upCmd.Flags().
 		StringVar(&cmd.SSHConfigPath, "ssh-config", "",
 			"The path to the ssh config to modify, if empty will use ~/.ssh/config")
+	upCmd.Flags().
+		StringVar(&cmd.SSHAgentSocket, "ssh-agent-socket", "",
+			"The path to the ssh agent socket to use instead of SSH_AUTH_SOCK for all ssh operations")
 }
 
 func (cmd *UpCmd) registerDotfilesFlags(upCmd *cobra.Command) {
@@ -164,6 +286,10 @@ func (cmd *UpCmd) registerDevContainerFlags(upCmd *cobra.Command) {
 	upCmd.Flags().
 		StringVar(&cmd.AdditionalFeatures, "additional-features", "",
 			`Additional features to apply to the dev container (JSON as per "features" section in devcontainer.json)`)
+	upCmd.Flags().
+		StringVar(&cmd.AdditionalFeatures, "devcontainer-features", "",
+			`Features to merge on top of the features of devcontainer.json, e.g. '{"<feature>": {"<option>": "<value>"}}'`)
+	upCmd.MarkFlagsMutuallyExclusive("additional-features", "devcontainer-features")
 	upCmd.Flags().
 		StringArrayVar(&cmd.Mounts, "mount", []string{},
 			"Additional mount to apply when creating the dev container. "+
@@ -175,6 +301,10 @@ func (cmd *UpCmd) registerIDEFlags(upCmd *cobra.Command) {
 		StringVar(&cmd.IDE, "ide", "", "The IDE to open the workspace in. If empty will use vscode locally or in browser")
 	upCmd.Flags().
 		StringArrayVar(&cmd.IDEOptions, "ide-option", []string{}, "IDE option in the form KEY=VALUE")
+	upCmd.Flags().
+		StringVar(&cmd.IDEVersion, "ide-version", "",
+			"The version of the IDE server backend, e.g. 2024.1.4 for JetBrains IDEs. "+
+				"Shorthand for --ide-option VERSION=<version>")
 	upCmd.Flags().
 		BoolVar(&cmd.OpenIDE, "open-ide", true,
 			"If this is false and an IDE is configured, DevPod will only install the IDE server backend, but not open it")
@@ -188,6 +318,10 @@ func (cmd *UpCmd) registerGitFlags(upCmd *cobra.Command) {
 	upCmd.Flags().
 		BoolVar(&cmd.GitCloneRecursiveSubmodules, "git-clone-recursive-submodules", false,
 			"If true will clone git submodule repositories recursively")
+	upCmd.Flags().
+		IntVar(&cmd.GitCloneDepth, "clone-depth", 0,
+			"The number of commits to fetch with --git-clone-strategy shallow, defaults to 1. "+
+				"Submodules cloned with --git-clone-recursive-submodules are still fetched with their full history")
 	upCmd.Flags().
 		StringVar(&cmd.GitSSHSigningKey, "git-ssh-signing-key", "",
 			"The ssh key to use when signing git commits. Used to explicitly setup DevPod's ssh signature "+
@@ -223,6 +357,37 @@ func (cmd *UpCmd) registerWorkspaceFlags(upCmd *cobra.Command) {
 			"Reconfigure the options for this workspace. Only supported in DevPod Pro right now.")
 	upCmd.Flags().
 		BoolVar(&cmd.Recreate, "recreate", false, "If true will remove any existing containers and recreate them")
+	upCmd.Flags().
+		BoolVar(&cmd.ForceReprovision, "force-reprovision", false,
+			"If true will provision the workspace again even if it is already running with an unchanged devcontainer.json")
+	upCmd.Flags().
+		BoolVar(&cmd.SkipLifecycleCommands, "skip-lifecycle-commands", false,
+			"If true will not run onCreate, updateContent, postCreate and postStart commands when the container "+
+				"is already running. postAttachCommand still runs on every up")
+	upCmd.Flags().
+		BoolVar(&cmd.CheckImageUpdate, "check-image-update", false,
+			"If true will check if the workspace image was updated upstream and offer to recreate the workspace")
+	upCmd.Flags().
+		StringVar(&cmd.CheckProviderVersion, "check-provider-version", checkProviderVersionWarn,
+			"What to do if a newer version of the provider is available. Can be off, warn or fail")
+	upCmd.Flags().
+		BoolVar(&cmd.Yes, "yes", false, "If true will automatically confirm prompts, e.g. to recreate an updated workspace")
+	upCmd.Flags().
+		StringVar(&cmd.Shell, "shell", "",
+			"The shell to use for interactive ssh sessions in the workspace. If empty, the login shell of "+
+				"the remote user is used")
+	upCmd.Flags().
+		StringVar(&cmd.Network, "network", "", "An existing docker network to attach the workspace container to")
+	upCmd.Flags().
+		BoolVar(&cmd.CreateNetwork, "create-network", false,
+			"If true will create the network specified with --network if it doesn't exist")
+	upCmd.Flags().
+		StringVar(&cmd.ImagePullPolicy, "image-pull-policy", "",
+			"When to pull the base image of the workspace, built images are never pulled. "+
+				"Can be always, if-not-present (default) or never")
+	upCmd.Flags().
+		StringVar(&cmd.RegistryMirror, "registry-mirror", "",
+			"A registry mirror, e.g. https://mirror.example.com, for the docker daemon of the workspace host and container")
 	upCmd.Flags().
 		BoolVar(&cmd.Reset, "reset", false,
 			"If true will remove any existing containers including sources, and recreate them")
@@ -236,12 +401,29 @@ func (cmd *UpCmd) registerWorkspaceFlags(upCmd *cobra.Command) {
 		StringSliceVar(&cmd.WorkspaceEnvFile, "workspace-env-file", []string{},
 			"The path to files containing a list of extra env variables to put into the workspace, "+
 				"e.g. MY_ENV_VAR=MY_VALUE")
+	upCmd.Flags().
+		StringArrayVar(&cmd.WorkspaceEnvSecrets, "workspace-env-secret", []string{},
+			"A secret holding a JSON object of extra env variables to put into the workspace in the form "+
+				"<provider>:<path>. Supported providers are env, vault and ssm, e.g. vault:secret/data/my-app")
+	upCmd.Flags().
+		BoolVar(&cmd.AgentForceReinstall, "no-agent-cache", false,
+			"If true will always reinstall the DevPod agent on the machine and in the container instead of reusing an existing binary")
+	upCmd.Flags().
+		StringArrayVar(&cmd.DevContainerEnv, "devcontainer-env", []string{},
+			"Extra env variables that are only set while running the lifecycle commands, e.g. MY_ENV_VAR=MY_VALUE")
 	upCmd.Flags().
 		StringArrayVar(&cmd.InitEnv, "init-env", []string{},
 			"Extra env variables to inject during the initialization of the workspace, e.g. MY_ENV_VAR=MY_VALUE")
 	upCmd.Flags().
 		BoolVar(&cmd.DisableDaemon, "disable-daemon", false,
 			"If enabled, will not install a daemon into the target machine to track activity")
+	upCmd.Flags().
+		StringVar(&cmd.PostUpCommand, "post-up-command", "",
+			"A command to run on the host after the workspace is ready. "+
+				"DEVPOD_WORKSPACE_ID, DEVPOD_SSH_HOST, DEVPOD_WORKSPACE_FOLDER and DEVPOD_REMOTE_USER are set for it")
+	upCmd.Flags().
+		BoolVar(&cmd.FailOnPostUpError, "fail-on-post-up-error", false,
+			"If true a failing --post-up-command fails devpod up instead of logging a warning")
 }
 
 func (cmd *UpCmd) registerTestingFlags(upCmd *cobra.Command) {
@@ -258,24 +440,108 @@ func (cmd *UpCmd) Run(
 	client client2.BaseWorkspaceClient,
 	args []string,
 	log log.Logger,
-) error {
-	cmd.prepareWorkspace(client, log)
+) (err error) {
+	start := time.Now()
+	metricRecorded := false
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanUp,
+		attribute.String("devpod.workspace", client.Workspace()),
+		attribute.String("devpod.provider", client.Provider()),
+	)
+	cmd.events = newUpEventWriter(client, log)
+	cmd.events.Record("up", provider2.EventLevelInfo, "Starting workspace "+client.Workspace())
+	defer func() {
+		if err != nil {
+			cmd.events.Fail("up", err)
+		} else {
+			cmd.events.Record("up", provider2.EventLevelInfo, "Workspace is up")
+		}
+		if !metricRecorded {
+			recordUpMetric(client, start, err == nil, log)
+			telemetry.EndSpan(span, err)
+			writeUpTrace(ctx, client, log)
+		}
+	}()
 
-	wctx, err := cmd.executeDevPodUp(ctx, devPodConfig, client, log)
+	err = cmd.prepareWorkspace(client, log)
 	if err != nil {
 		return err
 	}
-	if wctx == nil {
-		return nil // Platform mode
+
+	optionsDigest := cmd.upOptionsDigest(client.WorkspaceConfig().IDE)
+	wctx := cmd.reuseRunningWorkspace(ctx, devPodConfig, client, optionsDigest, log)
+	if wctx != nil {
+		cmd.startPostAttachHooks(client, wctx, log)
+	} else {
+		wctx, err = cmd.executeDevPodUp(ctx, devPodConfig, client, log)
+		if err != nil {
+			return err
+		}
+		if wctx == nil {
+			return nil // Platform mode
+		}
+		cmd.saveUpOptions(client, optionsDigest, log)
 	}
 
 	if err := cmd.configureWorkspace(devPodConfig, client, wctx, log); err != nil {
 		return err
 	}
 
+	if err := cmd.runPostUpCommand(ctx, client, wctx, log); err != nil {
+		return err
+	}
+
+	// the workspace is ready, opening the IDE might block until it is closed
+	recordUpMetric(client, start, true, log)
+	telemetry.EndSpan(span, nil)
+	writeUpTrace(ctx, client, log)
+	metricRecorded = true
 	return cmd.openIDE(ctx, devPodConfig, client, wctx, log)
 }
 
+// recordUpMetric appends the result of the up to the metrics file. Errors are only logged
+// as the metrics must never fail the up.
+func recordUpMetric(client client2.BaseWorkspaceClient, start time.Time, success bool, log log.Logger) {
+	metricsFile, err := provider2.GetUpMetricsFile()
+	if err != nil {
+		log.Debugf("error getting metrics file: %v", err)
+		return
+	}
+
+	err = provider2.AppendUpMetric(metricsFile, provider2.UpMetric{
+		WorkspaceID:     client.Workspace(),
+		Context:         client.Context(),
+		Provider:        client.Provider(),
+		DurationSeconds: time.Since(start).Seconds(),
+		Success:         success,
+		Timestamp:       time.Now().UTC(),
+	})
+	if err != nil {
+		log.Debugf("error writing up metric: %v", err)
+	}
+}
+
+// writeUpTrace stores the spans of the up for devpod workspace trace. Errors are only logged
+// as tracing must never fail the up.
+func writeUpTrace(ctx context.Context, client client2.BaseWorkspaceClient, log log.Logger) {
+	err := telemetry.WriteTrace(ctx, client.Context(), client.Workspace())
+	if err != nil {
+		log.Debugf("error writing trace: %v", err)
+	}
+}
+
+// newUpEventWriter creates the event writer of the workspace and removes the events of the last up.
+func newUpEventWriter(client client2.BaseWorkspaceClient, log log.Logger) *provider2.EventWriter {
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceDir, err := provider2.GetWorkspaceDir(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		workspaceDir = filepath.Dir(workspaceConfig.Origin)
+	}
+
+	events := provider2.NewEventWriter(workspaceDir, log)
+	events.Reset()
+	return events
+}
+
 // workspaceContext holds the result of workspace preparation.
 type workspaceContext struct {
 	result  *config2.Result
@@ -284,10 +550,18 @@ type workspaceContext struct {
 }
 
 // prepareWorkspace handles initial setup and validation.
-func (cmd *UpCmd) prepareWorkspace(client client2.BaseWorkspaceClient, log log.Logger) {
+func (cmd *UpCmd) prepareWorkspace(client client2.BaseWorkspaceClient, log log.Logger) error {
 	if cmd.Reset {
 		cmd.Recreate = true
 	}
+	if seal := client.WorkspaceConfig().Seal; seal != nil && cmd.Recreate {
+		return fmt.Errorf(
+			"workspace %s was sealed at %s with hash %s and can't be recreated",
+			client.Workspace(),
+			seal.Timestamp.Format(time.RFC3339),
+			seal.Hash,
+		)
+	}
 
 	targetIDE := client.WorkspaceConfig().IDE.Name
 	if cmd.IDE != "" {
@@ -295,13 +569,33 @@ func (cmd *UpCmd) prepareWorkspace(client client2.BaseWorkspaceClient, log log.L
 	}
 
 	if !cmd.Platform.Enabled && ide.ReusesAuthSock(targetIDE) {
-		cmd.SSHAuthSockID = util.RandStringBytes(10)
+		cmd.SSHAuthSockID = sshAuthSockID(client.WorkspaceConfig(), log)
 		log.Debug("Reusing SSH_AUTH_SOCK", cmd.SSHAuthSockID)
 	} else if cmd.Platform.Enabled && ide.ReusesAuthSock(targetIDE) {
 		log.Debug(
 			"Reusing SSH_AUTH_SOCK is not supported with platform mode, consider launching the IDE from the platform UI",
 		)
 	}
+
+	return nil
+}
+
+// sshAuthSockID returns the stored SSH_AUTH_SOCK id of the workspace or stores a new one. Reusing
+// the id keeps the agent forwarding of IDEs attached later pointed at the socket the running IDE
+// server uses.
+func sshAuthSockID(workspace *provider2.Workspace, log log.Logger) string {
+	id, err := provider2.LoadWorkspaceSSHAuthSockID(workspace.Context, workspace.ID)
+	if err == nil && id != "" {
+		return id
+	}
+
+	id = util.RandStringBytes(10)
+	err = provider2.SaveWorkspaceSSHAuthSockID(workspace.Context, workspace.ID, id)
+	if err != nil {
+		log.Debugf("save ssh auth sock id: %v", err)
+	}
+
+	return id
 }
 
 // executeDevPodUp runs the agent and returns workspace context.
@@ -322,6 +616,11 @@ func (cmd *UpCmd) executeDevPodUp(
 		return nil, nil
 	}
 
+	return newWorkspaceContext(client, result), nil
+}
+
+// newWorkspaceContext resolves the remote user and working directory from the result.
+func newWorkspaceContext(client client2.BaseWorkspaceClient, result *config2.Result) *workspaceContext {
 	user := config2.GetRemoteUser(result)
 	workdir := ""
 	if result.MergedConfig != nil && result.MergedConfig.WorkspaceFolder != "" {
@@ -335,7 +634,7 @@ func (cmd *UpCmd) executeDevPodUp(
 		workdir = result.SubstitutionContext.ContainerWorkspaceFolder
 	}
 
-	return &workspaceContext{result: result, user: user, workdir: workdir}, nil
+	return &workspaceContext{result: result, user: user, workdir: workdir}
 }
 
 // configureWorkspace sets up SSH, Git, and dotfiles.
@@ -365,7 +664,15 @@ func (cmd *UpCmd) configureWorkspace(
 			return err
 		}
 
-		log.Info("SSH configuration completed in workspace")
+		cmd.events.Infof("ssh", "SSH configuration completed in workspace")
+	}
+
+	if err := addWorkspaceKnownHost(devPodConfig, client); err != nil {
+		log.Debugf("error adding workspace host key to known hosts: %v", err)
+	}
+
+	if err := cmd.applyGitConfig(client, wctx.user, log); err != nil {
+		log.Warnf("Error setting git config in workspace: %v", err)
 	}
 
 	if err := dotfiles.Setup(dotfiles.SetupParams{
@@ -383,6 +690,125 @@ func (cmd *UpCmd) configureWorkspace(
 	return nil
 }
 
+// runPostUpCommand runs the --post-up-command on the host. A failing command only fails the up
+// if --fail-on-post-up-error is set.
+func (cmd *UpCmd) runPostUpCommand(
+	ctx context.Context,
+	client client2.BaseWorkspaceClient,
+	wctx *workspaceContext,
+	log log.Logger,
+) error {
+	if cmd.PostUpCommand == "" {
+		return nil
+	}
+
+	log.Infof("Running post up command: %s", cmd.PostUpCommand)
+	writer := log.Writer(logrus.InfoLevel, false)
+	defer func() { _ = writer.Close() }()
+	errWriter := log.Writer(logrus.ErrorLevel, false)
+	defer func() { _ = errWriter.Close() }()
+
+	err := shell.RunEmulatedShell(
+		ctx,
+		cmd.PostUpCommand,
+		nil,
+		writer,
+		errWriter,
+		append(os.Environ(), postUpCommandEnv(client.Workspace(), wctx)...),
+	)
+	if err == nil {
+		cmd.events.Infof("post-up", "Post up command completed")
+		return nil
+	} else if cmd.FailOnPostUpError {
+		return fmt.Errorf("run post up command: %w", err)
+	}
+
+	cmd.events.Warnf("post-up", "Post up command failed: %v", err)
+	return nil
+}
+
+// postUpCommandEnv returns the environment variables describing the workspace for the post up command.
+func postUpCommandEnv(workspaceID string, wctx *workspaceContext) []string {
+	return []string{
+		config.EnvWorkspaceID + "=" + workspaceID,
+		config.EnvSSHHost + "=" + workspaceID + config.SSHHostSuffix,
+		config.EnvWorkspaceFolder + "=" + wctx.workdir,
+		config.EnvRemoteUser + "=" + wctx.user,
+	}
+}
+
+// applyGitConfig sets the git identity stored via devpod workspace set-git-config in the container.
+func (cmd *UpCmd) applyGitConfig(
+	client client2.BaseWorkspaceClient,
+	user string,
+	log log.Logger,
+) error {
+	gitConfig := client.WorkspaceConfig().GitConfig
+	if gitConfig == nil {
+		return nil
+	}
+
+	log.Debugf("Setting git identity of workspace")
+	return cmd.runWorkspaceCommand(
+		client,
+		user,
+		git.IdentityCommand(gitConfig.Name, gitConfig.Email),
+	)
+}
+
+// runWorkspaceCommand runs the command as the user in the workspace container via devpod ssh.
+func (cmd *UpCmd) runWorkspaceCommand(
+	client client2.BaseWorkspaceClient,
+	user string,
+	command string,
+) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	args := []string{
+		"ssh",
+		"--agent-forwarding=false",
+		"--start-services=false",
+		"--user",
+		user,
+		"--context",
+		client.Context(),
+		client.Workspace(),
+		"--command",
+		command,
+	}
+	if cmd.DevPodHome != "" {
+		args = append(args, "--"+config.BinaryName+"-home", cmd.DevPodHome)
+	}
+
+	out, err := exec.Command(execPath, args...).CombinedOutput() //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(string(out)), err)
+	}
+
+	return nil
+}
+
+// addWorkspaceKnownHost writes the workspace host key into the DevPod known hosts file,
+// replacing the previous key if the workspace was recreated.
+func addWorkspaceKnownHost(devPodConfig *config.Config, client client2.BaseWorkspaceClient) error {
+	knownHostsFile, err := devssh.ResolveKnownHostsFile(
+		devPodConfig.ContextOption(config.ContextOptionSSHKnownHostsFile),
+	)
+	if err != nil {
+		return err
+	}
+
+	hostKey, err := devssh.GetHostPublicKey(client.WorkspaceConfig().Context, client.Workspace())
+	if err != nil {
+		return err
+	}
+
+	return devssh.AddKnownHost(knownHostsFile, client.Workspace()+config.SSHHostSuffix, hostKey)
+}
+
 // openIDE opens the configured IDE.
 func (cmd *UpCmd) openIDE(
 	ctx context.Context,
@@ -571,14 +997,24 @@ func (cmd *UpCmd) devPodUpMachine(
 		return nil, err
 	}
 
+	// check if the image was updated upstream
+	if !cmd.Recreate && client.WorkspaceConfig().Seal == nil && (cmd.CheckImageUpdate ||
+		devPodConfig.ContextOption(config.ContextOptionCheckImageUpdates) == config.BoolTrue) {
+		err = cmd.checkImageUpdate(ctx, client, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// compress info
+	cmd.Tracing = telemetry.NewTracingOptions(ctx)
 	workspaceInfo, wInfo, err := client.AgentInfo(cmd.CLIOptions)
 	if err != nil {
 		return nil, err
 	}
 
 	// create container etc.
-	log.Info("creating devcontainer")
+	cmd.events.Infof("devcontainer", "creating devcontainer")
 	defer log.Debug("done creating devcontainer")
 
 	// if we run on a platform, we need to pass the platform options
@@ -598,7 +1034,13 @@ func (cmd *UpCmd) devPodUpMachine(
 	}
 
 	// ssh tunnel command
-	sshTunnelCmd := fmt.Sprintf("'%s' helper ssh-server --stdio", client.AgentPath())
+	algorithms, err := helperssh.AlgorithmsFromContext(devPodConfig)
+	if err != nil {
+		return nil, err
+	}
+	sshTunnelCmd := algorithms.Command(
+		fmt.Sprintf("'%s' helper ssh-server --stdio", client.AgentPath()),
+	)
 	if log.GetLevel() == logrus.DebugLevel {
 		sshTunnelCmd += " --debug"
 	}
@@ -618,7 +1060,8 @@ func (cmd *UpCmd) devPodUpMachine(
 		cancelCtx context.Context, sshCmd string, sshTunnelStdinReader, sshTunnelStdoutWriter *os.File,
 		writer io.WriteCloser,
 	) error {
-		return agent.InjectAgent(&agent.InjectOptions{
+		cancelCtx, span := telemetry.StartSpan(cancelCtx, telemetry.SpanAgentInject)
+		err := agent.InjectAgent(&agent.InjectOptions{
 			Ctx: cancelCtx,
 			Exec: func(ctx context.Context, command string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
 				return client.Command(ctx, client2.CommandOptions{
@@ -637,7 +1080,10 @@ func (cmd *UpCmd) devPodUpMachine(
 			Stderr:          writer,
 			Log:             log.ErrorStreamOnly(),
 			Timeout:         wInfo.InjectTimeout,
+			ForceReinstall:  cmd.AgentForceReinstall,
 		})
+		telemetry.EndSpan(span, err)
+		return err
 	}
 
 	return sshtunnel.ExecuteCommand(ctx, sshtunnel.ExecuteCommandOptions{
@@ -663,6 +1109,91 @@ func (cmd *UpCmd) devPodUpMachine(
 	})
 }
 
+// checkImageUpdate pulls the image of the workspace and sets Recreate if the digest
+// differs from the base image recorded when the workspace container was created.
+func (cmd *UpCmd) checkImageUpdate(
+	ctx context.Context,
+	client client2.WorkspaceClient,
+	log log.Logger,
+) error {
+	result, err := provider2.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	} else if result == nil || result.MergedConfig == nil || result.MergedConfig.Image == "" ||
+		result.ContainerDetails == nil {
+		log.Debugf("skipping image update check, workspace wasn't created from an image")
+		return nil
+	}
+	baseImageID := result.ContainerDetails.Config.Labels[config2.BaseImageIDLabel]
+	if baseImageID == "" {
+		log.Debugf("skipping image update check, the base image of the workspace is unknown")
+		return nil
+	} else if !client.AgentLocal() {
+		log.Debugf("skipping image update check, only supported for local docker workspaces")
+		return nil
+	}
+
+	_, agentInfo, err := client.AgentInfo(provider2.CLIOptions{})
+	if err != nil {
+		return err
+	}
+	workspaceDriver, err := drivercreate.NewDriver(agentInfo, log)
+	if err != nil {
+		return err
+	}
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		log.Debugf("skipping image update check, only supported for local docker workspaces")
+		return nil
+	}
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return err
+	}
+
+	image := result.MergedConfig.Image
+	cmd.events.Infof("image", "checking for updates of image %s", image)
+	buf := &bytes.Buffer{}
+	err = dockerHelper.Run(ctx, []string{"pull", "--quiet", image}, nil, buf, buf)
+	if err != nil {
+		log.Warnf("error pulling image %s: %s %v", image, buf.String(), err)
+		return nil
+	}
+
+	imageDetails, err := dockerHelper.InspectImage(ctx, image, false)
+	if err != nil {
+		return err
+	} else if imageDetails.ID == baseImageID {
+		log.Debugf("image %s is up to date", image)
+		return nil
+	}
+
+	if cmd.Yes {
+		cmd.events.Infof("image", "image %s was updated, recreating workspace", image)
+		cmd.Recreate = true
+		return nil
+	} else if !terminal.IsTerminalIn {
+		log.Warnf("image %s was updated, run with --recreate or --yes to rebuild the workspace", image)
+		return nil
+	}
+
+	const (
+		yesOption = "Yes"
+		noOption  = "No"
+	)
+	answer, err := log.Question(&survey.QuestionOptions{
+		Question:     fmt.Sprintf("Image %s was updated. Do you want to recreate the workspace?", image),
+		DefaultValue: yesOption,
+		Options:      []string{yesOption, noOption},
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.Recreate = answer == yesOption
+	return nil
+}
+
 type configureSSHParams struct {
 	sshConfigPath        string
 	sshConfigIncludePath string
@@ -698,6 +1229,7 @@ func configureSSH(client client2.BaseWorkspaceClient, params configureSSHParams)
 		GPGAgent:             params.gpgagent,
 		DevPodHome:           params.devPodHome,
 		Provider:             client.Provider(),
+		IdentityFile:         devssh.GetWorkspaceIdentityFile(client.Context(), client.Workspace()),
 		Log:                  log.Default,
 	})
 	if err != nil {
@@ -717,6 +1249,7 @@ func mergeDevPodUpOptions(baseOptions *provider2.CLIOptions) error {
 		return fmt.Errorf("decode up options: %w", err)
 	} else if found {
 		baseOptions.WorkspaceEnv = append(oldOptions.WorkspaceEnv, baseOptions.WorkspaceEnv...)
+		baseOptions.DevContainerEnv = append(oldOptions.DevContainerEnv, baseOptions.DevContainerEnv...)
 		baseOptions.InitEnv = append(oldOptions.InitEnv, baseOptions.InitEnv...)
 		baseOptions.Mounts = append(oldOptions.Mounts, baseOptions.Mounts...)
 		baseOptions.PrebuildRepositories = append(
@@ -780,6 +1313,13 @@ func (cmd *UpCmd) prepareClient(
 		return nil, logger, err
 	}
 
+	cmd.storedWorkspaceEnv = slices.Clone(cmd.WorkspaceEnv)
+	secretEnv, err := secrets.FetchEnv(ctx, cmd.WorkspaceEnvSecrets)
+	if err != nil {
+		return nil, logger, err
+	}
+	cmd.WorkspaceEnv = append(cmd.WorkspaceEnv, secretEnv...)
+
 	cmd.WorkspaceEnv = options2.InheritFromEnvironment(
 		cmd.WorkspaceEnv,
 		inheritedEnvironmentVariables,
@@ -828,17 +1368,71 @@ func (cmd *UpCmd) prepareClient(
 		return nil, logger, err
 	}
 
+	err = cmd.saveShell(client.WorkspaceConfig())
+	if err != nil {
+		return nil, logger, err
+	}
+
+	// mounts passed via --mount take precedence over attached ones with the same target
+	cmd.Mounts = append(slices.Clone(client.WorkspaceConfig().ExtraMounts), cmd.Mounts...)
+
+	proInstance := workspace2.GetProInstance(devPodConfig, client.Provider(), logger)
 	if !cmd.Platform.Enabled {
-		proInstance := workspace2.GetProInstance(devPodConfig, client.Provider(), logger)
 		err = workspace2.CheckProviderUpdate(devPodConfig, proInstance, logger)
 		if err != nil {
 			return nil, logger, err
 		}
 	}
+	// pro providers are kept in sync with their instance by CheckProviderUpdate
+	if proInstance == nil {
+		err = cmd.checkProviderVersion(devPodConfig, client.Provider(), logger)
+		if err != nil {
+			return nil, logger, err
+		}
+	}
 
 	return client, logger, nil
 }
 
+// checkProviderVersion warns about an outdated provider or, with --check-provider-version=fail,
+// returns an error.
+func (cmd *UpCmd) checkProviderVersion(devPodConfig *config.Config, providerName string, log log.Logger) error {
+	if cmd.CheckProviderVersion == checkProviderVersionOff {
+		return nil
+	}
+
+	check, err := workspace2.CheckProviderVersion(devPodConfig, providerName, log)
+	if err != nil {
+		if cmd.CheckProviderVersion == checkProviderVersionFail {
+			return fmt.Errorf("check version of provider %s: %w", providerName, err)
+		}
+
+		log.Debugf("failed to check version of provider %s: %v", providerName, err)
+		return nil
+	} else if !check.UpdateAvailable {
+		return nil
+	}
+
+	if cmd.CheckProviderVersion == checkProviderVersionFail {
+		return fmt.Errorf(
+			"provider %s is outdated (%s, latest is %s), run 'devpod provider update %s' to update it",
+			providerName,
+			check.CurrentVersion,
+			check.LatestVersion,
+			providerName,
+		)
+	}
+
+	log.Warnf(
+		"Provider %s is outdated (%s, latest is %s), run 'devpod provider update %s' to update it",
+		providerName,
+		check.CurrentVersion,
+		check.LatestVersion,
+		providerName,
+	)
+	return nil
+}
+
 func WithSignals(ctx context.Context) (context.Context, func()) {
 	ctx, cancel := context.WithCancel(ctx)
 	signals := make(chan os.Signal, 1)
@@ -901,3 +1495,18 @@ func isValidMapping(mapping string) bool {
 	}
 	return true
 }
+
+// saveShell persists the --shell flag in the workspace config so later sessions reuse it.
+func (cmd *UpCmd) saveShell(workspace *provider2.Workspace) error {
+	if cmd.Shell == "" || workspace.IDE.Shell == cmd.Shell {
+		return nil
+	}
+
+	workspace.IDE.Shell = cmd.Shell
+	err := provider2.SaveWorkspaceConfig(workspace)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/pkg/agent"
+	client2 "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/compress"
+	"github.com/skevetter/devpod/pkg/config"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/skevetter/log/hash"
+)
+
+// reuseRunningWorkspace returns the workspace context of the last up if the workspace is still
+// running, its devcontainer.json is unchanged and it was started with the same options, so
+// provisioning can be skipped.
+func (cmd *UpCmd) reuseRunningWorkspace(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	client client2.BaseWorkspaceClient,
+	optionsDigest string,
+	log log.Logger,
+) *workspaceContext {
+	if cmd.ForceReprovision || cmd.Recreate || cmd.Reset || cmd.Platform.Enabled {
+		return nil
+	} else if cmd.CheckImageUpdate ||
+		devPodConfig.ContextOption(config.ContextOptionCheckImageUpdates) == config.BoolTrue {
+		return nil
+	}
+
+	workspaceClient, ok := client.(client2.WorkspaceClient)
+	if !ok {
+		return nil
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	lastOptionsDigest, err := provider2.LoadWorkspaceUpOptions(
+		workspaceConfig.Context,
+		workspaceConfig.ID,
+	)
+	if err != nil || optionsDigest == "" || lastOptionsDigest != optionsDigest {
+		log.Debugf("options of workspace %s changed, provisioning workspace", workspaceConfig.ID)
+		return nil
+	}
+
+	result, err := provider2.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil || result == nil || result.DevContainerConfigWithPath == nil ||
+		result.DevContainerConfigWithPath.Config == nil || result.DevContainerConfigWithPath.Path == "" {
+		return nil
+	}
+
+	configFolder := devContainerConfigFolder(workspaceClient, workspaceConfig)
+	if configFolder == "" {
+		log.Debugf("cannot read devcontainer.json of workspace %s locally, provisioning workspace", workspaceConfig.ID)
+		return nil
+	}
+
+	currentConfig, err := config2.ParseDevContainerJSONFile(
+		filepath.Join(configFolder, result.DevContainerConfigWithPath.Path),
+	)
+	if err != nil {
+		log.Debugf("parse devcontainer.json: %v", err)
+		return nil
+	}
+	digest := configDigest(currentConfig)
+	if digest == "" || digest != configDigest(result.DevContainerConfigWithPath.Config) {
+		log.Debugf("devcontainer.json of workspace %s changed, provisioning workspace", workspaceConfig.ID)
+		return nil
+	}
+
+	status, err := client.Status(ctx, client2.StatusOptions{})
+	if err != nil || status != client2.StatusRunning {
+		return nil
+	}
+
+	log.Infof("Workspace %s is already running and up to date, use --force-reprovision to provision it again", workspaceConfig.ID)
+	return newWorkspaceContext(client, result)
+}
+
+// devContainerConfigFolder returns the local folder the workspace's devcontainer.json is read from.
+func devContainerConfigFolder(client client2.WorkspaceClient, workspaceConfig *provider2.Workspace) string {
+	if workspaceConfig.Source.LocalFolder != "" {
+		return workspaceConfig.Source.LocalFolder
+	}
+
+	if client.AgentLocal() {
+		workspaceDir, err := agent.GetAgentWorkspaceDir("", workspaceConfig.Context, workspaceConfig.ID)
+		if err == nil {
+			return agent.GetAgentWorkspaceContentDir(workspaceDir)
+		}
+	}
+
+	return ""
+}
+
+func configDigest(devContainerConfig *config2.DevContainerConfig) string {
+	out, err := json.Marshal(devContainerConfig)
+	if err != nil {
+		return ""
+	}
+
+	return hash.String(string(out))
+}
+
+// upOptionsDigest returns a digest of the options and the IDE that change the workspace.
+// Options that only affect the current invocation are left out.
+func (cmd *UpCmd) upOptionsDigest(ide provider2.WorkspaceIDEConfig) string {
+	options := cmd.CLIOptions
+	options.Recreate = false
+	options.Reset = false
+	options.AgentForceReinstall = false
+	options.SSHAuthSockID = ""
+	options.Tracing = nil
+
+	out, err := json.Marshal(struct {
+		CLIOptions            provider2.CLIOptions
+		DotfilesSource        string
+		DotfilesScript        string
+		DotfilesScriptEnv     []string
+		DotfilesScriptEnvFile []string
+		Shell                 string
+		IDE                   provider2.WorkspaceIDEConfig
+	}{
+		CLIOptions:            options,
+		DotfilesSource:        cmd.DotfilesSource,
+		DotfilesScript:        cmd.DotfilesScript,
+		DotfilesScriptEnv:     cmd.DotfilesScriptEnv,
+		DotfilesScriptEnvFile: cmd.DotfilesScriptEnvFile,
+		Shell:                 cmd.Shell,
+		IDE:                   ide,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return hash.String(string(out))
+}
+
+// saveUpOptions stores the options digest and the workspace env of a successful up. Errors are
+// only logged as the next up then provisions the workspace again.
+func (cmd *UpCmd) saveUpOptions(client client2.BaseWorkspaceClient, digest string, log log.Logger) {
+	err := provider2.SaveWorkspaceUpOptions(client.Context(), client.Workspace(), digest)
+	if err != nil {
+		log.Debugf("save up options: %v", err)
+	}
+
+	// the workspace env is stored for devpod workspace clone
+	err = provider2.SaveWorkspaceEnv(client.Context(), client.Workspace(), cmd.storedWorkspaceEnv)
+	if err != nil {
+		log.Debugf("save workspace env: %v", err)
+	}
+}
+
+// startPostAttachHooks starts the postAttachCommand of a reused workspace in the background, the
+// same way the container setup does on a full up.
+func (cmd *UpCmd) startPostAttachHooks(
+	client client2.BaseWorkspaceClient,
+	wctx *workspaceContext,
+	log log.Logger,
+) {
+	if cmd.SkipLifecycleCommands || wctx.result.MergedConfig == nil ||
+		len(wctx.result.MergedConfig.PostAttachCommands) == 0 {
+		return
+	}
+
+	out, err := json.Marshal(wctx.result)
+	if err != nil {
+		log.Errorf("failed to start postAttachCommand: %v", err)
+		return
+	}
+	setupInfo, err := compress.Compress(string(out))
+	if err != nil {
+		log.Errorf("failed to start postAttachCommand: %v", err)
+		return
+	}
+
+	command := shellescape.QuoteCommand([]string{
+		"sh", "-c",
+		fmt.Sprintf(
+			"nohup '%s' agent container post-attach --setup-info '%s' >/dev/null 2>&1 &",
+			agent.ContainerDevPodHelperLocation,
+			setupInfo,
+		),
+	})
+	log.Debugf("Starting postAttachCommand of workspace")
+	err = cmd.runWorkspaceCommand(client, "root", command)
+	if err != nil {
+		log.Errorf("failed to start postAttachCommand: %v", err)
+	}
+}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"net"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,3 +30,97 @@ func TestUpMountFlagIsRepeatableAndPreservesValues(t *testing.T) {
 		`type=bind,source=/tmp/data,target=/data,readonly`,
 	}, mounts)
 }
+
+func TestPostUpCommandEnv(t *testing.T) {
+	env := postUpCommandEnv("my-workspace", &workspaceContext{
+		user:    "vscode",
+		workdir: "/workspaces/my-workspace",
+	})
+
+	require.Equal(t, []string{
+		"DEVPOD_WORKSPACE_ID=my-workspace",
+		"DEVPOD_SSH_HOST=my-workspace.devpod",
+		"DEVPOD_WORKSPACE_FOLDER=/workspaces/my-workspace",
+		"DEVPOD_REMOTE_USER=vscode",
+	}, env)
+}
+
+func TestUpCheckProviderVersionFlag(t *testing.T) {
+	cmd := &UpCmd{GlobalFlags: &flags.GlobalFlags{}}
+	upCmd := &cobra.Command{}
+	cmd.registerFlags(upCmd)
+
+	require.NoError(t, upCmd.ParseFlags(nil))
+	require.Equal(t, checkProviderVersionWarn, cmd.CheckProviderVersion)
+	require.NoError(t, cmd.validate())
+
+	require.NoError(t, upCmd.ParseFlags([]string{"--check-provider-version=fail"}))
+	require.NoError(t, cmd.validate())
+
+	require.NoError(t, upCmd.ParseFlags([]string{"--check-provider-version=error"}))
+	require.EqualError(t, cmd.validate(), "unexpected --check-provider-version error, choose one of off, warn, fail")
+}
+
+func TestValidateSSHAgentSocket(t *testing.T) {
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+
+	validated, err := validateSSHAgentSocket(socketPath)
+	require.NoError(t, err)
+	require.Equal(t, socketPath, validated)
+
+	_, err = validateSSHAgentSocket(filepath.Join(dir, "missing.sock"))
+	require.ErrorContains(t, err, "invalid --ssh-agent-socket")
+
+	filePath := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(filePath, nil, 0o600))
+	_, err = validateSSHAgentSocket(filePath)
+	require.EqualError(t, err, "invalid --ssh-agent-socket: "+filePath+" is not a socket")
+}
+
+func TestUpOptionsDigest(t *testing.T) {
+	cmd := &UpCmd{}
+	cmd.WorkspaceEnv = []string{"FOO=bar"}
+	ide := provider2.WorkspaceIDEConfig{Name: "vscode"}
+	digest := cmd.upOptionsDigest(ide)
+	require.NotEmpty(t, digest)
+
+	cmd.Recreate = true
+	cmd.SSHAuthSockID = "abc"
+	require.Equal(t, digest, cmd.upOptionsDigest(ide),
+		"transient options must not change the digest")
+
+	for name, change := range map[string]func(cmd *UpCmd){
+		"workspace-env":    func(cmd *UpCmd) { cmd.WorkspaceEnv = []string{"FOO=baz"} },
+		"dotfiles":         func(cmd *UpCmd) { cmd.DotfilesSource = "github.com/me/dotfiles" },
+		"devcontainer":     func(cmd *UpCmd) { cmd.DevContainerPath = ".devcontainer/other.json" },
+		"mount":            func(cmd *UpCmd) { cmd.Mounts = []string{"type=volume,target=/a"} },
+		"ide-option":       func(cmd *UpCmd) { cmd.IDEOptions = []string{"VERSION=1.0"} },
+		"image-pull":       func(cmd *UpCmd) { cmd.ImagePullPolicy = "always" },
+		"registry-mirror":  func(cmd *UpCmd) { cmd.RegistryMirror = "https://mirror.example.com" },
+		"workspace-secret": func(cmd *UpCmd) { cmd.WorkspaceEnv = append(cmd.WorkspaceEnv, "A=x") },
+	} {
+		changed := &UpCmd{}
+		changed.WorkspaceEnv = []string{"FOO=bar"}
+		change(changed)
+		require.NotEqual(t, digest, changed.upOptionsDigest(ide), name)
+	}
+
+	require.NotEqual(t, digest, cmd.upOptionsDigest(provider2.WorkspaceIDEConfig{Name: "goland"}))
+	require.NotEqual(t, digest, cmd.upOptionsDigest(provider2.WorkspaceIDEConfig{
+		Name:    "vscode",
+		Options: map[string]config.OptionValue{"VERSION": {Value: "1.0"}},
+	}))
+}
+
+func TestSSHAuthSockIDIsReused(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+	workspace := &provider2.Workspace{ID: "my-workspace", Context: "default"}
+
+	id := sshAuthSockID(workspace, log.Discard)
+	require.Len(t, id, 10)
+	require.Equal(t, id, sshAuthSockID(workspace, log.Discard))
+}
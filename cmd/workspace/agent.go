@@ -0,0 +1,106 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/skevetter/devpod/pkg/agent"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/ssh"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
+	"github.com/skevetter/log"
+)
+
+// RunAgentCommand injects the agent into the workspace host and runs the given
+// agent command there, writing its output to stdout and stderr.
+func RunAgentCommand(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	client clientpkg.WorkspaceClient,
+	agentCommand string,
+	stdout io.Writer,
+	stderr io.Writer,
+	log log.Logger,
+) error {
+	// create readers
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = stdoutWriter.Close() }()
+	defer func() { _ = stdinWriter.Close() }()
+
+	// ssh tunnel command
+	algorithms, err := helperssh.AlgorithmsFromContext(devPodConfig)
+	if err != nil {
+		return err
+	}
+	sshServerCmd := algorithms.Command(
+		fmt.Sprintf("'%s' helper ssh-server --stdio", client.AgentPath()),
+	)
+	if log.GetLevel() == logrus.DebugLevel {
+		sshServerCmd += " --debug"
+	}
+
+	// Get the timeout from the context options
+	timeout := config.ParseTimeOption(devPodConfig, config.ContextOptionAgentInjectTimeout)
+
+	// start ssh server in background
+	go func() {
+		writer := log.ErrorStreamOnly().Writer(logrus.DebugLevel, false)
+		defer func() { _ = writer.Close() }()
+
+		err := agent.InjectAgent(&agent.InjectOptions{
+			Ctx: ctx,
+			Exec: func(ctx context.Context, command string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+				return client.Command(ctx, clientpkg.CommandOptions{
+					Command: command,
+					Stdin:   stdin,
+					Stdout:  stdout,
+					Stderr:  stderr,
+				})
+			},
+			IsLocal:         client.AgentLocal(),
+			RemoteAgentPath: client.AgentPath(),
+			DownloadURL:     client.AgentURL(),
+			Command:         sshServerCmd,
+			Stdin:           stdinReader,
+			Stdout:          stdoutWriter,
+			Stderr:          writer,
+			Log:             log.ErrorStreamOnly(),
+			Timeout:         timeout,
+		})
+		if err != nil {
+			log.Debugf("Error running agent ssh server: %v", err)
+		}
+	}()
+
+	if log.GetLevel() == logrus.DebugLevel {
+		agentCommand += " --debug"
+	}
+
+	// start ssh client as root / default user
+	sshClient, err := ssh.StdioClientWithUser(stdoutReader, stdinWriter, "" /* default */, false)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = sshClient.Close() }()
+
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+	return session.Run(agentCommand)
+}
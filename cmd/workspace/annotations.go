@@ -0,0 +1,164 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	annotationsSet    = "set"
+	annotationsGet    = "get"
+	annotationsList   = "list"
+	annotationsDelete = "delete"
+)
+
+// AnnotationsCmd holds the configuration.
+type AnnotationsCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewAnnotationsCmd creates a new annotations command.
+func NewAnnotationsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AnnotationsCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "annotations [workspace-path|workspace-name] set <key> <value> | get <key> | list | delete <key>",
+		Short: "Manages the annotations of a workspace",
+		Long: `Manages key value annotations of a workspace, e.g. a cost center or ticket number.
+Annotations are stored in the workspace config and never affect the container, unlike labels.
+They can be used to filter workspaces, e.g. devpod list --filter annotation.cost-center=engineering`,
+		Args: cobra.RangeArgs(2, 4),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1], args[2:])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{annotationsSet, annotationsGet, annotationsList, annotationsDelete},
+					cobra.ShellCompDirectiveNoFileComp
+			} else if len(args) > 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *AnnotationsCmd) Run(
+	ctx context.Context,
+	workspaceName, action string,
+	args []string,
+) error {
+	err := validateAnnotationsArgs(action, args)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	switch action {
+	case annotationsGet:
+		value, ok := workspaceConfig.Annotations[args[0]]
+		if !ok {
+			return fmt.Errorf("workspace %s has no annotation %s", workspaceConfig.ID, args[0])
+		}
+
+		fmt.Println(value)
+		return nil
+	case annotationsList:
+		printAnnotations(workspaceConfig.Annotations)
+		return nil
+	case annotationsSet:
+		if workspaceConfig.Annotations == nil {
+			workspaceConfig.Annotations = map[string]string{}
+		}
+		workspaceConfig.Annotations[args[0]] = args[1]
+	case annotationsDelete:
+		if _, ok := workspaceConfig.Annotations[args[0]]; !ok {
+			return fmt.Errorf("workspace %s has no annotation %s", workspaceConfig.ID, args[0])
+		}
+		delete(workspaceConfig.Annotations, args[0])
+	}
+
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	log.Default.Donef("Updated annotation %s of workspace %s", args[0], workspaceConfig.ID)
+	return nil
+}
+
+// validateAnnotationsArgs checks the number of arguments of the action and the annotation key.
+func validateAnnotationsArgs(action string, args []string) error {
+	expectedArgs := map[string]int{
+		annotationsSet:    2,
+		annotationsGet:    1,
+		annotationsList:   0,
+		annotationsDelete: 1,
+	}
+	expected, ok := expectedArgs[action]
+	if !ok {
+		return fmt.Errorf(
+			"unexpected action %s, choose one of %s, %s, %s or %s",
+			action, annotationsSet, annotationsGet, annotationsList, annotationsDelete,
+		)
+	} else if len(args) != expected {
+		return fmt.Errorf("%s expects %d arguments, got %d", action, expected, len(args))
+	}
+
+	if len(args) > 0 && (args[0] == "" || strings.Contains(args[0], "=")) {
+		return fmt.Errorf("invalid annotation key %q, keys must not be empty or contain =", args[0])
+	}
+
+	return nil
+}
+
+func printAnnotations(annotations map[string]string) {
+	tableEntries := [][]string{}
+	for _, key := range slices.Sorted(maps.Keys(annotations)) {
+		tableEntries = append(tableEntries, []string{key, annotations[key]})
+	}
+	table.Print([]string{
+		"Key",
+		"Value",
+	}, tableEntries)
+}
@@ -0,0 +1,20 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAnnotationsArgs(t *testing.T) {
+	assert.NoError(t, validateAnnotationsArgs("set", []string{"cost-center", "engineering"}))
+	assert.NoError(t, validateAnnotationsArgs("get", []string{"cost-center"}))
+	assert.NoError(t, validateAnnotationsArgs("list", nil))
+	assert.NoError(t, validateAnnotationsArgs("delete", []string{"cost-center"}))
+
+	assert.EqualError(t, validateAnnotationsArgs("set", []string{"cost-center"}), "set expects 2 arguments, got 1")
+	assert.EqualError(t, validateAnnotationsArgs("list", []string{"cost-center"}), "list expects 0 arguments, got 1")
+	assert.ErrorContains(t, validateAnnotationsArgs("remove", []string{"cost-center"}), "unexpected action remove")
+	assert.ErrorContains(t, validateAnnotationsArgs("set", []string{"a=b", "c"}), "invalid annotation key")
+	assert.ErrorContains(t, validateAnnotationsArgs("get", []string{""}), "invalid annotation key")
+}
@@ -0,0 +1,200 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	client2 "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/skevetter/log/survey"
+	"github.com/skevetter/log/terminal"
+	"github.com/spf13/cobra"
+)
+
+// AttachVolumeCmd holds the configuration.
+type AttachVolumeCmd struct {
+	*flags.GlobalFlags
+
+	Source   string
+	Target   string
+	Recreate bool
+}
+
+// NewAttachVolumeCmd creates a new attach-volume command.
+func NewAttachVolumeCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &AttachVolumeCmd{
+		GlobalFlags: flags,
+	}
+	attachVolumeCmd := &cobra.Command{
+		Use:   "attach-volume [flags] [workspace-path|workspace-name]",
+		Short: "Adds a bind mount to a workspace",
+		Long: `Adds a bind mount from the host into the workspace container. The mount is saved with
+the workspace and added whenever devpod up creates the container. Docker and Podman cannot add
+mounts to an existing container, so a running or stopped container has to be recreated via
+devpod up --recreate for the mount to show up.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	attachVolumeCmd.Flags().StringVar(&cmd.Source, "source", "",
+		"The path on the host to mount, must be absolute for remote providers")
+	attachVolumeCmd.Flags().StringVar(&cmd.Target, "target", "", "The path in the container to mount to")
+	attachVolumeCmd.Flags().BoolVar(&cmd.Recreate, "recreate", false,
+		"If true recreates a running workspace without asking to add the mount")
+	_ = attachVolumeCmd.MarkFlagRequired("source")
+	_ = attachVolumeCmd.MarkFlagRequired("target")
+	return attachVolumeCmd
+}
+
+// Run runs the command logic.
+func (cmd *AttachVolumeCmd) Run(ctx context.Context, args []string) error {
+	if !path.IsAbs(cmd.Target) {
+		return fmt.Errorf("target %s must be an absolute path", cmd.Target)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	agentClient, ok := client.(client2.Client)
+	source, err := resolveVolumeSource(cmd.Source, ok && agentClient.AgentLocal())
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceConfig.ExtraMounts = addExtraMount(
+		workspaceConfig.ExtraMounts,
+		fmt.Sprintf("type=bind,source=%s,target=%s", source, cmd.Target),
+	)
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+	log.Default.Donef("Attached %s to %s in workspace %s", source, cmd.Target, workspaceConfig.ID)
+
+	status, err := client.Status(ctx, client2.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status == client2.StatusNotFound {
+		log.Default.Infof("The mount will be added on the next devpod up")
+		return nil
+	} else if status != client2.StatusRunning {
+		// an existing container is only restarted by devpod up, so it has to be recreated
+		log.Default.Infof(
+			"Run devpod up --recreate %s to add the mount to the workspace",
+			workspaceConfig.ID,
+		)
+		return nil
+	}
+
+	recreate, err := cmd.confirmRecreate(workspaceConfig.ID)
+	if err != nil {
+		return err
+	} else if !recreate {
+		log.Default.Infof(
+			"Run devpod up --recreate %s to add the mount to the running workspace",
+			workspaceConfig.ID,
+		)
+		return nil
+	}
+
+	return runDevPodCommand(
+		ctx,
+		cmd.GlobalFlags,
+		[]string{"up", "--recreate", workspaceConfig.ID},
+		os.Stdin,
+		os.Stdout,
+		os.Stderr,
+	)
+}
+
+// confirmRecreate asks if the running workspace should be recreated to add the mount.
+func (cmd *AttachVolumeCmd) confirmRecreate(workspaceID string) (bool, error) {
+	if cmd.Recreate {
+		return true, nil
+	} else if !terminal.IsTerminalIn {
+		return false, nil
+	}
+
+	const (
+		yesOption = "Yes"
+		noOption  = "No"
+	)
+	answer, err := log.Default.Question(&survey.QuestionOptions{
+		Question: fmt.Sprintf(
+			"Workspace %s is running. Do you want to recreate the container to add the mount?",
+			workspaceID,
+		),
+		DefaultValue: yesOption,
+		Options:      []string{yesOption, noOption},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return answer == yesOption, nil
+}
+
+// resolveVolumeSource returns the absolute source of the mount. Relative paths are only
+// resolved for local providers, for remote providers the source is a path on the remote host.
+func resolveVolumeSource(source string, local bool) (string, error) {
+	if local {
+		return filepath.Abs(source)
+	} else if !path.IsAbs(source) {
+		return "", fmt.Errorf(
+			"source %s must be an absolute path on the host of the remote provider",
+			source,
+		)
+	}
+
+	return path.Clean(source), nil
+}
+
+// addExtraMount adds mount to mounts and replaces an existing mount with the same target.
+func addExtraMount(mounts []string, mount string) []string {
+	target := devcontainerconfig.ParseMount(mount).Target
+	result := []string{}
+	for _, existing := range mounts {
+		if devcontainerconfig.ParseMount(existing).Target != target {
+			result = append(result, existing)
+		}
+	}
+
+	return append(result, mount)
+}
@@ -0,0 +1,37 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddExtraMountReplacesSameTarget(t *testing.T) {
+	mounts := []string{
+		"type=bind,source=/data,target=/mnt/data",
+		"type=bind,source=/cache,target=/mnt/cache",
+	}
+
+	require.Equal(t, []string{
+		"type=bind,source=/cache,target=/mnt/cache",
+		"type=bind,source=/other,target=/mnt/data",
+	}, addExtraMount(mounts, "type=bind,source=/other,target=/mnt/data"))
+}
+
+func TestResolveVolumeSource(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	source, err := resolveVolumeSource("data", true)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(wd, "data"), source)
+
+	source, err = resolveVolumeSource("/data/../cache/", false)
+	require.NoError(t, err)
+	require.Equal(t, "/cache", source)
+
+	_, err = resolveVolumeSource("data", false)
+	require.ErrorContains(t, err, "must be an absolute path")
+}
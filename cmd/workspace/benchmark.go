@@ -0,0 +1,276 @@
+package workspace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	"github.com/skevetter/devpod/pkg/table"
+	"github.com/skevetter/devpod/pkg/tunnel"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+)
+
+// BenchmarkCmd holds the configuration.
+type BenchmarkCmd struct {
+	*flags.GlobalFlags
+
+	Iterations     int
+	ThroughputSize int
+	Output         string
+}
+
+// BenchmarkReport is the result of a workspace benchmark.
+type BenchmarkReport struct {
+	Workspace              string  `json:"workspace"`
+	HandshakeMilliseconds  float64 `json:"handshakeMilliseconds"`
+	Iterations             int     `json:"iterations"`
+	LatencyP50Milliseconds float64 `json:"latencyP50Milliseconds"`
+	LatencyP95Milliseconds float64 `json:"latencyP95Milliseconds"`
+	ThroughputBytes        int64   `json:"throughputBytes"`
+	ThroughputMBPerSecond  float64 `json:"throughputMBPerSecond"`
+}
+
+// NewBenchmarkCmd creates a new benchmark command.
+func NewBenchmarkCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BenchmarkCmd{
+		GlobalFlags: flags,
+	}
+	benchmarkCmd := &cobra.Command{
+		Use:   "benchmark [flags] [workspace-path|workspace-name]",
+		Short: "Measures the ssh latency and throughput to a workspace",
+		Long: `Measures the time to establish a ssh connection to the workspace, the P50 and P95
+latency of round-trips through the connection and the throughput of reading zeros from the
+workspace. The workspace has to be running.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	benchmarkCmd.Flags().IntVar(&cmd.Iterations, "iterations", 50, "The number of round-trips to measure the latency")
+	benchmarkCmd.Flags().IntVar(&cmd.ThroughputSize, "throughput-size", 64,
+		"The amount of data in MB to transfer to measure the throughput")
+	benchmarkCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return benchmarkCmd
+}
+
+// Run runs the command logic.
+func (cmd *BenchmarkCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	} else if cmd.Iterations < 1 {
+		return fmt.Errorf("--iterations must be at least 1")
+	} else if cmd.ThroughputSize < 1 {
+		return fmt.Errorf("--throughput-size must be at least 1")
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	sshCmd, err := tunnel.CreateSSHCommand(ctx, client, log.Default, []string{"--stdio"})
+	if err != nil {
+		return err
+	}
+	stdout, err := sshCmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stdin, err := sshCmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	writer := log.Default.Writer(logrus.DebugLevel, false)
+	defer func() { _ = writer.Close() }()
+	sshCmd.Stderr = writer
+
+	report := &BenchmarkReport{Workspace: client.Workspace(), Iterations: cmd.Iterations}
+	log.Default.Infof("Connecting to workspace %s", client.Workspace())
+	start := time.Now()
+	err = sshCmd.Start()
+	if err != nil {
+		return fmt.Errorf("start ssh: %w", err)
+	}
+	defer func() {
+		_ = sshCmd.Process.Kill()
+		_ = sshCmd.Wait()
+	}()
+
+	sshClient, err := devssh.StdioClient(stdout, stdin, false)
+	if err != nil {
+		return fmt.Errorf("connect to workspace: %w", err)
+	}
+	defer func() { _ = sshClient.Close() }()
+	report.HandshakeMilliseconds = milliseconds(time.Since(start))
+
+	log.Default.Infof("Measuring latency with %d round-trips", cmd.Iterations)
+	latencies, err := measureLatency(sshClient, cmd.Iterations)
+	if err != nil {
+		return fmt.Errorf("measure latency: %w", err)
+	}
+	report.LatencyP50Milliseconds = milliseconds(percentile(latencies, 0.5))
+	report.LatencyP95Milliseconds = milliseconds(percentile(latencies, 0.95))
+
+	log.Default.Infof("Measuring throughput with %d MB", cmd.ThroughputSize)
+	bytes, duration, err := measureThroughput(sshClient, cmd.ThroughputSize)
+	if err != nil {
+		return fmt.Errorf("measure throughput: %w", err)
+	}
+	report.ThroughputBytes = bytes
+	report.ThroughputMBPerSecond = float64(bytes) / 1024 / 1024 / duration.Seconds()
+
+	return cmd.printReport(report)
+}
+
+func (cmd *BenchmarkCmd) printReport(report *BenchmarkReport) error {
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	table.Print([]string{
+		"Workspace",
+		"Handshake",
+		"Latency P50",
+		"Latency P95",
+		"Throughput",
+	}, [][]string{{
+		report.Workspace,
+		formatMilliseconds(report.HandshakeMilliseconds),
+		formatMilliseconds(report.LatencyP50Milliseconds),
+		formatMilliseconds(report.LatencyP95Milliseconds),
+		strconv.FormatFloat(report.ThroughputMBPerSecond, 'f', 1, 64) + " MB/s",
+	}})
+	return nil
+}
+
+// measureLatency echoes a line through cat in the workspace and returns the duration of every round-trip.
+func measureLatency(sshClient *ssh.Client, iterations int) ([]time.Duration, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = session.Close() }()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	err = session.Start("cat")
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bufio.NewReader(stdout)
+	latencies := make([]time.Duration, 0, iterations)
+	for range iterations {
+		start := time.Now()
+		_, err = io.WriteString(stdin, "ping\n")
+		if err != nil {
+			return nil, err
+		}
+		_, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		latencies = append(latencies, time.Since(start))
+	}
+
+	return latencies, nil
+}
+
+// measureThroughput reads sizeMB megabytes of zeros from the workspace and returns the bytes read
+// and the time it took.
+func measureThroughput(sshClient *ssh.Client, sizeMB int) (int64, time.Duration, error) {
+	session, err := sshClient.NewSession()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func() { _ = session.Close() }()
+
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	start := time.Now()
+	err = session.Start(fmt.Sprintf("dd if=/dev/zero bs=1048576 count=%d 2>/dev/null", sizeMB))
+	if err != nil {
+		return 0, 0, err
+	}
+	bytes, err := io.Copy(io.Discard, stdout)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = session.Wait()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return bytes, time.Since(start), nil
+}
+
+// percentile returns the nearest-rank percentile q of durations.
+func percentile(durations []time.Duration, q float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := slices.Sorted(slices.Values(durations))
+	rank := max(int(math.Ceil(q*float64(len(sorted)))), 1)
+	return sorted[rank-1]
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+func formatMilliseconds(milliseconds float64) string {
+	return strconv.FormatFloat(milliseconds, 'f', 1, 64) + "ms"
+}
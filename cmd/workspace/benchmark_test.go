@@ -0,0 +1,20 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{}
+	for i := 20; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	require.Equal(t, 10*time.Millisecond, percentile(durations, 0.5))
+	require.Equal(t, 19*time.Millisecond, percentile(durations, 0.95))
+	require.Equal(t, 1*time.Millisecond, percentile(durations[19:], 0.95))
+	require.Equal(t, time.Duration(0), percentile(nil, 0.5))
+}
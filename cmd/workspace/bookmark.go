@@ -0,0 +1,216 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// BookmarkCmd holds the configuration.
+type BookmarkCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// NewBookmarkCmd creates a new bookmark command.
+func NewBookmarkCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BookmarkCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "bookmark [workspace-path|workspace-name] [alias]",
+		Short: "Adds a short alias for a workspace",
+		Long: `Adds a short alias for a workspace. The alias can be used instead of the workspace
+name in all commands, e.g. devpod ssh py`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// NewBookmarksCmd creates a new bookmarks command.
+func NewBookmarksCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BookmarkCmd{
+		GlobalFlags: flags,
+	}
+	bookmarksCmd := &cobra.Command{
+		Use:   "bookmarks",
+		Short: "Lists all workspace aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.List()
+		},
+	}
+
+	bookmarksCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return bookmarksCmd
+}
+
+// NewUnbookmarkCmd creates a new unbookmark command.
+func NewUnbookmarkCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &BookmarkCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "unbookmark [alias]",
+		Short: "Removes a workspace alias",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Remove(args[0])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			bookmarks, err := workspace2.LoadBookmarks()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+
+			aliases := []string{}
+			for alias := range bookmarks {
+				aliases = append(aliases, alias)
+			}
+			slices.Sort(aliases)
+			return aliases, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *BookmarkCmd) Run(ctx context.Context, workspaceName, alias string) error {
+	err := workspace2.ValidateBookmarkAlias(alias)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	bookmarks, err := workspace2.LoadBookmarks()
+	if err != nil {
+		return err
+	}
+
+	workspaceID := client.Workspace()
+	if existing, ok := bookmarks[alias]; ok && existing.WorkspaceID != workspaceID {
+		log.Default.Infof("Alias %s pointed to workspace %s before", alias, existing.WorkspaceID)
+	}
+	bookmarks[alias] = workspace2.Bookmark{
+		WorkspaceID: workspaceID,
+		Context:     devPodConfig.DefaultContext,
+	}
+	err = workspace2.SaveBookmarks(bookmarks)
+	if err != nil {
+		return fmt.Errorf("save bookmarks: %w", err)
+	}
+
+	log.Default.Donef("Added alias %s for workspace %s", alias, workspaceID)
+	return nil
+}
+
+// List prints all bookmarks.
+func (cmd *BookmarkCmd) List() error {
+	bookmarks, err := workspace2.LoadBookmarks()
+	if err != nil {
+		return err
+	}
+
+	switch cmd.Output {
+	case "json":
+		out, err := json.MarshalIndent(bookmarks, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+	case "plain":
+		aliases := make([]string, 0, len(bookmarks))
+		for alias := range bookmarks {
+			aliases = append(aliases, alias)
+		}
+		slices.Sort(aliases)
+
+		tableEntries := [][]string{}
+		for _, alias := range aliases {
+			tableEntries = append(tableEntries, []string{
+				alias,
+				bookmarks[alias].WorkspaceID,
+				bookmarks[alias].Context,
+			})
+		}
+
+		table.Print([]string{
+			"Alias",
+			"Workspace",
+			"Context",
+		}, tableEntries)
+	default:
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	}
+
+	return nil
+}
+
+// Remove removes the bookmark with the given alias.
+func (cmd *BookmarkCmd) Remove(alias string) error {
+	bookmarks, err := workspace2.LoadBookmarks()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := bookmarks[alias]; !ok {
+		return fmt.Errorf("alias %s doesn't exist", alias)
+	}
+
+	delete(bookmarks, alias)
+	err = workspace2.SaveBookmarks(bookmarks)
+	if err != nil {
+		return fmt.Errorf("save bookmarks: %w", err)
+	}
+
+	log.Default.Donef("Removed alias %s", alias)
+	return nil
+}
@@ -0,0 +1,139 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// CleanupTempCmd holds the configuration.
+type CleanupTempCmd struct {
+	*flags.GlobalFlags
+
+	Confirm bool
+}
+
+// NewCleanupTempCmd creates a new cleanup-temp command.
+func NewCleanupTempCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CleanupTempCmd{
+		GlobalFlags: flags,
+	}
+	cleanupTempCmd := &cobra.Command{
+		Use:   "cleanup-temp [flags] [workspace-path|workspace-name]",
+		Short: "Removes leftover temporary files of workspaces",
+		Long: `Scans the local workspace folders for leftover .docker-compose override files
+and reports their size. If no workspace is given, all workspaces of the current context are scanned.
+Pass --confirm to remove them.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	cleanupTempCmd.Flags().BoolVar(&cmd.Confirm, "confirm", false,
+		"Remove the found folders instead of only printing them")
+	return cleanupTempCmd
+}
+
+// Run runs the command logic.
+func (cmd *CleanupTempCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	workspaceIDs, err := cmd.workspaceIDs(ctx, devPodConfig, args)
+	if err != nil {
+		return err
+	}
+
+	folders := []workspace2.TempFolder{}
+	for _, workspaceID := range workspaceIDs {
+		workspaceFolders, err := workspace2.FindTempFolders(devPodConfig.DefaultContext, workspaceID)
+		if err != nil {
+			return err
+		}
+
+		folders = append(folders, workspaceFolders...)
+	}
+
+	if len(folders) == 0 {
+		log.Default.Info("No leftover temporary files found")
+		return nil
+	}
+
+	var totalSize int64
+	for _, folder := range folders {
+		totalSize += folder.Size
+		fmt.Printf("%s\t%s\t%s\n", folder.WorkspaceID, folder.Path, units.HumanSize(float64(folder.Size)))
+	}
+
+	if !cmd.Confirm {
+		log.Default.Infof(
+			"Found %d folder(s) with a total size of %s, run again with --confirm to remove them",
+			len(folders),
+			units.HumanSize(float64(totalSize)),
+		)
+		return nil
+	}
+
+	err = workspace2.RemoveTempFolders(folders)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Removed %d folder(s), freed %s", len(folders), units.HumanSize(float64(totalSize)))
+	return nil
+}
+
+func (cmd *CleanupTempCmd) workspaceIDs(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	args []string,
+) ([]string, error) {
+	if len(args) > 0 {
+		client, err := workspace2.Get(ctx, workspace2.GetOptions{
+			DevPodConfig: devPodConfig,
+			Args:         args,
+			Owner:        cmd.Owner,
+			Log:          log.Default,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{client.Workspace()}, nil
+	}
+
+	workspaces, err := workspace2.ListLocalWorkspaces(devPodConfig.DefaultContext, false, log.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceIDs := []string{}
+	for _, workspace := range workspaces {
+		workspaceIDs = append(workspaceIDs, workspace.ID)
+	}
+
+	return workspaceIDs, nil
+}
@@ -0,0 +1,162 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/git"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// CloneCmd holds the configuration.
+type CloneCmd struct {
+	*flags.GlobalFlags
+
+	NewID        string
+	WorkspaceEnv []string
+}
+
+// NewCloneCmd creates a new clone command.
+func NewCloneCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CloneCmd{
+		GlobalFlags: flags,
+	}
+	cloneCmd := &cobra.Command{
+		Use:   "clone [flags] [workspace-path|workspace-name]",
+		Short: "Creates a new workspace from the source of an existing workspace",
+		Long: `Creates a new workspace with the source, provider, IDE and devcontainer settings of an
+existing workspace and starts it via devpod up. The new workspace gets a fresh container, nothing
+of the container of the existing workspace is copied. The workspace env variables of the existing
+workspace are inherited, --workspace-env adds to or overrides them.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	cloneCmd.Flags().StringVar(&cmd.NewID, "new-id", "", "The id of the new workspace")
+	cloneCmd.Flags().StringArrayVar(&cmd.WorkspaceEnv, "workspace-env", []string{},
+		"Extra env variables to put into the new workspace, e.g. MY_ENV_VAR=MY_VALUE")
+	_ = cloneCmd.MarkFlagRequired("new-id")
+	return cloneCmd
+}
+
+// Run runs the command logic.
+func (cmd *CloneCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	if provider.WorkspaceExists(client.Context(), cmd.NewID) {
+		return fmt.Errorf("workspace %s already exists", cmd.NewID)
+	}
+
+	workspaceEnv, err := provider.LoadWorkspaceEnv(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace env: %w", err)
+	}
+
+	// later variables take precedence in devpod up
+	upArgs, err := cloneUpArgs(
+		client.WorkspaceConfig(),
+		cmd.NewID,
+		append(workspaceEnv, cmd.WorkspaceEnv...),
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Infof("Cloning workspace %s into %s", client.Workspace(), cmd.NewID)
+	return runDevPodCommand(ctx, cmd.GlobalFlags, upArgs, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// cloneUpArgs returns the devpod up args that create a new workspace with the settings of workspace.
+func cloneUpArgs(workspace *provider.Workspace, newID string, workspaceEnv []string) ([]string, error) {
+	source := cloneSource(workspace.Source)
+	if source == "" {
+		return nil, fmt.Errorf("workspace %s has no source", workspace.ID)
+	}
+
+	args := []string{"up", source, "--source", source, "--id", newID}
+	if workspace.Provider.Name != "" {
+		args = append(args, "--provider", workspace.Provider.Name)
+	}
+	for _, option := range userProvidedOptions(workspace.Provider.Options) {
+		args = append(args, "--provider-option", option)
+	}
+	if workspace.IDE.Name != "" {
+		args = append(args, "--ide", workspace.IDE.Name)
+	}
+	for _, option := range userProvidedOptions(workspace.IDE.Options) {
+		args = append(args, "--ide-option", option)
+	}
+	if workspace.IDE.Shell != "" {
+		args = append(args, "--shell", workspace.IDE.Shell)
+	}
+	if workspace.DevContainerPath != "" {
+		args = append(args, "--devcontainer-path", workspace.DevContainerPath)
+	}
+	if workspace.DevContainerImage != "" {
+		args = append(args, "--devcontainer-image", workspace.DevContainerImage)
+	}
+	for _, env := range workspaceEnv {
+		args = append(args, "--workspace-env", env)
+	}
+
+	return args, nil
+}
+
+// cloneSource returns the source in the form of the devpod up --source flag.
+func cloneSource(source provider.WorkspaceSource) string {
+	value := source.String()
+	if source.GitRepository != "" && source.GitSubPath != "" && source.GitPRReference == "" {
+		value += git.SubPathDelimiter + source.GitSubPath
+	}
+
+	return value
+}
+
+// userProvidedOptions returns the options the user set as KEY=VALUE pairs, options filled in by
+// DevPod are resolved again for the new workspace.
+func userProvidedOptions(options map[string]config.OptionValue) []string {
+	result := []string{}
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		if options[key].UserProvided {
+			result = append(result, key+"="+options[key].Value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneUpArgs(t *testing.T) {
+	workspace := &provider.Workspace{
+		ID: "my-workspace",
+		Source: provider.WorkspaceSource{
+			GitRepository: "https://github.com/my-org/my-repo",
+			GitBranch:     "main",
+			GitSubPath:    "backend",
+		},
+		Provider: provider.WorkspaceProviderConfig{
+			Name: "docker",
+			Options: map[string]config.OptionValue{
+				"DOCKER_PATH": {Value: "/usr/bin/docker", UserProvided: true},
+				"INACTIVITY":  {Value: "10m"},
+			},
+		},
+		IDE: provider.WorkspaceIDEConfig{Name: "vscode"},
+	}
+
+	args, err := cloneUpArgs(workspace, "my-clone", []string{"FOO=bar"})
+	require.NoError(t, err)
+	source := "git:https://github.com/my-org/my-repo@main@subpath:backend"
+	require.Equal(t, []string{
+		"up", source, "--source", source, "--id", "my-clone",
+		"--provider", "docker",
+		"--provider-option", "DOCKER_PATH=/usr/bin/docker",
+		"--ide", "vscode",
+		"--workspace-env", "FOO=bar",
+	}, args)
+}
+
+func TestCloneUpArgsRequiresSource(t *testing.T) {
+	_, err := cloneUpArgs(&provider.Workspace{ID: "my-workspace"}, "my-clone", nil)
+	require.EqualError(t, err, "workspace my-workspace has no source")
+}
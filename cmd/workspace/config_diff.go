@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	diffColorAdded   = "\033[32m"
+	diffColorRemoved = "\033[31m"
+	diffColorReset   = "\033[0m"
+)
+
+// ConfigDiffCmd holds the configuration.
+type ConfigDiffCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewConfigDiffCmd creates a new config-diff command.
+func NewConfigDiffCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ConfigDiffCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "config-diff [flags] [workspace-path|workspace-name]",
+		Short: "Shows the devcontainer.json changes since the last up",
+		Long: `Compares the devcontainer config of the last devpod up with the config resolved from the
+current devcontainer.json of the workspace and prints a unified diff. These are the changes
+devpod up --recreate would apply. Features are compared by the digest of their options.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *ConfigDiffCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil || result.MergedConfig == nil {
+		return fmt.Errorf("workspace %s has not been started yet", client.Workspace())
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace config-diff --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	stdout := &bytes.Buffer{}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	currentConfig := &devcontainerconfig.MergedDevContainerConfig{}
+	err = json.Unmarshal(stdout.Bytes(), currentConfig)
+	if err != nil {
+		return fmt.Errorf("parse devcontainer config: %w", err)
+	}
+
+	diff, err := devcontainer.DiffMergedConfigs(result.MergedConfig, currentConfig)
+	if err != nil {
+		return err
+	} else if diff == "" {
+		log.Default.Donef("devcontainer config of workspace %s is unchanged", client.Workspace())
+		return nil
+	}
+
+	// #nosec G115 -- fd is always a valid file descriptor
+	if term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == "" {
+		diff = colorizeDiff(diff)
+	}
+	fmt.Print(diff)
+	return nil
+}
+
+// colorizeDiff prints added lines in green and removed lines in red.
+func colorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffColorAdded + strings.TrimSuffix(line, "\n") + diffColorReset + "\n"
+		case strings.HasPrefix(line, "-"):
+			lines[i] = diffColorRemoved + strings.TrimSuffix(line, "\n") + diffColorReset + "\n"
+		}
+	}
+
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,20 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorizeDiff(t *testing.T) {
+	diff := "--- last up\n+++ current\n@@ -1,2 +1,2 @@\n-  \"a\": 1\n+  \"a\": 2\n   \"b\": 3\n"
+
+	assert.Equal(
+		t,
+		"--- last up\n+++ current\n@@ -1,2 +1,2 @@\n"+
+			diffColorRemoved+"-  \"a\": 1"+diffColorReset+"\n"+
+			diffColorAdded+"+  \"a\": 2"+diffColorReset+"\n"+
+			"   \"b\": 3\n",
+		colorizeDiff(diff),
+	)
+}
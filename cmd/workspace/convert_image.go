@@ -0,0 +1,111 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ConvertImageCmd holds the configuration.
+type ConvertImageCmd struct {
+	*flags.GlobalFlags
+
+	PushTo string
+}
+
+// NewConvertImageCmd creates a new convert-image command.
+func NewConvertImageCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ConvertImageCmd{
+		GlobalFlags: flags,
+	}
+	convertImageCmd := &cobra.Command{
+		Use:   "convert-image [flags] [workspace-path|workspace-name] --push-to <image>",
+		Short: "Converts a running workspace into an image",
+		Long: `Commits the running workspace container to an image and pushes it to a registry.
+Before the commit the docker config, git credentials and shell history of all users are
+removed from the workspace, the credentials are set up again by the next devpod up or
+devpod ssh. To use the image as a prebuild, push it to one of the prebuild repositories
+tagged with the prebuild hash.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	convertImageCmd.Flags().StringVar(&cmd.PushTo, "push-to", "",
+		"The image to push to, e.g. ghcr.io/my-org/my-image:tag")
+	_ = convertImageCmd.MarkFlagRequired("push-to")
+	return convertImageCmd
+}
+
+// Run runs the command logic.
+func (cmd *ConvertImageCmd) Run(ctx context.Context, args []string) error {
+	_, err := name.ParseReference(cmd.PushTo)
+	if err != nil {
+		return fmt.Errorf("parse --push-to: %w", err)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status != clientpkg.StatusRunning {
+		return fmt.Errorf("workspace %s is %s, please run devpod up first", client.Workspace(), status)
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace convert-image --context '%s' --id '%s' --image '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+		cmd.PushTo,
+	)
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+	if err != nil {
+		return fmt.Errorf("convert workspace %s: %w", client.Workspace(), err)
+	}
+
+	log.Default.Donef("Pushed workspace %s as image %s", client.Workspace(), cmd.PushTo)
+	return nil
+}
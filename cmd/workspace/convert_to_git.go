@@ -0,0 +1,141 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/git"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ConvertToGitCmd holds the configuration.
+type ConvertToGitCmd struct {
+	*flags.GlobalFlags
+
+	Remote string
+	SkipUp bool
+}
+
+// NewConvertToGitCmd creates a new convert-to-git command.
+func NewConvertToGitCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ConvertToGitCmd{
+		GlobalFlags: flags,
+	}
+	convertToGitCmd := &cobra.Command{
+		Use:   "convert-to-git [flags] [workspace-path|workspace-name]",
+		Short: "Converts a local folder workspace into a git workspace",
+		Long: `Changes the source of a local folder workspace to the given git repository and
+reprovisions the workspace from it via devpod up --reset. Changes in the local folder that
+were not pushed to the repository are not part of the workspace afterwards.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	convertToGitCmd.Flags().StringVar(&cmd.Remote, "remote", "",
+		"The git repository to use as workspace source, e.g. github.com/my-org/my-repo@main")
+	convertToGitCmd.Flags().BoolVar(&cmd.SkipUp, "skip-up", false,
+		"If true only changes the workspace source without reprovisioning the workspace")
+	_ = convertToGitCmd.MarkFlagRequired("remote")
+	return convertToGitCmd
+}
+
+// Run runs the command logic.
+func (cmd *ConvertToGitCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	localFolder := workspaceConfig.Source.LocalFolder
+	if localFolder == "" {
+		return fmt.Errorf("workspace %s is not a local folder workspace", workspaceConfig.ID)
+	}
+
+	gitRepository, gitPRReference, gitBranch, gitCommit, gitSubDir := git.NormalizeRepository(
+		cmd.Remote,
+	)
+	if !strings.HasSuffix(cmd.Remote, ".git") &&
+		!git.PingRepository(gitRepository, git.GetDefaultExtraEnv(false)) {
+		return fmt.Errorf("cannot reach git repository %s", gitRepository)
+	}
+
+	warnUncommittedChanges(ctx, localFolder, log.Default)
+
+	workspaceConfig.Source = provider.WorkspaceSource{
+		GitRepository:  gitRepository,
+		GitBranch:      gitBranch,
+		GitCommit:      gitCommit,
+		GitPRReference: gitPRReference,
+		GitSubPath:     gitSubDir,
+	}
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+	log.Default.Donef("Changed source of workspace %s to %s", workspaceConfig.ID, gitRepository)
+
+	if cmd.SkipUp {
+		return nil
+	}
+
+	// --reset is required as --recreate keeps the content folder of git workspaces,
+	// which still holds the uploaded local folder
+	return runDevPodCommand(
+		ctx,
+		cmd.GlobalFlags,
+		[]string{"up", "--reset", workspaceConfig.ID},
+		os.Stdin,
+		os.Stdout,
+		os.Stderr,
+	)
+}
+
+// warnUncommittedChanges warns if the local folder has changes that are not committed.
+func warnUncommittedChanges(ctx context.Context, localFolder string, log log.Logger) {
+	out, err := git.CommandContext(ctx, nil, "-C", localFolder, "status", "--porcelain").Output()
+	if err != nil {
+		log.Debugf("skip checking %s for uncommitted changes, git status failed: %v", localFolder, err)
+		return
+	}
+
+	if changes := strings.TrimSpace(string(out)); changes != "" {
+		log.Warnf(
+			"local folder %s has uncommitted changes, these are not part of the git workspace:\n%s",
+			localFolder,
+			changes,
+		)
+	}
+}
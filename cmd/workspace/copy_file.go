@@ -0,0 +1,354 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	copySpecLocal     = "local:"
+	copySpecContainer = "container:"
+)
+
+// copySpec is one side of a copy, either a local path or a path in the workspace container.
+type copySpec struct {
+	container bool
+	path      string
+}
+
+// CopyFileCmd holds the configuration.
+type CopyFileCmd struct {
+	*flags.GlobalFlags
+
+	Recursive bool
+	User      string
+}
+
+// NewCopyFileCmd creates a new copy-file command.
+func NewCopyFileCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CopyFileCmd{
+		GlobalFlags: flags,
+	}
+	copyFileCmd := &cobra.Command{
+		Use:   "copy-file [flags] [workspace-path|workspace-name] <src> <dst>",
+		Short: "Copies files between the local machine and a workspace",
+		Long: `Copies files between the local machine and the workspace container. Each of src and dst
+is either local:<path> or container:<path>, one of them needs to be in the container. Relative
+container paths are resolved against the workspace folder. Local docker workspaces are copied
+with docker cp, all other workspaces with scp through the ssh config entry of the workspace.`,
+		Example: `  devpod workspace copy-file my-workspace local:./config.yaml container:config.yaml
+  devpod workspace copy-file my-workspace --recursive container:/var/log/app local:./logs`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			paths := args[len(args)-2:]
+			return cmd.Run(cobraCmd.Context(), args[:len(args)-2], paths[0], paths[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	copyFileCmd.Flags().
+		BoolVarP(&cmd.Recursive, "recursive", "r", false, "If true copies directories")
+	copyFileCmd.Flags().StringVar(&cmd.User, "user", "",
+		"The user that owns the files copied into the container. Defaults to root with docker cp "+
+			"and to the remote user with scp")
+	return copyFileCmd
+}
+
+// Run runs the command logic.
+func (cmd *CopyFileCmd) Run(ctx context.Context, args []string, src, dst string) error {
+	srcSpec, dstSpec, err := parseCopySpecs(src, dst)
+	if err != nil {
+		return err
+	}
+	if !srcSpec.container && !cmd.Recursive {
+		info, err := os.Stat(srcSpec.path)
+		if err != nil {
+			return err
+		} else if info.IsDir() {
+			return fmt.Errorf("%s is a directory, use --recursive to copy it", srcSpec.path)
+		}
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	logger := log.Default.ErrorStreamOnly()
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.LoadWorkspaceResult(baseClient.Context(), baseClient.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result != nil {
+		workdir := resultWorkdir(result, baseClient.WorkspaceConfig().Source.GitSubPath)
+		srcSpec = srcSpec.resolve(workdir)
+		dstSpec = dstSpec.resolve(workdir)
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if ok && client.AgentLocal() {
+		_, agentInfo, err := client.AgentInfo(provider.CLIOptions{})
+		if err != nil {
+			return err
+		}
+
+		if agentInfo.Agent.Driver == "" || agentInfo.Agent.Driver == provider.DockerDriver {
+			err = cmd.copyDocker(ctx, agentInfo, srcSpec, dstSpec, logger)
+			if err != nil {
+				return err
+			}
+
+			log.Default.Donef("Copied %s to %s", src, dst)
+			return nil
+		}
+	}
+
+	err = cmd.copySCP(ctx, baseClient.Workspace(), srcSpec, dstSpec)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Copied %s to %s", src, dst)
+	return nil
+}
+
+// copyDocker copies the files with docker cp into or out of the local workspace container.
+func (cmd *CopyFileCmd) copyDocker(
+	ctx context.Context,
+	agentInfo *provider.AgentWorkspaceInfo,
+	src, dst copySpec,
+	log log.Logger,
+) error {
+	workspaceDriver, err := drivercreate.NewDriver(agentInfo, log)
+	if err != nil {
+		return err
+	}
+	dockerDriver, ok := workspaceDriver.(driver.DockerDriver)
+	if !ok {
+		return fmt.Errorf("unexpected driver for local docker workspace")
+	}
+	dockerHelper, err := dockerDriver.DockerHelper()
+	if err != nil {
+		return err
+	}
+
+	runnerID := devcontainer.GetRunnerIDFromWorkspace(agentInfo.Workspace)
+	containerDetails, err := workspaceDriver.FindDevContainer(ctx, runnerID)
+	if err != nil {
+		return err
+	} else if containerDetails == nil {
+		return fmt.Errorf("workspace container is not running, run devpod up first")
+	}
+
+	runAsRoot := func(command string) error {
+		return workspaceDriver.CommandDevContainer(ctx, runnerID, "root", command, nil, nil, nil)
+	}
+	if src.container && !cmd.Recursive {
+		if runAsRoot("test ! -d "+shellescape.Quote(src.path)) != nil {
+			return fmt.Errorf("%s is a directory, use --recursive to copy it", src.path)
+		}
+	}
+	chownTarget := ""
+	if dst.container && cmd.User != "" {
+		chownTarget = copyTarget(src, dst, runAsRoot("test -d "+shellescape.Quote(dst.path)) == nil)
+	}
+
+	err = dockerHelper.Copy(
+		ctx,
+		src.dockerPath(containerDetails.ID),
+		dst.dockerPath(containerDetails.ID),
+	)
+	if err != nil {
+		return fmt.Errorf("docker cp: %w", err)
+	}
+
+	if chownTarget != "" {
+		err = runAsRoot(chownCommand(cmd.User, chownTarget))
+		if err != nil {
+			return fmt.Errorf("change owner of %s to %s: %w", chownTarget, cmd.User, err)
+		}
+	}
+
+	return nil
+}
+
+// copySCP copies the files with scp through the ssh config entry of the workspace.
+func (cmd *CopyFileCmd) copySCP(ctx context.Context, workspace string, src, dst copySpec) error {
+	scpBinary, err := exec.LookPath("scp")
+	if err != nil {
+		return fmt.Errorf(
+			"find scp binary, copying files of remote workspaces requires OpenSSH: %w",
+			err,
+		)
+	}
+
+	runAsRoot := func(command string) error {
+		return runSSHCommand(ctx, cmd.GlobalFlags, workspace, "root",
+			command, nil, io.Discard, os.Stderr)
+	}
+	chownTarget := ""
+	if dst.container && cmd.User != "" {
+		chownTarget = copyTarget(src, dst, runAsRoot("test -d "+shellescape.Quote(dst.path)) == nil)
+	}
+
+	host := workspace + config.SSHHostSuffix
+	args := []string{}
+	if cmd.Recursive {
+		args = append(args, "-r")
+	}
+	args = append(args, src.scpPath(host), dst.scpPath(host))
+
+	// #nosec G204 -- arguments are the paths given by the user
+	scpCmd := exec.CommandContext(ctx, scpBinary, args...)
+	scpCmd.Stdout = os.Stdout
+	scpCmd.Stderr = os.Stderr
+	err = scpCmd.Run()
+	if err != nil {
+		return fmt.Errorf("scp, make sure the ssh config entry %s exists: %w", host, err)
+	}
+
+	if chownTarget != "" {
+		err = runAsRoot(chownCommand(cmd.User, chownTarget))
+		if err != nil {
+			return fmt.Errorf("change owner of %s to %s: %w", chownTarget, cmd.User, err)
+		}
+	}
+
+	return nil
+}
+
+// parseCopySpecs parses the source and destination, exactly one of them needs to be in the
+// container.
+func parseCopySpecs(src, dst string) (copySpec, copySpec, error) {
+	srcSpec, err := parseCopySpec(src)
+	if err != nil {
+		return copySpec{}, copySpec{}, err
+	}
+	dstSpec, err := parseCopySpec(dst)
+	if err != nil {
+		return copySpec{}, copySpec{}, err
+	}
+	if srcSpec.container == dstSpec.container {
+		return copySpec{}, copySpec{}, fmt.Errorf(
+			"exactly one of src and dst needs to start with %s", copySpecContainer,
+		)
+	}
+
+	return srcSpec, dstSpec, nil
+}
+
+func parseCopySpec(spec string) (copySpec, error) {
+	var parsed copySpec
+	switch {
+	case strings.HasPrefix(spec, copySpecLocal):
+		parsed = copySpec{path: strings.TrimPrefix(spec, copySpecLocal)}
+	case strings.HasPrefix(spec, copySpecContainer):
+		parsed = copySpec{container: true, path: strings.TrimPrefix(spec, copySpecContainer)}
+	default:
+		return copySpec{}, fmt.Errorf(
+			"invalid path %q, expected %s<path> or %s<path>",
+			spec,
+			copySpecLocal,
+			copySpecContainer,
+		)
+	}
+	if parsed.path == "" {
+		return copySpec{}, fmt.Errorf("invalid path %q, the path is empty", spec)
+	}
+
+	return parsed, nil
+}
+
+// resolve makes a relative container path absolute to the workspace folder.
+func (s copySpec) resolve(workdir string) copySpec {
+	if s.container && workdir != "" && !path.IsAbs(s.path) {
+		s.path = path.Join(workdir, s.path)
+	}
+
+	return s
+}
+
+func (s copySpec) dockerPath(containerID string) string {
+	if s.container {
+		return containerID + ":" + s.path
+	}
+
+	return s.localPath()
+}
+
+func (s copySpec) scpPath(host string) string {
+	if s.container {
+		return host + ":" + s.path
+	}
+
+	return s.localPath()
+}
+
+// localPath prefixes relative local paths containing a colon with ./, otherwise docker cp and
+// scp read them as a remote path.
+func (s copySpec) localPath() string {
+	if filepath.IsAbs(s.path) || !strings.Contains(s.path, ":") ||
+		strings.HasPrefix(s.path, "./") || strings.HasPrefix(s.path, "../") {
+		return s.path
+	}
+
+	return "./" + s.path
+}
+
+// copyTarget returns the container path a local source is copied to. docker cp and scp copy
+// into dst if it is an existing directory.
+func copyTarget(src, dst copySpec, dstIsDir bool) string {
+	if !dstIsDir {
+		return dst.path
+	}
+
+	return path.Join(dst.path, filepath.Base(src.path))
+}
+
+func chownCommand(user, target string) string {
+	return fmt.Sprintf("chown -R %s: %s", shellescape.Quote(user), shellescape.Quote(target))
+}
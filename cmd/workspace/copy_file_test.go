@@ -0,0 +1,54 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopySpecs(t *testing.T) {
+	src, dst, err := parseCopySpecs("local:./config.yaml", "container:config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, copySpec{path: "./config.yaml"}, src)
+	assert.Equal(t, copySpec{container: true, path: "config.yaml"}, dst)
+
+	_, _, err = parseCopySpecs("local:a", "local:b")
+	require.Error(t, err)
+	_, _, err = parseCopySpecs("container:a", "container:b")
+	require.Error(t, err)
+	_, _, err = parseCopySpecs("./a", "container:b")
+	require.Error(t, err)
+	_, _, err = parseCopySpecs("local:", "container:b")
+	require.Error(t, err)
+}
+
+func TestCopySpecPaths(t *testing.T) {
+	containerSpec := copySpec{container: true, path: "logs"}.resolve("/workspaces/app")
+	assert.Equal(t, "/workspaces/app/logs", containerSpec.path)
+	assert.Equal(t, "abc123:/workspaces/app/logs", containerSpec.dockerPath("abc123"))
+	assert.Equal(t, "app.devpod:/workspaces/app/logs", containerSpec.scpPath("app.devpod"))
+
+	localSpec := copySpec{path: "logs"}.resolve("/workspaces/app")
+	assert.Equal(t, "logs", localSpec.dockerPath("abc123"))
+	assert.Equal(t, "logs", localSpec.scpPath("app.devpod"))
+
+	absoluteSpec := copySpec{container: true, path: "/var/log"}.resolve("/workspaces/app")
+	assert.Equal(t, "/var/log", absoluteSpec.path)
+}
+
+func TestCopySpecLocalPathWithColon(t *testing.T) {
+	assert.Equal(t, "./a:b", copySpec{path: "a:b"}.dockerPath("abc123"))
+	assert.Equal(t, "./a:b", copySpec{path: "a:b"}.scpPath("app.devpod"))
+	assert.Equal(t, "./a:b", copySpec{path: "./a:b"}.scpPath("app.devpod"))
+	assert.Equal(t, "/tmp/a:b", copySpec{path: "/tmp/a:b"}.dockerPath("abc123"))
+}
+
+func TestCopyTarget(t *testing.T) {
+	src := copySpec{path: "./bin/tool"}
+	dst := copySpec{container: true, path: "/usr/local/bin"}
+	assert.Equal(t, "/usr/local/bin/tool", copyTarget(src, dst, true))
+
+	dst = copySpec{container: true, path: "/usr/local/bin/tool"}
+	assert.Equal(t, "/usr/local/bin/tool", copyTarget(src, dst, false))
+}
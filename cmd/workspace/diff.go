@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// DiffCmd holds the configuration.
+type DiffCmd struct {
+	*flags.GlobalFlags
+
+	Filter []string
+}
+
+// NewDiffCmd creates a new diff command.
+func NewDiffCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DiffCmd{
+		GlobalFlags: flags,
+	}
+	diffCmd := &cobra.Command{
+		Use:   "diff [flags] [workspace-path|workspace-name]",
+		Short: "Shows files changed in the workspace container relative to its image",
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	diffCmd.Flags().StringSliceVar(&cmd.Filter, "filter", []string{},
+		"Only show changes of the given kind: C (changed), A (added) or D (deleted)")
+	return diffCmd
+}
+
+// Run runs the command logic.
+func (cmd *DiffCmd) Run(ctx context.Context, args []string) error {
+	for _, kind := range cmd.Filter {
+		switch strings.ToUpper(kind) {
+		case "C", "A", "D":
+		default:
+			return fmt.Errorf("unexpected filter %s, choose one of C, A or D", kind)
+		}
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace diff --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if len(cmd.Filter) > 0 {
+		agentCommand += fmt.Sprintf(" --filter '%s'", strings.Join(cmd.Filter, ","))
+	}
+
+	return RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+}
@@ -0,0 +1,126 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/compress"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// DiffFeaturesCmd holds the configuration.
+type DiffFeaturesCmd struct {
+	*flags.GlobalFlags
+
+	DesiredFeatures string
+}
+
+// NewDiffFeaturesCmd creates a new diff-features command.
+func NewDiffFeaturesCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &DiffFeaturesCmd{
+		GlobalFlags: flags,
+	}
+	diffFeaturesCmd := &cobra.Command{
+		Use:   "diff-features [flags] [workspace-path|workspace-name]",
+		Short: "Previews the Dockerfile changes of a different set of features",
+		Long: `Resolves the features of the devcontainer.json and the desired features on top of the
+base image of the workspace and prints a unified diff of the two generated Dockerfiles. Nothing
+is built, use devpod up --recreate to apply the change.`,
+		Example: `  devpod workspace diff-features my-workspace \
+    --desired-features '{"ghcr.io/devcontainers/features/go:1": {"version": "1.23"}}'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	diffFeaturesCmd.Flags().StringVar(&cmd.DesiredFeatures, "desired-features", "",
+		`The desired features as JSON like the "features" section in devcontainer.json`)
+	_ = diffFeaturesCmd.MarkFlagRequired("desired-features")
+	return diffFeaturesCmd
+}
+
+// Run runs the command logic.
+func (cmd *DiffFeaturesCmd) Run(ctx context.Context, args []string) error {
+	desiredFeatures := map[string]any{}
+	err := json.Unmarshal([]byte(cmd.DesiredFeatures), &desiredFeatures)
+	if err != nil {
+		return fmt.Errorf("invalid desired features JSON: %w", err)
+	}
+	compressed, err := compress.Compress(cmd.DesiredFeatures)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace diff-features --context '%s' --id '%s' --desired-features '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+		compressed,
+	)
+	stdout := &bytes.Buffer{}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	diff := stdout.String()
+	if diff == "" {
+		log.Default.Donef(
+			"desired features of workspace %s don't change the image",
+			client.Workspace(),
+		)
+		return nil
+	}
+
+	// #nosec G115 -- fd is always a valid file descriptor
+	if term.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == "" {
+		diff = colorizeDiff(diff)
+	}
+	fmt.Print(diff)
+	return nil
+}
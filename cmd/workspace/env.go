@@ -0,0 +1,205 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// EnvCmd holds the configuration.
+type EnvCmd struct {
+	*flags.GlobalFlags
+
+	Filter string
+	Output string
+}
+
+// NewEnvCmd creates a new env command.
+func NewEnvCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &EnvCmd{
+		GlobalFlags: flags,
+	}
+	envCmd := &cobra.Command{
+		Use:   "env [flags] [workspace-path|workspace-name]",
+		Short: "Prints the environment variables of the workspace container",
+		Long: `Prints the environment variables set in the running workspace container. If the
+workspace is not running, the containerEnv of the devcontainer.json it was last started with is
+printed instead.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	envCmd.Flags().StringVar(&cmd.Filter, "filter", "", "Only print variables starting with the given prefix")
+	envCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be plain or dotenv")
+	return envCmd
+}
+
+// Run runs the command logic.
+func (cmd *EnvCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "dotenv" {
+		return fmt.Errorf("unexpected output format, choose either plain or dotenv. Got %s", cmd.Output)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	result, err := provider.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	}
+
+	var env map[string]string
+	if status == clientpkg.StatusRunning {
+		env, err = cmd.containerEnv(ctx, devPodConfig, client, result)
+		if err != nil {
+			return err
+		}
+	} else if result != nil && result.MergedConfig != nil {
+		log.Default.Infof(
+			"Workspace %s is %s, printing the containerEnv it was last started with",
+			client.Workspace(),
+			strings.ToLower(string(status)),
+		)
+		env = result.MergedConfig.ContainerEnv
+	} else {
+		return fmt.Errorf("workspace %s is not running and has no stored result", client.Workspace())
+	}
+
+	return printEnv(os.Stdout, filterEnv(env, cmd.Filter), cmd.Output)
+}
+
+// containerEnv returns the environment of the remote user in the running workspace container.
+func (cmd *EnvCmd) containerEnv(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	client clientpkg.WorkspaceClient,
+	result *devcontainerconfig.Result,
+) (map[string]string, error) {
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace env --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if result != nil {
+		agentCommand += fmt.Sprintf(" --user '%s'", devcontainerconfig.GetRemoteUser(result))
+	}
+
+	stdout := &bytes.Buffer{}
+	err := RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, os.Stderr, log.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEnv(stdout.String()), nil
+}
+
+// parseEnv parses the output of env, lines without a = belong to the value of the previous variable.
+func parseEnv(out string) map[string]string {
+	env := map[string]string{}
+	lastKey := ""
+	for line := range strings.SplitSeq(strings.TrimSuffix(out, "\n"), "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			if lastKey != "" {
+				env[lastKey] += "\n" + line
+			}
+			continue
+		}
+
+		env[key] = value
+		lastKey = key
+	}
+
+	return env
+}
+
+func filterEnv(env map[string]string, prefix string) map[string]string {
+	retEnv := map[string]string{}
+	for key, value := range env {
+		if strings.HasPrefix(key, prefix) {
+			retEnv[key] = value
+		}
+	}
+
+	return retEnv
+}
+
+func printEnv(w io.Writer, env map[string]string, output string) error {
+	keys := slices.Sorted(maps.Keys(env))
+	if output == "dotenv" {
+		for _, key := range keys {
+			_, err := fmt.Fprintf(w, "%s=%s\n", key, shellescape.Quote(env[key]))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	tableEntries := [][]string{}
+	for _, key := range keys {
+		tableEntries = append(tableEntries, []string{key, env[key]})
+	}
+	table.Print([]string{
+		"Name",
+		"Value",
+	}, tableEntries)
+	return nil
+}
@@ -0,0 +1,32 @@
+package workspace
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnv(t *testing.T) {
+	env := parseEnv("HOME=/home/vscode\nMULTI=first\nsecond line\nEMPTY=\nURL=https://example.com?a=b\n")
+
+	assert.Equal(t, map[string]string{
+		"HOME":  "/home/vscode",
+		"MULTI": "first\nsecond line",
+		"EMPTY": "",
+		"URL":   "https://example.com?a=b",
+	}, env)
+}
+
+func TestPrintEnvDotenv(t *testing.T) {
+	env := filterEnv(map[string]string{
+		"APP_NAME":  "my app",
+		"APP_DEBUG": "true",
+		"HOME":      "/root",
+	}, "APP_")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, printEnv(out, env, "dotenv"))
+	assert.Equal(t, "APP_DEBUG=true\nAPP_NAME='my app'\n", out.String())
+}
@@ -0,0 +1,156 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// EventsCmd holds the configuration.
+type EventsCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// NewEventsCmd creates a new events command.
+func NewEventsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &EventsCmd{
+		GlobalFlags: flags,
+	}
+	eventsCmd := &cobra.Command{
+		Use:   "events [flags] [workspace-path|workspace-name]",
+		Short: "Shows the event timeline of the last up of a workspace",
+		Long: `Shows the events DevPod and the workspace agent recorded during the last up of a
+workspace in chronological order. The agent events are only available while the workspace is running.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	eventsCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return eventsCmd
+}
+
+// Run runs the command logic.
+func (cmd *EventsCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	eventsFile, err := provider2.GetWorkspaceEventsFile(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	}
+	events, err := provider2.LoadWorkspaceEvents(eventsFile)
+	if err != nil {
+		return fmt.Errorf("read workspace events: %w", err)
+	}
+
+	agentEvents, err := readAgentEvents(ctx, devPodConfig, client)
+	if err != nil {
+		log.Default.Debugf("error reading agent events: %v", err)
+	}
+	events = append(events, agentEvents...)
+	provider2.SortWorkspaceEvents(events)
+
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(events, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	tableEntries := [][]string{}
+	for _, event := range events {
+		tableEntries = append(tableEntries, []string{
+			event.Timestamp.Local().Format(time.DateTime),
+			event.Phase,
+			event.Level,
+			event.Message,
+		})
+	}
+	table.Print([]string{
+		"Time",
+		"Phase",
+		"Level",
+		"Message",
+	}, tableEntries)
+	return nil
+}
+
+// readAgentEvents returns the events the agent recorded if the workspace is running.
+func readAgentEvents(
+	ctx context.Context,
+	devPodConfig *config.Config,
+	baseClient clientpkg.BaseWorkspaceClient,
+) ([]provider2.WorkspaceEvent, error) {
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return nil, nil
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return nil, err
+	} else if status != clientpkg.StatusRunning {
+		return nil, nil
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace events --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	stdout := &bytes.Buffer{}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, io.Discard, log.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider2.ParseWorkspaceEvents(stdout)
+}
@@ -0,0 +1,226 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/driver/drivercreate"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ExecCmd holds the configuration.
+type ExecCmd struct {
+	*flags.GlobalFlags
+
+	User string
+}
+
+// NewExecCmd creates a new exec command.
+func NewExecCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ExecCmd{
+		GlobalFlags: flags,
+	}
+	execCmd := &cobra.Command{
+		Use:   "exec [flags] [workspace-path|workspace-name] -- <command>",
+		Short: "Executes a command in the workspace container",
+		Long: `Executes a command in the workspace container. For local docker workspaces
+the command is executed directly via docker exec without starting an ssh session.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			workspaceArgs, command, err := splitCommandArgs(cobraCmd, args)
+			if err != nil {
+				return err
+			}
+
+			return cmd.Run(cobraCmd.Context(), workspaceArgs, command)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	execCmd.Flags().StringVar(&cmd.User, "user", "", "The user of the workspace to use")
+	return execCmd
+}
+
+// splitCommandArgs splits the arguments into the workspace arguments and the command after --.
+func splitCommandArgs(cobraCmd *cobra.Command, args []string) ([]string, []string, error) {
+	dash := cobraCmd.ArgsLenAtDash()
+	if dash == -1 || dash == len(args) {
+		return nil, nil, fmt.Errorf("please specify a command after --")
+	}
+
+	return args[:dash], args[dash:], nil
+}
+
+// Run runs the command logic.
+func (cmd *ExecCmd) Run(ctx context.Context, args []string, command []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	logger := log.Default.ErrorStreamOnly()
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	return cmd.exec(
+		ctx,
+		baseClient,
+		shellescape.QuoteCommand(command),
+		os.Stdin,
+		os.Stdout,
+		os.Stderr,
+		logger,
+	)
+}
+
+// exec runs the command via docker exec for local docker workspaces and via ssh otherwise.
+func (cmd *ExecCmd) exec(
+	ctx context.Context,
+	baseClient clientpkg.BaseWorkspaceClient,
+	command string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+	log log.Logger,
+) error {
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if ok && client.AgentLocal() {
+		_, agentInfo, err := client.AgentInfo(provider.CLIOptions{})
+		if err != nil {
+			return err
+		}
+
+		if agentInfo.Agent.Driver == "" || agentInfo.Agent.Driver == provider.DockerDriver {
+			return cmd.execDocker(ctx, agentInfo, command, stdin, stdout, stderr, log)
+		}
+	}
+
+	return runSSHCommand(
+		ctx,
+		cmd.GlobalFlags,
+		baseClient.Workspace(),
+		cmd.User,
+		command,
+		stdin,
+		stdout,
+		stderr,
+	)
+}
+
+// execDocker runs the command via docker exec in the local workspace container.
+func (cmd *ExecCmd) execDocker(
+	ctx context.Context,
+	agentInfo *provider.AgentWorkspaceInfo,
+	command string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+	log log.Logger,
+) error {
+	user := cmd.User
+	if user == "" {
+		result, err := provider.LoadWorkspaceResult(agentInfo.Workspace.Context, agentInfo.Workspace.ID)
+		if err != nil {
+			return err
+		}
+
+		user = config2.GetRemoteUser(result)
+	}
+
+	workspaceDriver, err := drivercreate.NewDriver(agentInfo, log)
+	if err != nil {
+		return err
+	}
+
+	return workspaceDriver.CommandDevContainer(
+		ctx,
+		devcontainer.GetRunnerIDFromWorkspace(agentInfo.Workspace),
+		user,
+		command,
+		stdin,
+		stdout,
+		stderr,
+	)
+}
+
+// runSSHCommand runs the command in the workspace via devpod ssh --command. An empty
+// user runs the command as the remote user of the workspace.
+func runSSHCommand(
+	ctx context.Context,
+	globalFlags *flags.GlobalFlags,
+	workspace string,
+	user string,
+	command string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) error {
+	args := []string{"ssh", "--command", command}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+	args = append(args, workspace)
+
+	return runDevPodCommand(ctx, globalFlags, args, stdin, stdout, stderr)
+}
+
+// runDevPodCommand runs the devpod binary itself with the given args and the context and
+// home of the global flags.
+func runDevPodCommand(
+	ctx context.Context,
+	globalFlags *flags.GlobalFlags,
+	args []string,
+	stdin io.Reader,
+	stdout io.Writer,
+	stderr io.Writer,
+) error {
+	execPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	if globalFlags.Context != "" {
+		args = append(args, "--context", globalFlags.Context)
+	}
+	if globalFlags.DevPodHome != "" {
+		args = append(args, "--"+config.BinaryName+"-home", globalFlags.DevPodHome)
+	}
+
+	// #nosec G204 -- the command is the devpod binary itself
+	devPodCmd := exec.CommandContext(ctx, execPath, args...)
+	devPodCmd.Stdin = stdin
+	devPodCmd.Stdout = stdout
+	devPodCmd.Stderr = stderr
+	return devPodCmd.Run()
+}
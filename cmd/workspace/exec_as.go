@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// NewExecAsCmd creates a new exec-as command.
+func NewExecAsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ExecCmd{
+		GlobalFlags: flags,
+	}
+	execAsCmd := &cobra.Command{
+		Use:   "exec-as [flags] [workspace-path|workspace-name] --user <user> -- <command>",
+		Short: "Executes a command as a different user in the workspace container",
+		Long: `Executes a command as the given container user or numeric UID. For local docker
+workspaces the command is executed directly via docker exec -u without starting an ssh
+session, for all other workspaces devpod ssh --user is used. The exit code of the
+command is passed through.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			workspaceArgs, command, err := splitCommandArgs(cobraCmd, args)
+			if err != nil {
+				return err
+			}
+
+			return cmd.Run(cobraCmd.Context(), workspaceArgs, command)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	execAsCmd.Flags().StringVar(&cmd.User, "user", "", "The container user name or numeric UID to run the command as")
+	_ = execAsCmd.MarkFlagRequired("user")
+	return execAsCmd
+}
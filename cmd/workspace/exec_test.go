@@ -0,0 +1,28 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecRequiresCommandAfterDash(t *testing.T) {
+	for _, args := range [][]string{
+		{"my-workspace"},
+		{"my-workspace", "--"},
+	} {
+		execCmd := NewExecCmd(&flags.GlobalFlags{})
+		execCmd.SetArgs(args)
+		execCmd.SilenceUsage = true
+		require.EqualError(t, execCmd.Execute(), "please specify a command after --")
+	}
+}
+
+func TestExecAsRequiresUser(t *testing.T) {
+	execAsCmd := NewExecAsCmd(&flags.GlobalFlags{})
+	execAsCmd.SetArgs([]string{"my-workspace", "--", "id"})
+	execAsCmd.SilenceUsage = true
+	execAsCmd.SilenceErrors = true
+	require.EqualError(t, execAsCmd.Execute(), `required flag(s) "user" not set`)
+}
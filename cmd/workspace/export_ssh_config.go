@@ -0,0 +1,128 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ExportSSHConfigCmd holds the configuration.
+type ExportSSHConfigCmd struct {
+	*flags.GlobalFlags
+
+	User               string
+	GPGAgentForwarding bool
+}
+
+// NewExportSSHConfigCmd creates a new export-ssh-config command.
+func NewExportSSHConfigCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ExportSSHConfigCmd{
+		GlobalFlags: flags,
+	}
+	exportSSHConfigCmd := &cobra.Command{
+		Use:   "export-ssh-config [flags] [workspace-path|workspace-name]",
+		Short: "Prints the ssh config entry of a workspace",
+		Long: `Prints the ssh config entry DevPod writes for the workspace to stdout without modifying
+any file, e.g. to feed it to ansible, rsync or scp.`,
+		Example: `  devpod workspace export-ssh-config my-workspace >> ~/.ssh/devpod_hosts
+  devpod workspace export-ssh-config my-workspace > /tmp/my-workspace.ssh-config
+  rsync -e "ssh -F /tmp/my-workspace.ssh-config" -a ./ my-workspace.devpod:`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	exportSSHConfigCmd.Flags().StringVar(&cmd.User, "user", "",
+		"The user to connect as. Defaults to the remote user of the last devpod up")
+	exportSSHConfigCmd.Flags().BoolVar(&cmd.GPGAgentForwarding, "gpg-agent-forwarding", false,
+		"If true the entry forwards the gpg agent")
+	return exportSSHConfigCmd
+}
+
+// Run runs the command logic.
+func (cmd *ExportSSHConfigCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default.ErrorStreamOnly(),
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	}
+
+	user, workdir := cmd.User, ""
+	if result != nil {
+		if user == "" {
+			user = devcontainerconfig.GetRemoteUser(result)
+		}
+		workdir = resultWorkdir(result, client.WorkspaceConfig().Source.GitSubPath)
+	}
+	if user == "" {
+		user = "root"
+	}
+
+	entry, err := devssh.SSHConfigEntry(devssh.SSHConfigParams{
+		Context:   client.Context(),
+		Workspace: client.Workspace(),
+		User:      user,
+		Workdir:   workdir,
+		GPGAgent: cmd.GPGAgentForwarding ||
+			devPodConfig.ContextOption(config.ContextOptionGPGAgentForwarding) == config.BoolTrue,
+		DevPodHome:   os.Getenv(config.EnvHome),
+		Provider:     client.Provider(),
+		IdentityFile: devssh.GetWorkspaceIdentityFile(client.Context(), client.Workspace()),
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(entry)
+	return nil
+}
+
+// resultWorkdir returns the workspace folder in the container the same way devpod up does.
+func resultWorkdir(result *devcontainerconfig.Result, gitSubPath string) string {
+	if gitSubPath != "" && result.SubstitutionContext != nil {
+		return filepath.Join(result.SubstitutionContext.ContainerWorkspaceFolder, gitSubPath)
+	} else if result.MergedConfig != nil {
+		return result.MergedConfig.WorkspaceFolder
+	}
+
+	return ""
+}
@@ -0,0 +1,21 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultWorkdir(t *testing.T) {
+	result := &config.Result{
+		MergedConfig: &config.MergedDevContainerConfig{
+			DevContainerConfigBase: config.DevContainerConfigBase{WorkspaceFolder: "/workspaces/app"},
+		},
+		SubstitutionContext: &config.SubstitutionContext{ContainerWorkspaceFolder: "/workspaces/repo"},
+	}
+
+	assert.Equal(t, "/workspaces/app", resultWorkdir(result, ""))
+	assert.Equal(t, "/workspaces/repo/backend", resultWorkdir(result, "backend"))
+	assert.Empty(t, resultWorkdir(&config.Result{}, ""))
+}
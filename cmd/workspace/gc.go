@@ -0,0 +1,218 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/command"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/git"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/skevetter/log/survey"
+	"github.com/skevetter/log/terminal"
+	"github.com/spf13/cobra"
+)
+
+// GCCmd holds the configuration.
+type GCCmd struct {
+	*flags.GlobalFlags
+
+	Delete                    bool
+	Force                     bool
+	CheckGitReachability      bool
+	DeleteMissingRepositories bool
+}
+
+// staleWorkspace is a workspace whose source no longer exists.
+type staleWorkspace struct {
+	workspace *provider.Workspace
+	reason    string
+
+	// repositoryNotFound is set if the git repository was reported as not found, these
+	// workspaces are only deleted after a confirmation
+	repositoryNotFound bool
+}
+
+// NewGCCmd creates a new gc command.
+func NewGCCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &GCCmd{
+		GlobalFlags: flags,
+	}
+	gcCmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Finds workspaces whose source no longer exists",
+		Long: `Lists the workspaces of the current context whose local folder was removed. With
+--check-git-reachability git workspaces whose repository is reported as not found are listed as
+well, this requires network access. Repositories that can't be reached because of a network
+error are not considered stale. Git hosts like GitHub also report private repositories as not
+found if credentials are missing or lack access. Pass --delete to delete the listed workspaces,
+pinned workspaces are skipped unless --force is set. Workspaces with a repository that was not
+found are only deleted after a confirmation or with --delete-missing-repositories.`,
+		Args: cobra.NoArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context())
+		},
+	}
+
+	gcCmd.Flags().BoolVar(&cmd.Delete, "delete", false, "Delete the stale workspaces instead of only listing them")
+	gcCmd.Flags().BoolVar(&cmd.Force, "force", false,
+		"Delete pinned stale workspaces and workspaces that are not found remotely anymore")
+	gcCmd.Flags().BoolVar(&cmd.CheckGitReachability, "check-git-reachability", false,
+		"If true git workspaces whose repository is reported as not found are considered stale")
+	gcCmd.Flags().BoolVar(&cmd.DeleteMissingRepositories, "delete-missing-repositories", false,
+		"Delete workspaces whose git repository was not found without asking for confirmation")
+	return gcCmd
+}
+
+// Run runs the command logic.
+func (cmd *GCCmd) Run(ctx context.Context) error {
+	if cmd.CheckGitReachability && !command.Exists("git") {
+		return errors.New("--check-git-reachability requires git to be installed")
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := workspace2.ListLocalWorkspaces(devPodConfig.DefaultContext, true, log.Default)
+	if err != nil {
+		return fmt.Errorf("list workspaces: %w", err)
+	}
+
+	stale := []staleWorkspace{}
+	for _, workspace := range workspaces {
+		if entry := cmd.staleWorkspace(workspace); entry != nil {
+			stale = append(stale, *entry)
+		}
+	}
+	if len(stale) == 0 {
+		log.Default.Info("No stale workspaces found")
+		return nil
+	} else if !cmd.Delete {
+		printStaleWorkspaces(stale)
+		return nil
+	}
+
+	failed := 0
+	for _, entry := range stale {
+		shouldDelete, err := cmd.shouldDelete(entry)
+		if err != nil {
+			return err
+		} else if !shouldDelete {
+			continue
+		}
+
+		_, err = workspace2.Delete(ctx, workspace2.DeleteOptions{
+			DevPodConfig: devPodConfig,
+			Args:         []string{entry.workspace.ID},
+			Force:        cmd.Force,
+			Owner:        cmd.Owner,
+			Log:          log.Default,
+		})
+		if err != nil {
+			failed++
+			log.Default.Errorf("Failed to delete workspace %s: %v", entry.workspace.ID, err)
+			continue
+		}
+
+		log.Default.Donef("deleted workspace %s (%s)", entry.workspace.ID, entry.reason)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d stale workspaces", failed, len(stale))
+	}
+
+	return nil
+}
+
+// staleWorkspace returns the workspace with the reason why its source no longer exists, or nil
+// if the workspace is not stale.
+func (cmd *GCCmd) staleWorkspace(workspace *provider.Workspace) *staleWorkspace {
+	source := workspace.Source
+	if source.LocalFolder != "" {
+		_, err := os.Stat(source.LocalFolder)
+		if os.IsNotExist(err) {
+			return &staleWorkspace{
+				workspace: workspace,
+				reason:    fmt.Sprintf("local folder %s does not exist", source.LocalFolder),
+			}
+		}
+	} else if source.GitRepository != "" && cmd.CheckGitReachability {
+		if git.RepositoryNotFound(source.GitRepository, git.GetDefaultExtraEnv(false)) {
+			return &staleWorkspace{
+				workspace: workspace,
+				reason: fmt.Sprintf(
+					"git repository %s was not found or is not accessible with the current "+
+						"credentials",
+					source.GitRepository,
+				),
+				repositoryNotFound: true,
+			}
+		}
+	}
+
+	return nil
+}
+
+// shouldDelete returns if the stale workspace should be deleted. Pinned workspaces require
+// --force and workspaces with a missing repository a confirmation.
+func (cmd *GCCmd) shouldDelete(entry staleWorkspace) (bool, error) {
+	if entry.workspace.Pinned && !cmd.Force {
+		log.Default.Infof(
+			"Skipping pinned workspace %s, use --force to delete it",
+			entry.workspace.ID,
+		)
+		return false, nil
+	} else if !entry.repositoryNotFound || cmd.DeleteMissingRepositories {
+		return true, nil
+	} else if !terminal.IsTerminalIn {
+		log.Default.Infof(
+			"Skipping workspace %s (%s), use --delete-missing-repositories to delete it",
+			entry.workspace.ID,
+			entry.reason,
+		)
+		return false, nil
+	}
+
+	const (
+		yesOption = "Yes"
+		noOption  = "No"
+	)
+	answer, err := log.Default.Question(&survey.QuestionOptions{
+		Question: fmt.Sprintf(
+			"The git repository of workspace %s was not found or is not accessible. "+
+				"Do you want to delete it?",
+			entry.workspace.ID,
+		),
+		DefaultValue: noOption,
+		Options:      []string{yesOption, noOption},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return answer == yesOption, nil
+}
+
+func printStaleWorkspaces(stale []staleWorkspace) {
+	tableEntries := [][]string{}
+	for _, entry := range stale {
+		tableEntries = append(tableEntries, []string{
+			entry.workspace.ID,
+			entry.workspace.Provider.Name,
+			entry.reason,
+		})
+	}
+
+	table.Print([]string{
+		"Workspace",
+		"Provider",
+		"Reason",
+	}, tableEntries)
+}
@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/command"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleWorkspaceLocalFolder(t *testing.T) {
+	cmd := &GCCmd{}
+	existing := t.TempDir()
+	missing := filepath.Join(existing, "missing")
+
+	require.Nil(t, cmd.staleWorkspace(&provider.Workspace{
+		Source: provider.WorkspaceSource{LocalFolder: existing},
+	}))
+	entry := cmd.staleWorkspace(&provider.Workspace{
+		Source: provider.WorkspaceSource{LocalFolder: missing},
+	})
+	require.NotNil(t, entry)
+	require.Equal(t, "local folder "+missing+" does not exist", entry.reason)
+	require.False(t, entry.repositoryNotFound)
+	require.Nil(t, cmd.staleWorkspace(&provider.Workspace{
+		Source: provider.WorkspaceSource{GitRepository: "https://github.com/my-org/missing"},
+	}))
+}
+
+func TestStaleWorkspaceUnreachableRepository(t *testing.T) {
+	if !command.Exists("git") {
+		t.Skip("git is not installed")
+	}
+
+	cmd := &GCCmd{CheckGitReachability: true}
+
+	// connection refused is a transient error and must never mark the workspace stale
+	require.Nil(t, cmd.staleWorkspace(&provider.Workspace{
+		Source: provider.WorkspaceSource{GitRepository: "https://127.0.0.1:1/my-org/repo.git"},
+	}))
+
+	missing := filepath.Join(t.TempDir(), "missing")
+	entry := cmd.staleWorkspace(&provider.Workspace{
+		Source: provider.WorkspaceSource{GitRepository: missing},
+	})
+	require.NotNil(t, entry)
+	require.True(t, entry.repositoryNotFound)
+}
+
+func TestShouldDeleteMissingRepository(t *testing.T) {
+	entry := staleWorkspace{
+		workspace:          &provider.Workspace{ID: "my-workspace"},
+		reason:             "git repository https://github.com/my-org/missing was not found",
+		repositoryNotFound: true,
+	}
+
+	shouldDelete, err := (&GCCmd{}).shouldDelete(entry)
+	require.NoError(t, err)
+	require.False(t, shouldDelete, "requires a confirmation")
+
+	shouldDelete, err = (&GCCmd{DeleteMissingRepositories: true}).shouldDelete(entry)
+	require.NoError(t, err)
+	require.True(t, shouldDelete)
+
+	entry.workspace.Pinned = true
+	shouldDelete, err = (&GCCmd{DeleteMissingRepositories: true}).shouldDelete(entry)
+	require.NoError(t, err)
+	require.False(t, shouldDelete)
+}
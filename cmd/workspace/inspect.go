@@ -0,0 +1,146 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	fieldWorkspace      = "workspace"
+	fieldResult         = "result"
+	fieldMergedConfig   = "merged-config"
+	fieldSeal           = "seal"
+	fieldFeatureOptions = "feature-options"
+)
+
+// InspectCmd holds the configuration.
+type InspectCmd struct {
+	*flags.GlobalFlags
+
+	Field string
+}
+
+// inspectOutput is the combined document printed by workspace inspect.
+type inspectOutput struct {
+	Workspace    *provider.Workspace               `json:"workspace"`
+	Result       *config2.Result                   `json:"result,omitempty"`
+	MergedConfig *config2.MergedDevContainerConfig `json:"mergedConfig,omitempty"`
+	Seal         *provider.WorkspaceSeal           `json:"seal,omitempty"`
+}
+
+// NewInspectCmd creates a new inspect command.
+func NewInspectCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &InspectCmd{
+		GlobalFlags: flags,
+	}
+	inspectCmd := &cobra.Command{
+		Use:   "inspect [flags] [workspace-path|workspace-name]",
+		Short: "Prints the resolved workspace and devcontainer configuration",
+		Long: `Prints the workspace config, the result of the last devpod up and the merged
+devcontainer configuration as a single JSON document. Sealed workspaces also show the seal
+hash and the time they were sealed, feature option overrides are part of the workspace.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	inspectCmd.Flags().StringVar(&cmd.Field, "field", "",
+		"Only print the given field. Can be workspace, result, merged-config, seal or "+
+			"feature-options")
+	return inspectCmd
+}
+
+// Run runs the command logic.
+func (cmd *InspectCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default.ErrorStreamOnly(),
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	result, err := provider.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	}
+
+	seal, err := provider.LoadWorkspaceSeal(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return fmt.Errorf("load workspace seal: %w", err)
+	}
+
+	output := &inspectOutput{
+		Workspace: workspaceConfig,
+		Result:    result,
+		Seal:      seal,
+	}
+	if result != nil {
+		output.MergedConfig = result.MergedConfig
+	}
+
+	var value any
+	switch cmd.Field {
+	case "":
+		value = output
+	case fieldWorkspace:
+		value = output.Workspace
+	case fieldResult:
+		value = output.Result
+	case fieldMergedConfig:
+		value = output.MergedConfig
+	case fieldSeal:
+		value = output.Seal
+	case fieldFeatureOptions:
+		value = output.Workspace.FeatureOptions
+	default:
+		return fmt.Errorf(
+			"unexpected field, choose either %s, %s, %s, %s or %s. Got %s",
+			fieldWorkspace,
+			fieldResult,
+			fieldMergedConfig,
+			fieldSeal,
+			fieldFeatureOptions,
+			cmd.Field,
+		)
+	}
+
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(out))
+	return nil
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// IPCmd holds the configuration.
+type IPCmd struct {
+	*flags.GlobalFlags
+
+	Network string
+}
+
+// NewIPCmd creates a new ip command.
+func NewIPCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &IPCmd{
+		GlobalFlags: flags,
+	}
+	ipCmd := &cobra.Command{
+		Use:   "ip [flags] [workspace-path|workspace-name]",
+		Short: "Prints the ip address of the workspace container",
+		Long: `Prints the ip address of the workspace container, one per line. For docker compose
+workspaces the ip addresses of all containers of the compose project are printed. If the container
+is not connected to the default bridge network, the ip addresses of all its networks are printed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	ipCmd.Flags().StringVar(&cmd.Network, "network", "", "Print the ip address in the given docker network")
+	return ipCmd
+}
+
+// Run runs the command logic.
+func (cmd *IPCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace ip --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if cmd.Network != "" {
+		agentCommand += " --network " + shellescape.Quote(cmd.Network)
+	}
+
+	return RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+}
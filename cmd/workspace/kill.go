@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// KillCmd holds the configuration.
+type KillCmd struct {
+	*flags.GlobalFlags
+
+	Container bool
+}
+
+// NewKillCmd creates a new kill command.
+func NewKillCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &KillCmd{
+		GlobalFlags: flags,
+	}
+	killCmd := &cobra.Command{
+		Use:   "kill [flags] [workspace-path|workspace-name]",
+		Short: "Force-stops a workspace that is stuck in busy state",
+		Long: `Releases the workspace lock left behind by a crashed devpod command, removes the busy
+marker of the agent and, with --container, kills the workspace containers so the workspace
+reports Stopped again. Killing may leave the container in an inconsistent state.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	killCmd.Flags().BoolVar(&cmd.Container, "container", false,
+		"If true, also runs docker kill on the workspace containers")
+	return killCmd
+}
+
+// Run runs the command logic.
+func (cmd *KillCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Default.Warnf(
+		"Killing workspace %s may leave its container in an inconsistent state",
+		baseClient.Workspace(),
+	)
+	held, err := releaseWorkspaceLock(baseClient.Context(), baseClient.Workspace())
+	if err != nil {
+		return err
+	} else if held {
+		log.Default.Infof("Released the lock of workspace %s", baseClient.Workspace())
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		log.Default.Infof("Killing the container is not supported for proxy providers")
+		return nil
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace kill --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if cmd.Container {
+		agentCommand += " --container"
+	}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+	if err != nil {
+		return fmt.Errorf("kill workspace: %w", err)
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Killed workspace %s, status is now %s", client.Workspace(), status)
+	return nil
+}
+
+// releaseWorkspaceLock releases the local file lock of the workspace and returns true if it was
+// held. Locks held by a hanging process can't be released, so the lock file is removed instead
+// and the next devpod command creates a new one.
+func releaseWorkspaceLock(contextName, workspaceID string) (bool, error) {
+	locksDir, err := provider.GetLocksDir(contextName)
+	if err != nil {
+		return false, err
+	}
+
+	lockPath := filepath.Join(locksDir, workspaceID+".workspace.lock")
+	if _, err := os.Stat(lockPath); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	fileLock := flock.New(lockPath)
+	locked, err := fileLock.TryLock()
+	if err != nil {
+		return false, fmt.Errorf("lock workspace: %w", err)
+	} else if locked {
+		return false, fileLock.Unlock()
+	}
+
+	err = os.Remove(lockPath)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("remove workspace lock: %w", err)
+	}
+
+	return true, nil
+}
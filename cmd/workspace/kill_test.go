@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/flock"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseWorkspaceLock(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	held, err := releaseWorkspaceLock("default", "my-workspace")
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	locksDir, err := provider.GetLocksDir("default")
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(locksDir, 0o755))
+	lockPath := filepath.Join(locksDir, "my-workspace.workspace.lock")
+
+	fileLock := flock.New(lockPath)
+	locked, err := fileLock.TryLock()
+	require.NoError(t, err)
+	require.True(t, locked)
+	defer func() { _ = fileLock.Unlock() }()
+
+	held, err = releaseWorkspaceLock("default", "my-workspace")
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.NoFileExists(t, lockPath)
+
+	// a new lock can be acquired right away
+	newLock := flock.New(lockPath)
+	locked, err = newLock.TryLock()
+	require.NoError(t, err)
+	assert.True(t, locked)
+	_ = newLock.Unlock()
+
+	held, err = releaseWorkspaceLock("default", "my-workspace")
+	require.NoError(t, err)
+	assert.False(t, held)
+}
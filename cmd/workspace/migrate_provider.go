@@ -0,0 +1,183 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	"github.com/skevetter/devpod/pkg/types"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// MigrateProviderCmd holds the configuration.
+type MigrateProviderCmd struct {
+	*flags.GlobalFlags
+
+	FromVersion string
+	ToVersion   string
+}
+
+// optionChange is an option of a workspace that does not match the options of the provider.
+type optionChange struct {
+	name   string
+	change string
+}
+
+// NewMigrateProviderCmd creates a new migrate-provider command.
+func NewMigrateProviderCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &MigrateProviderCmd{
+		GlobalFlags: flags,
+	}
+	migrateProviderCmd := &cobra.Command{
+		Use:   "migrate-provider [flags] [workspace-path|workspace-name]",
+		Short: "Migrates the provider options of a workspace to a new provider version",
+		Long: `Applies the migrations of the provider.yaml that lead from --from-version to
+--to-version to the provider options stored with the workspace. If the provider has no
+migrations for the versions, the options that changed are listed to update them manually.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	migrateProviderCmd.Flags().StringVar(&cmd.FromVersion, "from-version", "",
+		"The provider version the workspace options were created with")
+	migrateProviderCmd.Flags().StringVar(&cmd.ToVersion, "to-version", "",
+		"The provider version to migrate the options to. Defaults to the installed provider version")
+	_ = migrateProviderCmd.MarkFlagRequired("from-version")
+	return migrateProviderCmd
+}
+
+// Run runs the command logic.
+func (cmd *MigrateProviderCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	providerWithOptions, err := workspace2.FindProvider(devPodConfig, workspaceConfig.Provider.Name, log.Default)
+	if err != nil {
+		return err
+	}
+	providerConfig := providerWithOptions.Config
+	toVersion := cmd.ToVersion
+	if toVersion == "" {
+		toVersion = providerConfig.Version
+	}
+
+	migrations, err := provider.FindMigrations(providerConfig.Migrations, cmd.FromVersion, toVersion)
+	if errors.Is(err, provider.ErrNoMigrationPath) {
+		changes := optionChanges(workspaceConfig.Provider.Options, providerConfig.Options)
+		if len(changes) == 0 {
+			log.Default.Infof(
+				"Provider %s has no migrations from %s to %s, but the options of workspace %s match",
+				providerConfig.Name,
+				cmd.FromVersion,
+				toVersion,
+				workspaceConfig.ID,
+			)
+			return nil
+		}
+
+		printOptionChanges(changes)
+		return fmt.Errorf(
+			"provider %s has no migrations from %s to %s, update the options above with "+
+				"'devpod up %s --provider-option KEY=VALUE'",
+			providerConfig.Name,
+			cmd.FromVersion,
+			toVersion,
+			workspaceConfig.ID,
+		)
+	} else if err != nil {
+		return err
+	} else if len(migrations) == 0 {
+		log.Default.Infof("Workspace %s is already at provider version %s", workspaceConfig.ID, toVersion)
+		return nil
+	}
+
+	for _, migration := range migrations {
+		log.Default.Debugf("applying provider migration from %s to %s", migration.From, migration.To)
+		workspaceConfig.Provider.Options = migration.Apply(workspaceConfig.Provider.Options)
+	}
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	log.Default.Donef(
+		"Migrated provider options of workspace %s from %s to %s",
+		workspaceConfig.ID,
+		cmd.FromVersion,
+		toVersion,
+	)
+	return nil
+}
+
+// optionChanges returns the stored options the provider does not know anymore and the
+// required provider options that are not stored.
+func optionChanges(
+	stored map[string]config.OptionValue,
+	providerOptions map[string]*types.Option,
+) []optionChange {
+	changes := []optionChange{}
+	for _, name := range slices.Sorted(maps.Keys(stored)) {
+		if _, ok := providerOptions[name]; !ok {
+			changes = append(changes, optionChange{name: name, change: "removed"})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(providerOptions)) {
+		option := providerOptions[name]
+		if _, ok := stored[name]; ok || option == nil || !option.Required || option.Default != "" {
+			continue
+		}
+
+		changes = append(changes, optionChange{name: name, change: "added, required"})
+	}
+
+	return changes
+}
+
+func printOptionChanges(changes []optionChange) {
+	tableEntries := [][]string{}
+	for _, change := range changes {
+		tableEntries = append(tableEntries, []string{change.name, change.change})
+	}
+
+	table.Print([]string{
+		"Option",
+		"Change",
+	}, tableEntries)
+}
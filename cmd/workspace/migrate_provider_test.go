@@ -0,0 +1,27 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionChanges(t *testing.T) {
+	stored := map[string]config.OptionValue{
+		"DISK_SIZE": {Value: "40"},
+		"ZONE":      {Value: "us-east1-b"},
+	}
+	providerOptions := map[string]*types.Option{
+		"ZONE":         {},
+		"DISK_SIZE_GB": {Required: true},
+		"MACHINE_TYPE": {Required: true, Default: "e2-standard-4"},
+		"NETWORK":      {},
+	}
+
+	assert.Equal(t, []optionChange{
+		{name: "DISK_SIZE", change: "removed"},
+		{name: "DISK_SIZE_GB", change: "added, required"},
+	}, optionChanges(stored, providerOptions))
+}
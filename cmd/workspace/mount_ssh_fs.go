@@ -0,0 +1,192 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// macFUSEPath is where macFUSE installs its file system bundle.
+const macFUSEPath = "/Library/Filesystems/macfuse.fs"
+
+// MountSSHFSCmd holds the configuration.
+type MountSSHFSCmd struct {
+	*flags.GlobalFlags
+
+	Options []string
+}
+
+// NewMountSSHFSCmd creates a new mount-ssh-fs command.
+func NewMountSSHFSCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &MountSSHFSCmd{
+		GlobalFlags: flags,
+	}
+	mountCmd := &cobra.Command{
+		Use:   "mount-ssh-fs [flags] [workspace-path|workspace-name] <mountpoint>",
+		Short: "Mounts the workspace folder at a local path with SSHFS",
+		Long: `Mounts the workspace folder of the container at a local path with sshfs through the
+ssh config entry of the workspace. Requires sshfs and on macOS macFUSE. Use devpod workspace
+unmount to remove the mount again.`,
+		Example: `  devpod workspace mount-ssh-fs my-workspace ~/mnt/my-workspace`,
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[:len(args)-1], args[len(args)-1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveFilterDirs
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	mountCmd.Flags().StringArrayVarP(&cmd.Options, "option", "o", []string{},
+		"Additional sshfs options, e.g. -o follow_symlinks")
+	return mountCmd
+}
+
+// Run runs the command logic.
+func (cmd *MountSSHFSCmd) Run(ctx context.Context, args []string, mountpoint string) error {
+	sshfsBinary, err := findSSHFS()
+	if err != nil {
+		return err
+	}
+
+	mountpoint, err = filepath.Abs(mountpoint)
+	if err != nil {
+		return err
+	}
+	// #nosec G301 -- the mountpoint is covered by the mounted file system
+	err = os.MkdirAll(mountpoint, 0o755)
+	if err != nil {
+		return fmt.Errorf("create mountpoint: %w", err)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil {
+		return fmt.Errorf(
+			"workspace %s has no stored result, run devpod up first",
+			client.Workspace(),
+		)
+	}
+	workdir := resultWorkdir(result, client.WorkspaceConfig().Source.GitSubPath)
+	if workdir == "" {
+		return fmt.Errorf("couldn't find the workspace folder of %s", client.Workspace())
+	}
+
+	host := client.Workspace() + config.SSHHostSuffix
+	// #nosec G204 -- arguments are the workspace and the mountpoint given by the user
+	sshfsCmd := exec.CommandContext(
+		ctx,
+		sshfsBinary,
+		sshfsArgs(host, workdir, mountpoint, client.Workspace(), cmd.Options)...,
+	)
+	sshfsCmd.Stdout = os.Stdout
+	sshfsCmd.Stderr = os.Stderr
+	err = sshfsCmd.Run()
+	if err != nil {
+		return fmt.Errorf("sshfs, make sure the ssh config entry %s exists: %w", host, err)
+	}
+
+	err = provider.SaveWorkspaceSSHFSMount(client.Context(), client.Workspace(), mountpoint)
+	if err != nil {
+		return fmt.Errorf("save mountpoint: %w", err)
+	}
+
+	log.Default.Donef("Mounted %s:%s at %s", client.Workspace(), workdir, mountpoint)
+	return nil
+}
+
+// sshfsArgs returns the arguments to mount the workspace folder. The mount reconnects after
+// the connection to the workspace dropped.
+func sshfsArgs(host, workdir, mountpoint, volumeName string, options []string) []string {
+	args := []string{
+		host + ":" + workdir,
+		mountpoint,
+		"-o", "reconnect,ServerAliveInterval=15,ServerAliveCountMax=3",
+	}
+	if runtime.GOOS == "darwin" {
+		args = append(args, "-o", "volname="+volumeName)
+	}
+	for _, option := range options {
+		args = append(args, "-o", option)
+	}
+
+	return args
+}
+
+// findSSHFS returns the path of sshfs or an error with installation instructions for the
+// current platform.
+func findSSHFS() (string, error) {
+	switch runtime.GOOS {
+	case "linux", "darwin":
+	default:
+		return "", fmt.Errorf("mounting workspaces with sshfs is not supported on %s", runtime.GOOS)
+	}
+
+	sshfsBinary, err := exec.LookPath("sshfs")
+	if err != nil {
+		return "", fmt.Errorf("couldn't find sshfs in $PATH. %s", sshfsInstallHint())
+	}
+	if runtime.GOOS == "darwin" {
+		_, err = os.Stat(macFUSEPath)
+		if err != nil {
+			return "", fmt.Errorf(
+				"couldn't find macFUSE at %s. %s",
+				macFUSEPath,
+				sshfsInstallHint(),
+			)
+		}
+	}
+
+	return sshfsBinary, nil
+}
+
+func sshfsInstallHint() string {
+	if runtime.GOOS == "darwin" {
+		return "Install macFUSE and sshfs with 'brew install --cask macfuse' and " +
+			"'brew install gromgit/fuse/sshfs-mac', or from https://macfuse.github.io"
+	}
+
+	return "Install sshfs with your package manager, e.g. 'sudo apt install sshfs' on " +
+		"Debian and Ubuntu or 'sudo dnf install fuse-sshfs' on Fedora"
+}
@@ -0,0 +1,37 @@
+package workspace
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSSHFSArgs(t *testing.T) {
+	args := sshfsArgs(
+		"my-ws.devpod",
+		"/workspaces/my-ws",
+		"/mnt/my-ws",
+		"my-ws",
+		[]string{"follow_symlinks"},
+	)
+
+	assert.Equal(t, []string{"my-ws.devpod:/workspaces/my-ws", "/mnt/my-ws"}, args[:2])
+	assert.Equal(t, []string{"-o", "follow_symlinks"}, args[len(args)-2:])
+	if runtime.GOOS == "darwin" {
+		assert.Contains(t, args, "volname=my-ws")
+	} else {
+		assert.NotContains(t, args, "volname=my-ws")
+	}
+}
+
+func TestUnmountCommand(t *testing.T) {
+	name, args := unmountCommand("/mnt/my-ws")
+	if runtime.GOOS == "darwin" {
+		assert.Equal(t, "umount", name)
+		assert.Equal(t, []string{"/mnt/my-ws"}, args)
+	} else {
+		assert.Equal(t, "fusermount", name)
+		assert.Equal(t, []string{"-u", "/mnt/my-ws"}, args)
+	}
+}
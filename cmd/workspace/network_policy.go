@@ -0,0 +1,199 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// NetworkPolicyCmd holds the configuration.
+type NetworkPolicyCmd struct {
+	*flags.GlobalFlags
+
+	Allow []string
+	Deny  []string
+	List  bool
+	Clear bool
+}
+
+// NewNetworkPolicyCmd creates a new network-policy command.
+func NewNetworkPolicyCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &NetworkPolicyCmd{
+		GlobalFlags: flags,
+	}
+	networkPolicyCmd := &cobra.Command{
+		Use:   "network-policy [flags] [workspace-path|workspace-name]",
+		Short: "Restricts the outgoing traffic of a workspace",
+		Long: `Applies iptables egress rules inside the workspace container. Rules are evaluated in
+the order they were added, --allow rules of a call before its --deny rules, and loopback
+traffic is always allowed. The rules are stored with the workspace and applied again every
+time the workspace starts. The container needs iptables and the NET_ADMIN capability, e.g.
+"capAdd": ["NET_ADMIN"] in devcontainer.json.`,
+		Example: `  devpod workspace network-policy my-workspace --allow 10.0.0.0/8 --deny 0.0.0.0/0
+  devpod workspace network-policy my-workspace --list
+  devpod workspace network-policy my-workspace --clear`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	networkPolicyCmd.Flags().StringArrayVar(&cmd.Allow, "allow", []string{},
+		"Allow outgoing traffic to the given CIDR")
+	networkPolicyCmd.Flags().StringArrayVar(&cmd.Deny, "deny", []string{},
+		"Drop outgoing traffic to the given CIDR")
+	networkPolicyCmd.Flags().BoolVar(&cmd.List, "list", false,
+		"Print the rules currently applied in the container")
+	networkPolicyCmd.Flags().BoolVar(&cmd.Clear, "clear", false,
+		"Remove all rules applied by DevPod")
+	networkPolicyCmd.MarkFlagsMutuallyExclusive("list", "clear", "allow")
+	networkPolicyCmd.MarkFlagsMutuallyExclusive("list", "clear", "deny")
+	networkPolicyCmd.MarkFlagsOneRequired("list", "clear", "allow", "deny")
+	return networkPolicyCmd
+}
+
+// Run runs the command logic.
+func (cmd *NetworkPolicyCmd) Run(ctx context.Context, args []string) error {
+	newPolicies, err := parseNetworkPolicies(cmd.Allow, cmd.Deny)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace network-policy --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if cmd.List {
+		return RunAgentCommand(ctx, devPodConfig, client, agentCommand+" --list",
+			os.Stdout, os.Stderr, log.Default)
+	}
+
+	policies, err := provider.LoadWorkspaceNetworkPolicies(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	}
+	if cmd.Clear {
+		policies = nil
+		agentCommand += " --clear"
+	} else {
+		policies = mergeNetworkPolicies(policies, newPolicies)
+		for _, policy := range policies {
+			agentCommand += fmt.Sprintf(" --rule '%s=%s'", policy.Action, policy.CIDR)
+		}
+	}
+
+	err = provider.SaveWorkspaceNetworkPolicies(client.Context(), client.Workspace(), policies)
+	if err != nil {
+		return fmt.Errorf("save network policies: %w", err)
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status != clientpkg.StatusRunning {
+		log.Default.Donef("Saved network policies, they are applied when workspace %s starts",
+			client.Workspace())
+		return nil
+	}
+
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef(
+		"Applied %d network policies to workspace %s",
+		len(policies),
+		client.Workspace(),
+	)
+	return nil
+}
+
+// parseNetworkPolicies validates the cidrs and returns the allow rules followed by the deny
+// rules.
+func parseNetworkPolicies(allow, deny []string) ([]provider.NetworkPolicy, error) {
+	policies := []provider.NetworkPolicy{}
+	for _, cidr := range allow {
+		policy, err := devcontainer.ParseNetworkPolicy(
+			provider.NetworkPolicyAllow,
+			strings.TrimSpace(cidr),
+		)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	for _, cidr := range deny {
+		policy, err := devcontainer.ParseNetworkPolicy(
+			provider.NetworkPolicyDeny,
+			strings.TrimSpace(cidr),
+		)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// mergeNetworkPolicies appends the new rules to the existing ones, skipping duplicates.
+func mergeNetworkPolicies(
+	policies []provider.NetworkPolicy,
+	newPolicies []provider.NetworkPolicy,
+) []provider.NetworkPolicy {
+	for _, policy := range newPolicies {
+		if !slices.Contains(policies, policy) {
+			policies = append(policies, policy)
+		}
+	}
+
+	return policies
+}
@@ -0,0 +1,36 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetworkPolicies(t *testing.T) {
+	policies, err := parseNetworkPolicies([]string{"10.0.0.0/8"}, []string{"0.0.0.0/0"})
+	require.NoError(t, err)
+	assert.Equal(t, []provider.NetworkPolicy{
+		{Action: provider.NetworkPolicyAllow, CIDR: "10.0.0.0/8"},
+		{Action: provider.NetworkPolicyDeny, CIDR: "0.0.0.0/0"},
+	}, policies)
+
+	_, err = parseNetworkPolicies([]string{"example.com"}, nil)
+	require.Error(t, err)
+}
+
+func TestMergeNetworkPolicies(t *testing.T) {
+	existing := []provider.NetworkPolicy{
+		{Action: provider.NetworkPolicyAllow, CIDR: "10.0.0.0/8"},
+	}
+	merged := mergeNetworkPolicies(existing, []provider.NetworkPolicy{
+		{Action: provider.NetworkPolicyAllow, CIDR: "10.0.0.0/8"},
+		{Action: provider.NetworkPolicyDeny, CIDR: "0.0.0.0/0"},
+	})
+
+	assert.Equal(t, []provider.NetworkPolicy{
+		{Action: provider.NetworkPolicyAllow, CIDR: "10.0.0.0/8"},
+		{Action: provider.NetworkPolicyDeny, CIDR: "0.0.0.0/0"},
+	}, merged)
+}
@@ -0,0 +1,106 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	devpodopen "github.com/skevetter/devpod/pkg/open"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// OpenInBrowserCmd holds the configuration.
+type OpenInBrowserCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewOpenInBrowserCmd creates a new open-in-browser command.
+func NewOpenInBrowserCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &OpenInBrowserCmd{
+		GlobalFlags: flags,
+	}
+	openInBrowserCmd := &cobra.Command{
+		Use:   "open-in-browser [flags] [workspace-path|workspace-name]",
+		Short: "Opens the browser IDE of a workspace",
+		Long: `Opens the url of a browser IDE such as OpenVSCode or Jupyter Notebook in the default
+browser. The browser IDE tunnel started by devpod up needs to be running.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	return openInBrowserCmd
+}
+
+// Run runs the command logic.
+func (cmd *OpenInBrowserCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	ideURL, err := provider.LoadWorkspaceIDEURL(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load ide url: %w", err)
+	}
+	if ideURL == "" || !urlReachable(ideURL) {
+		log.Default.Infof(
+			"The browser IDE of workspace %s is not running, please run the following command first:",
+			client.Workspace(),
+		)
+		log.Default.Infof("devpod up %s", client.Workspace())
+		return fmt.Errorf("browser IDE of workspace %s is not running", client.Workspace())
+	}
+
+	log.Default.Infof("Opening %s", ideURL)
+	return devpodopen.Run(ideURL)
+}
+
+// urlReachable returns true if a connection to the host and port of the url can be established.
+func urlReachable(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || parsedURL.Port() == "" {
+		return false
+	}
+
+	address := net.JoinHostPort(parsedURL.Hostname(), parsedURL.Port())
+	conn, err := net.DialTimeout("tcp", address, time.Second)
+	if err != nil {
+		return false
+	}
+
+	_ = conn.Close()
+	return true
+}
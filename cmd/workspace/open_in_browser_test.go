@@ -0,0 +1,23 @@
+package workspace
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	assert.True(t, urlReachable("http://127.0.0.1:"+strconv.Itoa(port)+"/?folder=/workspaces/app"))
+
+	require.NoError(t, listener.Close())
+	assert.False(t, urlReachable("http://127.0.0.1:"+strconv.Itoa(port)+"/"))
+	assert.False(t, urlReachable("http://localhost/"))
+	assert.False(t, urlReachable("::invalid"))
+}
@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// PinCmd holds the configuration.
+type PinCmd struct {
+	*flags.GlobalFlags
+
+	unpin bool
+}
+
+// NewPinCmd creates a new pin command.
+func NewPinCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PinCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "pin [workspace-path|workspace-name]",
+		Short: "Protects a workspace from accidental deletion",
+		Long: `Pins a workspace. Pinned workspaces can only be deleted with devpod delete --force
+and are skipped by devpod stop --all unless --force is passed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: cmd.validArgs,
+	}
+}
+
+// NewUnpinCmd creates a new unpin command.
+func NewUnpinCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PinCmd{
+		GlobalFlags: flags,
+		unpin:       true,
+	}
+	return &cobra.Command{
+		Use:               "unpin [workspace-path|workspace-name]",
+		Short:             "Removes the deletion protection of a workspace",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cmd.validArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+	}
+}
+
+func (cmd *PinCmd) validArgs(
+	rootCmd *cobra.Command, args []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	return completion.GetWorkspaceSuggestions(
+		rootCmd,
+		cmd.Context,
+		cmd.Provider,
+		args,
+		toComplete,
+		cmd.Owner,
+		log.Default,
+	)
+}
+
+// Run runs the command logic.
+func (cmd *PinCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceConfig.Pinned = !cmd.unpin
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	if cmd.unpin {
+		log.Default.Donef("Unpinned workspace %s", workspaceConfig.ID)
+	} else {
+		log.Default.Donef("Pinned workspace %s", workspaceConfig.ID)
+	}
+	return nil
+}
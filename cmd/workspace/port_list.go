@@ -0,0 +1,282 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	portTypeForwarded = "forwarded"
+	portTypeExposed   = "exposed"
+	portTypeBound     = "bound"
+
+	portStatusAccessible = "accessible"
+	portStatusClosed     = "closed"
+	portStatusUnknown    = "unknown"
+
+	portDialTimeout = 500 * time.Millisecond
+)
+
+// portEntry is a row of the port list.
+type portEntry struct {
+	Port         string `json:"port"`
+	Type         string `json:"type"`
+	LocalAddress string `json:"localAddress,omitempty"`
+	Status       string `json:"status"`
+}
+
+// PortListCmd holds the configuration.
+type PortListCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// NewPortListCmd creates a new port-list command.
+func NewPortListCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &PortListCmd{
+		GlobalFlags: flags,
+	}
+	portListCmd := &cobra.Command{
+		Use:   "port-list [flags] [workspace-path|workspace-name]",
+		Short: "Shows the forwarded and exposed ports of a workspace",
+		Long: `Lists the forwardPorts and appPort of the devcontainer config of the last devpod up
+together with the ports the workspace containers publish on the host. For docker compose
+workspaces the ports of all running services are shown. A port is accessible if its local
+address accepts connections. Ports published by a remote provider are on the remote host and
+their status is unknown.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	portListCmd.Flags().StringVar(&cmd.Output, "output", "plain",
+		"The output format to use. Can be json or plain")
+	return portListCmd
+}
+
+// Run runs the command logic.
+func (cmd *PortListCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf(
+			"unexpected output format, choose either json or plain. Got %s",
+			cmd.Output,
+		)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace port-list --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	stdout := &bytes.Buffer{}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	bindings := []docker.PortBinding{}
+	err = json.Unmarshal(stdout.Bytes(), &bindings)
+	if err != nil {
+		return fmt.Errorf("parse port bindings: %w", err)
+	}
+
+	entries := append(configPorts(result), boundPorts(bindings)...)
+	for i := range entries {
+		entries[i].Status = entryStatus(entries[i], client.AgentLocal())
+	}
+
+	return printPorts(entries, cmd.Output)
+}
+
+// configPorts returns the forwardPorts of the merged config and the appPort of the
+// devcontainer.json of the last up.
+func configPorts(result *devcontainerconfig.Result) []portEntry {
+	entries := []portEntry{}
+	if result == nil {
+		return entries
+	}
+
+	if result.MergedConfig != nil {
+		for _, port := range result.MergedConfig.ForwardPorts {
+			// compose workspaces forward ports of other services as service:port
+			hostPort := port[strings.LastIndex(port, ":")+1:]
+			entries = append(entries, portEntry{
+				Port:         port,
+				Type:         portTypeForwarded,
+				LocalAddress: net.JoinHostPort("localhost", hostPort),
+			})
+		}
+	}
+
+	if result.DevContainerConfigWithPath != nil && result.DevContainerConfigWithPath.Config != nil {
+		for _, appPort := range result.DevContainerConfigWithPath.Config.AppPort {
+			entries = append(entries, appPortEntry(appPort))
+		}
+	}
+
+	return entries
+}
+
+// appPortEntry parses an appPort in the docker format port, hostPort:port or ip:hostPort:port.
+func appPortEntry(appPort string) portEntry {
+	entry := portEntry{Port: appPort, Type: portTypeExposed}
+	parts := strings.Split(appPort, ":")
+	switch len(parts) {
+	case 1:
+		entry.LocalAddress = net.JoinHostPort("localhost", parts[0])
+	case 2:
+		entry.Port = parts[1]
+		entry.LocalAddress = net.JoinHostPort("localhost", parts[0])
+	case 3:
+		entry.Port = parts[2]
+		entry.LocalAddress = net.JoinHostPort(localHost(parts[0]), parts[1])
+	}
+
+	return entry
+}
+
+// boundPorts returns the ports of the workspace containers, ports that are not published on
+// the host are exposed. Bindings of the same port on IPv4 and IPv6 are shown once.
+func boundPorts(bindings []docker.PortBinding) []portEntry {
+	entries := []portEntry{}
+	seen := map[portEntry]bool{}
+	for _, binding := range bindings {
+		entry := portEntry{
+			Port: binding.Container + ":" + binding.ContainerPort,
+			Type: portTypeExposed,
+		}
+		if binding.HostPort != "" {
+			entry.Type = portTypeBound
+			entry.LocalAddress = net.JoinHostPort(localHost(binding.HostIP), binding.HostPort)
+		}
+		if seen[entry] {
+			continue
+		}
+
+		seen[entry] = true
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// localHost returns the host to connect to for a published address, wildcard addresses are
+// reachable on localhost.
+func localHost(hostIP string) string {
+	switch hostIP {
+	case "", "0.0.0.0", "::":
+		return "localhost"
+	}
+
+	return hostIP
+}
+
+// entryStatus returns the status of a port. Bound and exposed ports are published on the docker
+// host of the agent, so they are only probed if the agent runs locally.
+func entryStatus(entry portEntry, agentLocal bool) string {
+	if entry.Type != portTypeForwarded && !agentLocal {
+		return portStatusUnknown
+	}
+
+	return portStatus(entry.LocalAddress)
+}
+
+// portStatus checks whether the local address accepts connections.
+func portStatus(localAddress string) string {
+	if localAddress == "" {
+		return portStatusClosed
+	}
+
+	conn, err := net.DialTimeout("tcp", localAddress, portDialTimeout)
+	if err != nil {
+		return portStatusClosed
+	}
+	_ = conn.Close()
+	return portStatusAccessible
+}
+
+func printPorts(entries []portEntry, output string) error {
+	if output == "json" {
+		out, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	tableEntries := [][]string{}
+	for _, entry := range entries {
+		localAddress := entry.LocalAddress
+		if localAddress == "" {
+			localAddress = "-"
+		}
+		tableEntries = append(tableEntries, []string{
+			entry.Port,
+			entry.Type,
+			localAddress,
+			entry.Status,
+		})
+	}
+	table.Print([]string{"Port", "Type", "Local Address", "Status"}, tableEntries)
+	return nil
+}
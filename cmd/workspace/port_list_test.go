@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"net"
+	"testing"
+
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPorts(t *testing.T) {
+	result := &devcontainerconfig.Result{
+		MergedConfig: &devcontainerconfig.MergedDevContainerConfig{
+			DevContainerConfigBase: devcontainerconfig.DevContainerConfigBase{
+				ForwardPorts: []string{"3000", "db:5432"},
+			},
+		},
+		DevContainerConfigWithPath: &devcontainerconfig.DevContainerConfigWithPath{
+			Config: &devcontainerconfig.DevContainerConfig{
+				NonComposeBase: devcontainerconfig.NonComposeBase{
+					AppPort: []string{"8080", "8000:8010", "127.0.0.1:9000:9010"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []portEntry{
+		{Port: "3000", Type: portTypeForwarded, LocalAddress: "localhost:3000"},
+		{Port: "db:5432", Type: portTypeForwarded, LocalAddress: "localhost:5432"},
+		{Port: "8080", Type: portTypeExposed, LocalAddress: "localhost:8080"},
+		{Port: "8010", Type: portTypeExposed, LocalAddress: "localhost:8000"},
+		{Port: "9010", Type: portTypeExposed, LocalAddress: "127.0.0.1:9000"},
+	}, configPorts(result))
+	assert.Empty(t, configPorts(nil))
+}
+
+func TestBoundPorts(t *testing.T) {
+	assert.Equal(t, []portEntry{
+		{Port: "app:8080/tcp", Type: portTypeBound, LocalAddress: "localhost:8080"},
+		{Port: "app:9000/tcp", Type: portTypeExposed},
+		{Port: "db:5432/tcp", Type: portTypeBound, LocalAddress: "127.0.0.1:15432"},
+	}, boundPorts([]docker.PortBinding{
+		{Container: "app", ContainerPort: "8080/tcp", HostIP: "0.0.0.0", HostPort: "8080"},
+		{Container: "app", ContainerPort: "8080/tcp", HostIP: "::", HostPort: "8080"},
+		{Container: "app", ContainerPort: "9000/tcp"},
+		{Container: "db", ContainerPort: "5432/tcp", HostIP: "127.0.0.1", HostPort: "15432"},
+	}))
+}
+
+func TestPortStatus(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	assert.Equal(t, portStatusAccessible, portStatus(address))
+	require.NoError(t, listener.Close())
+	assert.Equal(t, portStatusClosed, portStatus(address))
+	assert.Equal(t, portStatusClosed, portStatus(""))
+}
+
+func TestEntryStatus(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	address := listener.Addr().String()
+
+	forwarded := portEntry{Type: portTypeForwarded, LocalAddress: address}
+	bound := portEntry{Type: portTypeBound, LocalAddress: address}
+	assert.Equal(t, portStatusAccessible, entryStatus(forwarded, false))
+	assert.Equal(t, portStatusAccessible, entryStatus(bound, true))
+	assert.Equal(t, portStatusUnknown, entryStatus(bound, false))
+}
@@ -0,0 +1,189 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/sirupsen/logrus"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/agent/tunnelserver"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// RefreshCredentialsCmd holds the configuration.
+type RefreshCredentialsCmd struct {
+	*flags.GlobalFlags
+
+	User string
+}
+
+// NewRefreshCredentialsCmd creates a new refresh-credentials command.
+func NewRefreshCredentialsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RefreshCredentialsCmd{
+		GlobalFlags: flags,
+	}
+	refreshCredentialsCmd := &cobra.Command{
+		Use:   "refresh-credentials [flags] [workspace-path|workspace-name]",
+		Short: "Writes the current git and docker credentials into a running workspace",
+		Long: `Fetches the current git and docker credentials of the host once and writes them into
+the running workspace, via docker exec for local docker workspaces and via ssh otherwise. Use this
+after a token on the host was renewed to make it available to processes in the workspace without
+recreating it. Docker and git credentials are handed to the docker and git credential helpers
+configured in the workspace, besides the ones of DevPod. Which credentials are injected follows the
+SSH_INJECT_GIT_CREDENTIALS and SSH_INJECT_DOCKER_CREDENTIALS context options.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	refreshCredentialsCmd.Flags().StringVar(&cmd.User, "user", "",
+		"The user of the workspace to write the credentials for")
+	return refreshCredentialsCmd
+}
+
+// Run runs the command logic.
+func (cmd *RefreshCredentialsCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	configureGitCredentials := devPodConfig.ContextOption(
+		config.ContextOptionSSHInjectGitCredentials,
+	) == config.BoolTrue
+	configureDockerCredentials := devPodConfig.ContextOption(
+		config.ContextOptionSSHInjectDockerCredentials,
+	) == config.BoolTrue
+	if !configureGitCredentials && !configureDockerCredentials {
+		return fmt.Errorf(
+			"git and docker credential injection are disabled for context %s",
+			devPodConfig.DefaultContext,
+		)
+	}
+
+	command := refreshCredentialsCommand(
+		client.WorkspaceConfig().Source.GitRepository,
+		configureGitCredentials,
+		configureDockerCredentials,
+		cmd.Debug,
+	)
+	execCmd := &ExecCmd{GlobalFlags: cmd.GlobalFlags, User: cmd.User}
+	err = runCredentialsTunnel(
+		ctx,
+		client.WorkspaceConfig(),
+		configureGitCredentials,
+		configureDockerCredentials,
+		func(stdin io.Reader, stdout io.Writer) error {
+			writer := log.Default.Writer(logrus.DebugLevel, false)
+			defer func() { _ = writer.Close() }()
+
+			return execCmd.exec(ctx, client, command, stdin, stdout, writer, log.Default)
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Refreshed credentials of workspace %s", client.Workspace())
+	return nil
+}
+
+// refreshCredentialsCommand returns the agent command that writes the credentials into the
+// container.
+func refreshCredentialsCommand(repository string, git, docker, debug bool) string {
+	command := []string{
+		agent.ContainerDevPodHelperLocation,
+		"agent", "container", "refresh-credentials",
+	}
+	if git && repository != "" {
+		command = append(command, "--configure-git-helper", "--repository", repository)
+	}
+	if docker {
+		command = append(command, "--configure-docker-helper")
+	}
+	if debug {
+		command = append(command, "--debug")
+	}
+
+	return shellescape.QuoteCommand(command)
+}
+
+// runCredentialsTunnel serves the credentials of the host to the agent command that run
+// starts with the given stdio until it returns.
+func runCredentialsTunnel(
+	ctx context.Context,
+	workspace *provider.Workspace,
+	git, docker bool,
+	run func(stdin io.Reader, stdout io.Writer) error,
+) error {
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		_ = stdoutReader.Close()
+		_ = stdoutWriter.Close()
+		return err
+	}
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		defer func() { _ = stdoutReader.Close() }()
+		defer func() { _ = stdinWriter.Close() }()
+
+		errChan <- tunnelserver.RunServicesServer(
+			cancelCtx,
+			stdoutReader,
+			stdinWriter,
+			git,
+			docker,
+			nil,
+			workspace,
+			log.Default,
+		)
+	}()
+
+	err = run(stdinReader, stdoutWriter)
+	_ = stdoutWriter.Close()
+	_ = stdinReader.Close()
+	cancel()
+	<-errChan
+	return err
+}
@@ -0,0 +1,21 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshCredentialsCommand(t *testing.T) {
+	assert.Equal(t,
+		"/usr/local/bin/devpod agent container refresh-credentials --configure-git-helper "+
+			"--repository https://github.com/skevetter/devpod.git --configure-docker-helper",
+		refreshCredentialsCommand("https://github.com/skevetter/devpod.git", true, true, false),
+	)
+	assert.Equal(t,
+		"/usr/local/bin/devpod agent container refresh-credentials "+
+			"--configure-docker-helper --debug",
+		refreshCredentialsCommand("", true, true, true),
+		"git credentials need a repository",
+	)
+}
@@ -0,0 +1,180 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/types"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// lifecycleCommands are the lifecycle hooks that can be replayed, in the order they run during up.
+var lifecycleCommands = []string{
+	"onCreateCommand",
+	"updateContentCommand",
+	"postCreateCommand",
+	"postStartCommand",
+	"postAttachCommand",
+}
+
+// ReplayLifecycleCmd holds the configuration.
+type ReplayLifecycleCmd struct {
+	*flags.GlobalFlags
+
+	Command string
+}
+
+// NewReplayLifecycleCmd creates a new replay-lifecycle command.
+func NewReplayLifecycleCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ReplayLifecycleCmd{
+		GlobalFlags: flags,
+	}
+	replayLifecycleCmd := &cobra.Command{
+		Use:   "replay-lifecycle [flags] [workspace-path|workspace-name] --command <hook>",
+		Short: "Re-runs a lifecycle hook in the workspace container",
+		Long: `Re-runs a lifecycle hook in the running workspace container without recreating it.
+The hook is taken from the devcontainer.json the workspace was last started with and runs
+as the remote user in the workspace folder. Run devpod up to pick up changes to
+devcontainer.json first.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	replayLifecycleCmd.Flags().StringVar(&cmd.Command, "command", "",
+		"The lifecycle hook to run, one of "+strings.Join(lifecycleCommands, ", "))
+	_ = replayLifecycleCmd.MarkFlagRequired("command")
+	return replayLifecycleCmd
+}
+
+// Run runs the command logic.
+func (cmd *ReplayLifecycleCmd) Run(ctx context.Context, args []string) error {
+	if !slices.Contains(lifecycleCommands, cmd.Command) {
+		return fmt.Errorf(
+			"unknown lifecycle hook %s, choose one of %s",
+			cmd.Command,
+			strings.Join(lifecycleCommands, ", "),
+		)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.LoadWorkspaceResult(client.Context(), client.Workspace())
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil || result.MergedConfig == nil {
+		return fmt.Errorf(
+			"workspace %s has no stored result, please run devpod up first",
+			client.Workspace(),
+		)
+	}
+
+	commands := lifecycleShellCommands(lifecycleHooks(result.MergedConfig, cmd.Command))
+	if len(commands) == 0 {
+		log.Default.Infof("workspace %s has no %s", client.Workspace(), cmd.Command)
+		return nil
+	}
+
+	remoteUser := devcontainerconfig.GetRemoteUser(result)
+	workspaceFolder := ""
+	if result.SubstitutionContext != nil {
+		workspaceFolder = result.SubstitutionContext.ContainerWorkspaceFolder
+	}
+	for _, command := range commands {
+		log.Default.Infof("running %s: %s", cmd.Command, command)
+		if workspaceFolder != "" {
+			command = fmt.Sprintf("cd %s && %s", shellescape.Quote(workspaceFolder), command)
+		}
+
+		err = runSSHCommand(
+			ctx, cmd.GlobalFlags, client.Workspace(), remoteUser, command, os.Stdin, os.Stdout, os.Stderr,
+		)
+		if err != nil {
+			return fmt.Errorf("run %s: %w", cmd.Command, err)
+		}
+	}
+
+	log.Default.Donef("ran %s in workspace %s", cmd.Command, client.Workspace())
+	return nil
+}
+
+// lifecycleHooks returns the hooks of the merged config for the given lifecycle command.
+func lifecycleHooks(
+	mergedConfig *devcontainerconfig.MergedDevContainerConfig,
+	command string,
+) []types.LifecycleHook {
+	switch command {
+	case "onCreateCommand":
+		return mergedConfig.OnCreateCommands
+	case "updateContentCommand":
+		return mergedConfig.UpdateContentCommands
+	case "postCreateCommand":
+		return mergedConfig.PostCreateCommands
+	case "postStartCommand":
+		return mergedConfig.PostStartCommands
+	case "postAttachCommand":
+		return mergedConfig.PostAttachCommands
+	}
+
+	return nil
+}
+
+// lifecycleShellCommands converts the hooks into shell commands. A single string runs in a
+// shell, an array runs as a single command without shell, the same as during up.
+func lifecycleShellCommands(hooks []types.LifecycleHook) []string {
+	commands := []string{}
+	for _, hook := range hooks {
+		for _, name := range slices.Sorted(maps.Keys(hook)) {
+			command := hook[name]
+			switch len(command) {
+			case 0:
+				continue
+			case 1:
+				commands = append(commands, command[0])
+			default:
+				commands = append(commands, shellescape.QuoteCommand(command))
+			}
+		}
+	}
+
+	return commands
+}
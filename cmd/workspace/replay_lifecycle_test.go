@@ -0,0 +1,34 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	devcontainerconfig "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycleShellCommands(t *testing.T) {
+	mergedConfig := &devcontainerconfig.MergedDevContainerConfig{}
+	mergedConfig.PostCreateCommands = []types.LifecycleHook{
+		{"": {"npm install"}},
+		{"server": {"echo", "hello world"}, "db": {"make db"}, "empty": {}},
+	}
+
+	assert.Equal(t, []string{
+		"npm install",
+		"make db",
+		"echo 'hello world'",
+	}, lifecycleShellCommands(lifecycleHooks(mergedConfig, "postCreateCommand")))
+	assert.Empty(t, lifecycleShellCommands(lifecycleHooks(mergedConfig, "postStartCommand")))
+}
+
+func TestReplayLifecycleRejectsUnknownHook(t *testing.T) {
+	replayLifecycleCmd := NewReplayLifecycleCmd(&flags.GlobalFlags{})
+	replayLifecycleCmd.SetArgs([]string{"my-workspace", "--command", "initializeCommand"})
+	replayLifecycleCmd.SilenceUsage = true
+	replayLifecycleCmd.SilenceErrors = true
+	require.ErrorContains(t, replayLifecycleCmd.Execute(), "unknown lifecycle hook initializeCommand")
+}
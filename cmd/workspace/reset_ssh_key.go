@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ResetSSHKeyCmd holds the configuration.
+type ResetSSHKeyCmd struct {
+	*flags.GlobalFlags
+
+	SSHConfigPath string
+}
+
+// NewResetSSHKeyCmd creates a new reset-ssh-key command.
+func NewResetSSHKeyCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ResetSSHKeyCmd{
+		GlobalFlags: flags,
+	}
+	resetSSHKeyCmd := &cobra.Command{
+		Use:   "reset-ssh-key [flags] [workspace-path|workspace-name]",
+		Short: "Regenerates the SSH key pair of a workspace",
+		Long: `Generates a new Ed25519 key pair for the workspace, points the IdentityFile of the
+workspace ssh config entry to it and removes the old known hosts entry. Connections through
+devpod ssh, which the ssh config entry uses as ProxyCommand, only accept the new key from then
+on and reject a previous one. Other tunnels, such as the one of the workspace daemon, don't
+check the workspace key.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	resetSSHKeyCmd.Flags().StringVar(&cmd.SSHConfigPath, "ssh-config", "", "The path to the ssh config to modify")
+	return resetSSHKeyCmd
+}
+
+// Run runs the command logic.
+func (cmd *ResetSSHKeyCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	result, err := provider2.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return err
+	} else if result == nil {
+		return fmt.Errorf("workspace %s was never started, run devpod up first", client.Workspace())
+	}
+
+	// the ssh server started by devpod ssh trusts the new key via the workspace token
+	identityFile, _, err := devssh.ResetWorkspaceKeyPair(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return err
+	}
+
+	user := config2.GetRemoteUser(result)
+	err = cmd.updateSSHConfig(devPodConfig, client, result, user, identityFile)
+	if err != nil {
+		return err
+	}
+
+	knownHostsFile, err := devssh.ResolveKnownHostsFile(
+		devPodConfig.ContextOption(config.ContextOptionSSHKnownHostsFile),
+	)
+	if err != nil {
+		return err
+	}
+	err = devssh.RemoveKnownHost(knownHostsFile, client.Workspace()+config.SSHHostSuffix)
+	if err != nil {
+		return fmt.Errorf("remove known hosts entry: %w", err)
+	}
+
+	log.Default.Donef("Reset ssh key of workspace %s, new key is %s", client.Workspace(), identityFile)
+	return nil
+}
+
+// updateSSHConfig rewrites the ssh config entry of the workspace so it uses the new identity file.
+func (cmd *ResetSSHKeyCmd) updateSSHConfig(
+	devPodConfig *config.Config,
+	client clientpkg.WorkspaceClient,
+	result *config2.Result,
+	user string,
+	identityFile string,
+) error {
+	sshConfigPath, sshConfigIncludePath, err := devssh.ResolveSSHConfigPaths(
+		devPodConfig,
+		cmp.Or(cmd.SSHConfigPath, client.WorkspaceConfig().SSHConfigPath),
+		client.WorkspaceConfig().SSHConfigIncludePath,
+	)
+	if err != nil {
+		return err
+	}
+
+	workdir := ""
+	if result.MergedConfig != nil {
+		workdir = result.MergedConfig.WorkspaceFolder
+	}
+	if gitSubPath := client.WorkspaceConfig().Source.GitSubPath; gitSubPath != "" && result.SubstitutionContext != nil {
+		workdir = filepath.Join(result.SubstitutionContext.ContainerWorkspaceFolder, gitSubPath)
+	}
+
+	return devssh.UpdateSSHConfig(devssh.SSHConfigParams{
+		SSHConfigPath:        sshConfigPath,
+		SSHConfigIncludePath: sshConfigIncludePath,
+		Context:              client.Context(),
+		Workspace:            client.Workspace(),
+		User:                 user,
+		Workdir:              workdir,
+		GPGAgent:             devPodConfig.ContextOption(config.ContextOptionGPGAgentForwarding) == config.BoolTrue,
+		DevPodHome:           os.Getenv(config.EnvHome),
+		Provider:             client.Provider(),
+		Log:                  log.Default,
+	}, func(params *devssh.SSHConfigParams) {
+		params.IdentityFile = identityFile
+	})
+}
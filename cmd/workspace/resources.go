@@ -0,0 +1,170 @@
+package workspace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor to the top left and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// ResourcesCmd holds the configuration.
+type ResourcesCmd struct {
+	*flags.GlobalFlags
+
+	Watch  bool
+	Output string
+}
+
+// NewResourcesCmd creates a new resources command.
+func NewResourcesCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ResourcesCmd{
+		GlobalFlags: flags,
+	}
+	resourcesCmd := &cobra.Command{
+		Use:   "resources [flags] [workspace-path|workspace-name]",
+		Short: "Shows the CPU and memory usage of the workspace containers",
+		Long: `Shows the CPU and memory usage of the workspace container. For docker compose
+workspaces all containers of the compose project are shown.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	resourcesCmd.Flags().BoolVarP(&cmd.Watch, "watch", "w", false, "Refresh the usage every second until interrupted")
+	resourcesCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return resourcesCmd
+}
+
+// Run runs the command logic.
+func (cmd *ResourcesCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace resources --context '%s' --id '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+	)
+	if cmd.Watch {
+		agentCommand += " --watch"
+	}
+
+	reader, writer := io.Pipe()
+	printErr := make(chan error, 1)
+	go func() {
+		printErr <- cmd.printStats(reader, os.Stdout)
+		_ = reader.Close()
+	}()
+
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, writer, os.Stderr, log.Default)
+	_ = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return <-printErr
+}
+
+// printStats renders every json line the agent prints.
+func (cmd *ResourcesCmd) printStats(reader io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if cmd.Output == "json" {
+			_, _ = fmt.Fprintln(w, line)
+			continue
+		}
+
+		stats := []docker.ContainerStats{}
+		err := json.Unmarshal([]byte(line), &stats)
+		if err != nil {
+			return fmt.Errorf("parse container stats: %w", err)
+		}
+		if cmd.Watch {
+			_, _ = fmt.Fprint(w, clearScreen)
+		}
+		printStatsTable(stats)
+	}
+
+	return scanner.Err()
+}
+
+func printStatsTable(stats []docker.ContainerStats) {
+	tableEntries := [][]string{}
+	for _, sample := range stats {
+		tableEntries = append(tableEntries, []string{
+			strings.TrimPrefix(sample.Name, "/"),
+			sample.CPUPerc,
+			sample.MemUsage,
+			sample.MemPerc,
+			sample.NetIO,
+			sample.BlockIO,
+			sample.PIDs,
+		})
+	}
+
+	table.Print([]string{
+		"Container",
+		"CPU %",
+		"Mem Usage / Limit",
+		"Mem %",
+		"Net I/O",
+		"Block I/O",
+		"PIDs",
+	}, tableEntries)
+}
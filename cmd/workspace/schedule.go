@@ -0,0 +1,244 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/daemon/host"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// ScheduleStopCmd holds the configuration.
+type ScheduleStopCmd struct {
+	*flags.GlobalFlags
+
+	At string
+}
+
+// NewScheduleStopCmd creates a new schedule-stop command.
+func NewScheduleStopCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ScheduleStopCmd{
+		GlobalFlags: flags,
+	}
+	scheduleStopCmd := &cobra.Command{
+		Use:   "schedule-stop [flags] [workspace-path|workspace-name] --at <time>",
+		Short: "Stops a workspace at the given time",
+		Long: `Registers a one-shot stop of the workspace. A background process runs the stop once
+the time is reached and exits when no schedules are pending. After a reboot the next devpod
+command starts it again. A new schedule replaces the pending stop of the workspace.`,
+		Example: `  devpod workspace schedule-stop my-workspace --at "18:00 UTC"
+  devpod workspace schedule-stop my-workspace --at 2025-01-20T18:00:00Z
+  devpod workspace schedule-stop my-workspace --at "in 2h"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	scheduleStopCmd.Flags().StringVar(&cmd.At, "at", "",
+		"When to stop the workspace, e.g. \"18:00 UTC\", \"2025-01-20T18:00:00Z\" or \"in 2h\"")
+	_ = scheduleStopCmd.MarkFlagRequired("at")
+	return scheduleStopCmd
+}
+
+// Run runs the command logic.
+func (cmd *ScheduleStopCmd) Run(ctx context.Context, args []string) error {
+	now := time.Now()
+	at, err := host.ParseScheduleTime(cmd.At, now)
+	if err != nil {
+		return err
+	} else if !at.After(now) {
+		return fmt.Errorf("%s is in the past", at.Format(time.RFC3339))
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	schedule := host.Schedule{
+		Workspace: client.Workspace(),
+		Context:   client.Context(),
+		Action:    host.ActionStop,
+		At:        at,
+	}
+	err = host.UpdateSchedules(func(schedules []host.Schedule) ([]host.Schedule, error) {
+		remaining := slices.DeleteFunc(
+			schedules,
+			isWorkspaceSchedule(schedule.Context, schedule.Workspace),
+		)
+		return append(remaining, schedule), nil
+	})
+	if err != nil {
+		return fmt.Errorf("save schedule: %w", err)
+	}
+
+	err = host.StartDaemon()
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef(
+		"Scheduled stop of workspace %s at %s",
+		client.Workspace(),
+		at.Local().Format(time.RFC3339),
+	)
+	return nil
+}
+
+// ListSchedulesCmd holds the configuration.
+type ListSchedulesCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewListSchedulesCmd creates a new list-schedules command.
+func NewListSchedulesCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ListSchedulesCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "list-schedules [workspace-name]",
+		Short: "Lists the pending scheduled actions",
+		Long: "Lists the pending scheduled actions of the workspace or of all workspaces " +
+			"in the selected context.",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return cmd.Run(args)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *ListSchedulesCmd) Run(args []string) error {
+	contextName, err := selectedContext(cmd.GlobalFlags)
+	if err != nil {
+		return err
+	}
+
+	schedules, err := host.LoadSchedules()
+	if err != nil {
+		return err
+	}
+
+	tableEntries := [][]string{}
+	for _, schedule := range schedules {
+		if schedule.Context != contextName {
+			continue
+		} else if len(args) > 0 && schedule.Workspace != args[0] {
+			continue
+		}
+
+		tableEntries = append(tableEntries, []string{
+			schedule.Workspace,
+			schedule.Context,
+			schedule.Action,
+			schedule.At.Local().Format(time.RFC3339),
+		})
+	}
+	if len(tableEntries) == 0 {
+		log.Default.Info("No pending schedules found")
+		return nil
+	}
+
+	table.Print([]string{
+		"Workspace",
+		"Context",
+		"Action",
+		"At",
+	}, tableEntries)
+	return nil
+}
+
+// CancelScheduleCmd holds the configuration.
+type CancelScheduleCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewCancelScheduleCmd creates a new cancel-schedule command.
+func NewCancelScheduleCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &CancelScheduleCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "cancel-schedule <workspace-name>",
+		Short: "Cancels the pending scheduled stop of a workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return cmd.Run(args[0])
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *CancelScheduleCmd) Run(workspaceID string) error {
+	contextName, err := selectedContext(cmd.GlobalFlags)
+	if err != nil {
+		return err
+	}
+
+	canceled := false
+	err = host.UpdateSchedules(func(schedules []host.Schedule) ([]host.Schedule, error) {
+		remaining := slices.DeleteFunc(schedules, isWorkspaceSchedule(contextName, workspaceID))
+		canceled = len(remaining) < len(schedules)
+		return remaining, nil
+	})
+	if err != nil {
+		return err
+	} else if !canceled {
+		return fmt.Errorf("workspace %s has no pending schedule", workspaceID)
+	}
+
+	log.Default.Donef("Canceled the scheduled stop of workspace %s", workspaceID)
+	return nil
+}
+
+// selectedContext returns the context selected with --context, or the default context.
+func selectedContext(globalFlags *flags.GlobalFlags) (string, error) {
+	if globalFlags.Context != "" {
+		return globalFlags.Context, nil
+	}
+
+	devPodConfig, err := config.LoadConfig("", globalFlags.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	return devPodConfig.DefaultContext, nil
+}
+
+func isWorkspaceSchedule(contextName, workspaceID string) func(host.Schedule) bool {
+	return func(schedule host.Schedule) bool {
+		return schedule.Context == contextName && schedule.Workspace == workspaceID
+	}
+}
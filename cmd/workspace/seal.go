@@ -0,0 +1,176 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/types"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SealCmd holds the configuration.
+type SealCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewSealCmd creates a new seal command.
+func NewSealCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SealCmd{
+		GlobalFlags: flags,
+	}
+	sealCmd := &cobra.Command{
+		Use:   "seal [flags] [workspace-path|workspace-name]",
+		Short: "Seals a workspace with a tamper-evident hash",
+		Long: `Verifies that the workspace container has no changes outside of its workspace folder
+and records a SHA-256 of the workspace config and the container image digest in seal.json in
+the local workspace folder. Sealed workspaces can't be recreated with devpod up --recreate or
+--reset, devpod workspace inspect shows the hash and the time the workspace was sealed.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	return sealCmd
+}
+
+// Run runs the command logic.
+func (cmd *SealCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	if workspaceConfig.Seal != nil {
+		return fmt.Errorf("workspace %s is already sealed with hash %s",
+			client.Workspace(), workspaceConfig.Seal.Hash)
+	}
+
+	workspaceFolder, err := containerWorkspaceFolder(workspaceConfig)
+	if err != nil {
+		return err
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace seal-info --context '%s' --id '%s' --workspace-folder %s",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+		shellescape.Quote(workspaceFolder),
+	)
+	stdout := &bytes.Buffer{}
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	sealInfo := &provider.SealInfo{}
+	err = json.Unmarshal(stdout.Bytes(), sealInfo)
+	if err != nil {
+		return fmt.Errorf("parse seal info: %w", err)
+	} else if len(sealInfo.Changes) > 0 {
+		return fmt.Errorf(
+			"workspace container has changes outside of %s:\n%s",
+			workspaceFolder,
+			strings.Join(sealInfo.Changes, "\n"),
+		)
+	}
+
+	seal, err := sealWorkspace(workspaceConfig, sealInfo.ImageDigest)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef(
+		"Sealed workspace %s at %s with hash %s",
+		client.Workspace(),
+		seal.Timestamp.Format(time.RFC3339),
+		seal.Hash,
+	)
+	return nil
+}
+
+// containerWorkspaceFolder returns the workspace folder in the container of the last up, it
+// defaults to /workspaces.
+func containerWorkspaceFolder(workspaceConfig *provider.Workspace) (string, error) {
+	result, err := provider.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return "", fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil || result.SubstitutionContext == nil ||
+		result.SubstitutionContext.ContainerWorkspaceFolder == "" {
+		return "/workspaces", nil
+	}
+
+	return result.SubstitutionContext.ContainerWorkspaceFolder, nil
+}
+
+// sealWorkspace writes the seal.json and marks the workspace as sealed.
+func sealWorkspace(
+	workspaceConfig *provider.Workspace,
+	imageDigest string,
+) (*provider.WorkspaceSeal, error) {
+	hash, err := provider.SealHash(workspaceConfig, imageDigest)
+	if err != nil {
+		return nil, err
+	}
+
+	seal := &provider.WorkspaceSeal{
+		Hash:        hash,
+		ImageDigest: imageDigest,
+		Timestamp:   types.NewTime(time.Now()),
+	}
+	err = provider.SaveWorkspaceSeal(workspaceConfig.Context, workspaceConfig.ID, seal)
+	if err != nil {
+		return nil, fmt.Errorf("save seal: %w", err)
+	}
+
+	workspaceConfig.Seal = seal
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("save workspace: %w", err)
+	}
+
+	return seal, nil
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/ide/ideparse"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetDefaultIDECmd holds the configuration.
+type SetDefaultIDECmd struct {
+	*flags.GlobalFlags
+
+	IDEOptions []string
+}
+
+// NewSetDefaultIDECmd creates a new set-default-ide command.
+func NewSetDefaultIDECmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetDefaultIDECmd{
+		GlobalFlags: flags,
+	}
+	setDefaultIDECmd := &cobra.Command{
+		Use:   "set-default-ide [workspace-path|workspace-name] [ide]",
+		Short: "Changes the IDE of a workspace without provisioning it again",
+		Long: `Changes the IDE of a workspace. The next devpod up or devpod workspace attach opens
+the new IDE without recreating the workspace container. Some IDEs, e.g. the JetBrains IDEs,
+install their backend into the workspace on the next start, which can take a while.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				ides := []string{}
+				for _, allowedIDE := range ideparse.AllowedIDEs {
+					ides = append(ides, string(allowedIDE.Name))
+				}
+				return ides, cobra.ShellCompDirectiveNoFileComp
+			} else if len(args) > 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	setDefaultIDECmd.Flags().
+		StringArrayVar(&cmd.IDEOptions, "ide-option", []string{}, "IDE option in the form KEY=VALUE")
+	return setDefaultIDECmd
+}
+
+// Run runs the command logic.
+func (cmd *SetDefaultIDECmd) Run(ctx context.Context, workspaceName, ide string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig, err := ideparse.RefreshIDEOptions(devPodConfig, client.WorkspaceConfig(), ide, cmd.IDEOptions)
+	if err != nil {
+		return fmt.Errorf("set ide: %w", err)
+	}
+
+	log.Default.Donef("Set IDE of workspace %s to %s", workspaceConfig.ID, workspaceConfig.IDE.Name)
+	return nil
+}
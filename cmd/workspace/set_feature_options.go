@@ -0,0 +1,149 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetFeatureOptionsCmd holds the configuration.
+type SetFeatureOptionsCmd struct {
+	*flags.GlobalFlags
+
+	Feature string
+	Options []string
+	Reset   bool
+}
+
+// NewSetFeatureOptionsCmd creates a new set-feature-options command.
+func NewSetFeatureOptionsCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetFeatureOptionsCmd{
+		GlobalFlags: flags,
+	}
+	setFeatureOptionsCmd := &cobra.Command{
+		Use:   "set-feature-options [flags] [workspace-path|workspace-name]",
+		Short: "Overrides the options of a devcontainer feature for a workspace",
+		Long: `Overrides the options of a feature of the devcontainer.json for this workspace only.
+The options are stored with the workspace and merged into the feature options when the features
+are resolved, run devpod up --recreate to reinstall the feature with the new options.`,
+		Example: `  devpod workspace set-feature-options my-workspace \
+    --feature ghcr.io/devcontainers/features/node:1 --option version=22`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	setFeatureOptionsCmd.Flags().StringVar(&cmd.Feature, "feature", "",
+		"The feature id as used in the devcontainer.json")
+	setFeatureOptionsCmd.Flags().StringArrayVar(&cmd.Options, "option", []string{},
+		"Feature option in the form KEY=VALUE")
+	setFeatureOptionsCmd.Flags().BoolVar(&cmd.Reset, "reset", false,
+		"Remove all option overrides of the feature")
+	_ = setFeatureOptionsCmd.MarkFlagRequired("feature")
+	setFeatureOptionsCmd.MarkFlagsOneRequired("option", "reset")
+	setFeatureOptionsCmd.MarkFlagsMutuallyExclusive("option", "reset")
+	return setFeatureOptionsCmd
+}
+
+// Run runs the command logic.
+func (cmd *SetFeatureOptionsCmd) Run(ctx context.Context, args []string) error {
+	options, err := parseFeatureOptions(cmd.Options)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceConfig.FeatureOptions = mergeFeatureOptions(
+		workspaceConfig.FeatureOptions,
+		cmd.Feature,
+		options,
+	)
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	log.Default.Donef(
+		"Saved options of feature %s, run 'devpod up %s --recreate' to apply them",
+		cmd.Feature,
+		client.Workspace(),
+	)
+	return nil
+}
+
+// parseFeatureOptions parses the KEY=VALUE options.
+func parseFeatureOptions(rawOptions []string) (map[string]string, error) {
+	options := map[string]string{}
+	for _, rawOption := range rawOptions {
+		key, value, found := strings.Cut(rawOption, "=")
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("invalid --option %q, expected KEY=VALUE", rawOption)
+		}
+		options[strings.TrimSpace(key)] = value
+	}
+
+	return options, nil
+}
+
+// mergeFeatureOptions adds the options to the overrides of the feature. The overrides of the
+// feature are removed if options is empty.
+func mergeFeatureOptions(
+	featureOptions map[string]map[string]string,
+	featureID string,
+	options map[string]string,
+) map[string]map[string]string {
+	merged := maps.Clone(featureOptions)
+	if merged == nil {
+		merged = map[string]map[string]string{}
+	}
+	if len(options) == 0 {
+		delete(merged, featureID)
+		return merged
+	}
+
+	featureOverrides := maps.Clone(merged[featureID])
+	if featureOverrides == nil {
+		featureOverrides = map[string]string{}
+	}
+	maps.Copy(featureOverrides, options)
+	merged[featureID] = featureOverrides
+	return merged
+}
@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureOptions(t *testing.T) {
+	options, err := parseFeatureOptions(
+		[]string{"version=22", "nodeGypDependencies=false", "a=b=c"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"version":             "22",
+		"nodeGypDependencies": "false",
+		"a":                   "b=c",
+	}, options)
+
+	_, err = parseFeatureOptions([]string{"version"})
+	assert.Error(t, err)
+	_, err = parseFeatureOptions([]string{"=22"})
+	assert.Error(t, err)
+}
+
+func TestMergeFeatureOptions(t *testing.T) {
+	node := "ghcr.io/devcontainers/features/node:1"
+	git := "ghcr.io/devcontainers/features/git:1"
+	existing := map[string]map[string]string{
+		node: {"version": "18", "nvmVersion": "0.39"},
+		git:  {"ppa": "false"},
+	}
+
+	merged := mergeFeatureOptions(existing, node, map[string]string{"version": "22"})
+	assert.Equal(t, map[string]map[string]string{
+		node: {"version": "22", "nvmVersion": "0.39"},
+		git:  {"ppa": "false"},
+	}, merged)
+	assert.Equal(t, "18", existing[node]["version"])
+
+	assert.Equal(t, map[string]map[string]string{
+		node: {"version": "18", "nvmVersion": "0.39"},
+	}, mergeFeatureOptions(existing, git, nil))
+	assert.Equal(t, map[string]map[string]string{
+		git: {"ppa": "true"},
+	}, mergeFeatureOptions(nil, git, map[string]string{"ppa": "true"}))
+}
@@ -0,0 +1,141 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/git"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetGitConfigCmd holds the configuration.
+type SetGitConfigCmd struct {
+	*flags.GlobalFlags
+
+	Name  string
+	Email string
+}
+
+// NewSetGitConfigCmd creates a new set-git-config command.
+func NewSetGitConfigCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetGitConfigCmd{
+		GlobalFlags: flags,
+	}
+	setGitConfigCmd := &cobra.Command{
+		Use:   "set-git-config [flags] [workspace-path|workspace-name]",
+		Short: "Sets the git identity of a workspace",
+		Long: `Sets the global git user.name and user.email of the remote user in the workspace
+container. The identity is stored with the workspace and configured again on every devpod up.`,
+		Example: `  devpod workspace set-git-config my-workspace --name "Jane Doe" --email jane@example.com`,
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	setGitConfigCmd.Flags().StringVar(&cmd.Name, "name", "", "The git user.name")
+	setGitConfigCmd.Flags().StringVar(&cmd.Email, "email", "", "The git user.email")
+	setGitConfigCmd.MarkFlagsOneRequired("name", "email")
+	return setGitConfigCmd
+}
+
+// Run runs the command logic.
+func (cmd *SetGitConfigCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Email != "" {
+		if _, err := mail.ParseAddress(cmd.Email); err != nil {
+			return fmt.Errorf("invalid --email %q: %w", cmd.Email, err)
+		}
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceConfig.GitConfig = mergeGitConfig(workspaceConfig.GitConfig, cmd.Name, cmd.Email)
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status != clientpkg.StatusRunning {
+		log.Default.Donef(
+			"Saved git config, it is applied when workspace %s starts",
+			client.Workspace(),
+		)
+		return nil
+	}
+
+	command := git.IdentityCommand(cmd.Name, cmd.Email)
+	err = runSSHCommand(
+		ctx,
+		cmd.GlobalFlags,
+		client.Workspace(),
+		"",
+		command,
+		nil,
+		os.Stdout,
+		os.Stderr,
+	)
+	if err != nil {
+		return fmt.Errorf("set git config: %w", err)
+	}
+
+	log.Default.Donef("Set git config of workspace %s", client.Workspace())
+	return nil
+}
+
+// mergeGitConfig overrides the existing identity with the non-empty values.
+func mergeGitConfig(
+	gitConfig *provider.WorkspaceGitConfig,
+	name, email string,
+) *provider.WorkspaceGitConfig {
+	merged := &provider.WorkspaceGitConfig{}
+	if gitConfig != nil {
+		*merged = *gitConfig
+	}
+	if name != "" {
+		merged.Name = name
+	}
+	if email != "" {
+		merged.Email = email
+	}
+
+	return merged
+}
@@ -0,0 +1,25 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeGitConfig(t *testing.T) {
+	assert.Equal(
+		t,
+		&provider.WorkspaceGitConfig{Name: "Jane Doe", Email: "jane@work.example.com"},
+		mergeGitConfig(
+			&provider.WorkspaceGitConfig{Name: "Jane Doe", Email: "jane@example.com"},
+			"",
+			"jane@work.example.com",
+		),
+	)
+	assert.Equal(
+		t,
+		&provider.WorkspaceGitConfig{Name: "Jane Doe"},
+		mergeGitConfig(nil, "Jane Doe", ""),
+	)
+}
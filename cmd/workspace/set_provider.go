@@ -0,0 +1,157 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetProviderCmd holds the configuration.
+type SetProviderCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewSetProviderCmd creates a new set-provider command.
+func NewSetProviderCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetProviderCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "set-provider [workspace-path|workspace-name] [provider]",
+		Short: "Changes the provider of a workspace without recreating it",
+		Long: `Changes the provider of a workspace. The next devpod up creates the workspace with the
+new provider, provider options set for the old provider are dropped. The workspace and machine
+on the old provider are not deleted. Machine providers are not supported, as they need a new
+machine for the workspace; delete the workspace and create it again with the provider instead.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return completion.GetProviderSuggestions(
+					rootCmd,
+					cmd.Context,
+					cmd.Provider,
+					args,
+					toComplete,
+					cmd.Owner,
+					log.Default,
+				)
+			} else if len(args) > 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *SetProviderCmd) Run(ctx context.Context, workspaceName, providerName string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	if workspaceConfig.Provider.Name == providerName {
+		log.Default.Infof("Workspace %s already uses provider %s", workspaceConfig.ID, providerName)
+		return nil
+	}
+
+	newProvider, err := workspace2.FindProvider(devPodConfig, providerName, log.Default)
+	if err != nil {
+		return err
+	} else if newProvider.State == nil || !newProvider.State.Initialized {
+		return fmt.Errorf(
+			"provider '%s' is not initialized, please make sure to run 'devpod provider use %s' "+
+				"at least once before using this provider",
+			providerName,
+			providerName,
+		)
+	}
+	err = validateProviderSource(workspaceConfig, newProvider.Config)
+	if err != nil {
+		return err
+	}
+
+	if workspaceConfig.Machine.ID != "" {
+		log.Default.Warnf(
+			"Machine %s of provider %s is not deleted automatically, run 'devpod machine delete %s' to remove it",
+			workspaceConfig.Machine.ID,
+			workspaceConfig.Provider.Name,
+			workspaceConfig.Machine.ID,
+		)
+	}
+
+	oldProvider := workspaceConfig.Provider.Name
+	workspaceConfig.Provider = provider.WorkspaceProviderConfig{Name: providerName}
+	workspaceConfig.Machine = provider.WorkspaceMachineConfig{}
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	log.Default.Donef(
+		"Changed provider of workspace %s from %s to %s, run devpod up to create it",
+		workspaceConfig.ID,
+		oldProvider,
+		providerName,
+	)
+	return nil
+}
+
+// validateProviderSource returns an error if the source of the workspace cannot be used with
+// the provider.
+func validateProviderSource(workspace *provider.Workspace, providerConfig *provider.ProviderConfig) error {
+	switch {
+	case workspace.Pro != nil:
+		return fmt.Errorf("workspace %s is a platform workspace, its provider cannot be changed", workspace.ID)
+	case workspace.Source.Container != "":
+		return fmt.Errorf(
+			"workspace %s uses the existing container %s of its provider, its provider cannot be changed",
+			workspace.ID,
+			workspace.Source.Container,
+		)
+	case providerConfig.IsMachineProvider():
+		return fmt.Errorf(
+			"provider %s creates machines, delete workspace %s and run devpod up --provider %s instead",
+			providerConfig.Name,
+			workspace.ID,
+			providerConfig.Name,
+		)
+	case workspace.Source.LocalFolder != "" &&
+		(providerConfig.IsProxyProvider() || providerConfig.IsDaemonProvider()):
+		return fmt.Errorf("provider %s does not support local folder workspaces", providerConfig.Name)
+	}
+
+	return nil
+}
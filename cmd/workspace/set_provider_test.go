@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProviderSource(t *testing.T) {
+	dockerProvider := &provider.ProviderConfig{Name: "docker"}
+	proxyProvider := &provider.ProviderConfig{
+		Name: "pro",
+		Exec: provider.ProviderCommands{Proxy: &provider.ProxyCommands{}},
+	}
+	localFolder := &provider.Workspace{
+		ID:     "my-workspace",
+		Source: provider.WorkspaceSource{LocalFolder: "/path/to/folder"},
+	}
+
+	require.NoError(t, validateProviderSource(localFolder, dockerProvider))
+	require.EqualError(
+		t,
+		validateProviderSource(localFolder, proxyProvider),
+		"provider pro does not support local folder workspaces",
+	)
+	require.EqualError(
+		t,
+		validateProviderSource(&provider.Workspace{
+			ID:     "my-workspace",
+			Source: provider.WorkspaceSource{Container: "abc"},
+		}, dockerProvider),
+		"workspace my-workspace uses the existing container abc of its provider, its provider cannot be changed",
+	)
+	require.EqualError(
+		t,
+		validateProviderSource(localFolder, &provider.ProviderConfig{
+			Name: "aws",
+			Exec: provider.ProviderCommands{Create: []string{"create"}},
+		}),
+		"provider aws creates machines, delete workspace my-workspace and run devpod up "+
+			"--provider aws instead",
+	)
+}
@@ -0,0 +1,177 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	resourceLimitCPUs   = "cpus"
+	resourceLimitMemory = "memory"
+)
+
+// SetResourceLimitCmd holds the configuration.
+type SetResourceLimitCmd struct {
+	*flags.GlobalFlags
+
+	resource string
+}
+
+// NewSetCPULimitCmd creates a new set-cpu-limit command.
+func NewSetCPULimitCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetResourceLimitCmd{
+		GlobalFlags: flags,
+		resource:    resourceLimitCPUs,
+	}
+	return cmd.newCommand(
+		"set-cpu-limit [workspace-path|workspace-name] <cpus>",
+		"Changes the cpu limit of a workspace without recreating it",
+		"  devpod workspace set-cpu-limit my-workspace 1.5",
+	)
+}
+
+// NewSetMemoryLimitCmd creates a new set-memory-limit command.
+func NewSetMemoryLimitCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetResourceLimitCmd{
+		GlobalFlags: flags,
+		resource:    resourceLimitMemory,
+	}
+	return cmd.newCommand(
+		"set-memory-limit [workspace-path|workspace-name] <memory>",
+		"Changes the memory limit of a workspace without recreating it",
+		"  devpod workspace set-memory-limit my-workspace 4g",
+	)
+}
+
+func (cmd *SetResourceLimitCmd) newCommand(use, short, example string) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Long: short + `. The limit is applied to the running container with docker update
+and stored with the workspace, so it is applied again on every devpod up. For docker compose
+workspaces it is written to the deploy.resources section of the devcontainer service.`,
+		Example: example,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *SetResourceLimitCmd) Run(ctx context.Context, workspaceName, limit string) error {
+	limits, err := cmd.parseLimit(limit)
+	if err != nil {
+		return err
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	workspaceConfig.ResourceLimits = mergeResourceLimits(workspaceConfig.ResourceLimits, limits)
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	status, err := client.Status(ctx, clientpkg.StatusOptions{})
+	if err != nil {
+		return err
+	} else if status != clientpkg.StatusRunning {
+		log.Default.Donef("Saved %s limit %s, it is applied when workspace %s starts",
+			cmd.resource, limit, client.Workspace())
+		return nil
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace set-resource-limits --context '%s' --id '%s' --%s '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+		cmd.resource,
+		limit,
+	)
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, os.Stdout, os.Stderr, log.Default)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Set %s limit of workspace %s to %s", cmd.resource, client.Workspace(), limit)
+	return nil
+}
+
+// parseLimit validates the limit and returns it as resource limits.
+func (cmd *SetResourceLimitCmd) parseLimit(limit string) (*provider.ResourceLimits, error) {
+	if cmd.resource == resourceLimitCPUs {
+		_, err := devcontainer.ParseCPULimit(limit)
+		return &provider.ResourceLimits{CPUs: limit}, err
+	}
+
+	_, err := devcontainer.ParseMemoryLimit(limit)
+	return &provider.ResourceLimits{Memory: limit}, err
+}
+
+// mergeResourceLimits overrides the existing limits with the non-empty new ones.
+func mergeResourceLimits(
+	limits *provider.ResourceLimits,
+	newLimits *provider.ResourceLimits,
+) *provider.ResourceLimits {
+	merged := &provider.ResourceLimits{}
+	if limits != nil {
+		*merged = *limits
+	}
+	if newLimits.CPUs != "" {
+		merged.CPUs = newLimits.CPUs
+	}
+	if newLimits.Memory != "" {
+		merged.Memory = newLimits.Memory
+	}
+
+	return merged
+}
@@ -0,0 +1,36 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetResourceLimitParseLimit(t *testing.T) {
+	cpuCmd := &SetResourceLimitCmd{resource: resourceLimitCPUs}
+	limits, err := cpuCmd.parseLimit("1.5")
+	require.NoError(t, err)
+	assert.Equal(t, &provider.ResourceLimits{CPUs: "1.5"}, limits)
+	_, err = cpuCmd.parseLimit("0")
+	require.Error(t, err)
+
+	memoryCmd := &SetResourceLimitCmd{resource: resourceLimitMemory}
+	limits, err = memoryCmd.parseLimit("4g")
+	require.NoError(t, err)
+	assert.Equal(t, &provider.ResourceLimits{Memory: "4g"}, limits)
+	_, err = memoryCmd.parseLimit("lots")
+	require.Error(t, err)
+}
+
+func TestMergeResourceLimits(t *testing.T) {
+	merged := mergeResourceLimits(
+		&provider.ResourceLimits{CPUs: "2", Memory: "8g"},
+		&provider.ResourceLimits{Memory: "4g"},
+	)
+	assert.Equal(t, &provider.ResourceLimits{CPUs: "2", Memory: "4g"}, merged)
+
+	merged = mergeResourceLimits(nil, &provider.ResourceLimits{CPUs: "1"})
+	assert.Equal(t, &provider.ResourceLimits{CPUs: "1"}, merged)
+}
@@ -0,0 +1,147 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	client2 "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// SetWorkspaceFolderCmd holds the configuration.
+type SetWorkspaceFolderCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewSetWorkspaceFolderCmd creates a new set-workspace-folder command.
+func NewSetWorkspaceFolderCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &SetWorkspaceFolderCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "set-workspace-folder [workspace-path|workspace-name] [path]",
+		Short: "Overrides the folder of the workspace in the container",
+		Long: `Overrides the folder in the container that ssh sessions and IDEs open, e.g. if a
+devcontainer places the sources at /app. The folder is stored with the result of the last
+devpod up, the next devpod up detects the folder again. Set workspaceFolder in the
+devcontainer.json to change it permanently.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1])
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+}
+
+// Run runs the command logic.
+func (cmd *SetWorkspaceFolderCmd) Run(ctx context.Context, workspaceName, folder string) error {
+	if !path.IsAbs(folder) {
+		return fmt.Errorf("workspace folder %s must be an absolute path", folder)
+	}
+	folder = path.Clean(folder)
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	result, err := provider.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil || result.SubstitutionContext == nil {
+		return fmt.Errorf("workspace %s has no stored result, use devpod up to provision it", workspaceConfig.ID)
+	}
+
+	setResultWorkspaceFolder(result, folder)
+	err = provider.SaveWorkspaceResult(workspaceConfig, result)
+	if err != nil {
+		return fmt.Errorf("save workspace result: %w", err)
+	}
+
+	err = updateSSHConfigWorkdir(devPodConfig, client, result, folder)
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Set the folder of workspace %s to %s", workspaceConfig.ID, folder)
+	return nil
+}
+
+// setResultWorkspaceFolder overrides the workspace folder of the stored result.
+func setResultWorkspaceFolder(result *config2.Result, folder string) {
+	result.SubstitutionContext.ContainerWorkspaceFolder = folder
+	// the workspaceFolder of the devcontainer.json takes precedence when opening the workspace
+	if result.MergedConfig != nil {
+		result.MergedConfig.WorkspaceFolder = folder
+	}
+}
+
+// updateSSHConfigWorkdir rewrites the ssh config entry of the workspace with the new workdir.
+func updateSSHConfigWorkdir(
+	devPodConfig *config.Config,
+	client client2.BaseWorkspaceClient,
+	result *config2.Result,
+	workdir string,
+) error {
+	workspaceConfig := client.WorkspaceConfig()
+	sshConfigPath, sshConfigIncludePath, err := devssh.ResolveSSHConfigPaths(
+		devPodConfig,
+		workspaceConfig.SSHConfigPath,
+		workspaceConfig.SSHConfigIncludePath,
+	)
+	if err != nil {
+		return err
+	}
+
+	return devssh.UpdateSSHConfig(devssh.SSHConfigParams{
+		SSHConfigPath:        sshConfigPath,
+		SSHConfigIncludePath: sshConfigIncludePath,
+		Context:              client.Context(),
+		Workspace:            client.Workspace(),
+		User:                 config2.GetRemoteUser(result),
+		GPGAgent:             devPodConfig.ContextOption(config.ContextOptionGPGAgentForwarding) == config.BoolTrue,
+		DevPodHome:           os.Getenv(config.EnvHome),
+		Provider:             client.Provider(),
+		IdentityFile:         devssh.GetWorkspaceIdentityFile(client.Context(), client.Workspace()),
+		Log:                  log.Default,
+	}, func(params *devssh.SSHConfigParams) {
+		params.Workdir = workdir
+	})
+}
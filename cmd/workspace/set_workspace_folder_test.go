@@ -0,0 +1,29 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetResultWorkspaceFolder(t *testing.T) {
+	result := &config2.Result{
+		SubstitutionContext: &config2.SubstitutionContext{
+			ContainerWorkspaceFolder: "/workspaces/my-ws",
+		},
+		MergedConfig: &config2.MergedDevContainerConfig{},
+	}
+	setResultWorkspaceFolder(result, "/app")
+
+	assert.Equal(t, "/app", result.SubstitutionContext.ContainerWorkspaceFolder)
+	assert.Equal(t, "/app", result.MergedConfig.WorkspaceFolder)
+}
+
+func TestSetWorkspaceFolderRequiresAbsolutePath(t *testing.T) {
+	cmd := &SetWorkspaceFolderCmd{GlobalFlags: &flags.GlobalFlags{}}
+	err := cmd.Run(context.Background(), "my-ws", "app")
+	assert.ErrorContains(t, err, "must be an absolute path")
+}
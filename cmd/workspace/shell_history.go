@@ -0,0 +1,237 @@
+package workspace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// shellHistoryScript prints the name of the shell of the remote user in the first line
+// followed by the content of its history file.
+const shellHistoryScript = `shell="${SHELL:-$(getent passwd "$(id -un)" 2>/dev/null | cut -d: -f7)}"
+shell="$(basename "${shell:-sh}")"
+case "$shell" in
+  zsh) file="$HOME/.zsh_history" ;;
+  fish) file="${XDG_DATA_HOME:-$HOME/.local/share}/fish/fish_history" ;;
+  ash) file="$HOME/.ash_history" ;;
+  sh|ksh|mksh) file="$HOME/.sh_history" ;;
+  *) file="$HOME/.bash_history" ;;
+esac
+echo "$shell"
+cat "$file" 2>/dev/null || true`
+
+// ShellHistoryCmd holds the configuration.
+type ShellHistoryCmd struct {
+	*flags.GlobalFlags
+
+	Lines int
+	Grep  string
+}
+
+// historyEntry is a single command of the shell history.
+type historyEntry struct {
+	Time    *time.Time
+	Command string
+}
+
+// NewShellHistoryCmd creates a new shell-history command.
+func NewShellHistoryCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ShellHistoryCmd{
+		GlobalFlags: flags,
+	}
+	shellHistoryCmd := &cobra.Command{
+		Use:   "shell-history [flags] [workspace-path|workspace-name]",
+		Short: "Shows the shell history of the remote user of the workspace",
+		Long: `Shows the commands of the shell history of the remote user of the workspace. The
+history file is picked based on the shell of the remote user, timestamps are shown if the
+shell recorded them.`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	shellHistoryCmd.Flags().IntVar(&cmd.Lines, "lines", 50, "The number of commands to show, 0 shows all commands")
+	shellHistoryCmd.Flags().StringVar(&cmd.Grep, "grep", "", "Only show commands matching the regular expression")
+	return shellHistoryCmd
+}
+
+// Run runs the command logic.
+func (cmd *ShellHistoryCmd) Run(ctx context.Context, args []string) error {
+	var filter *regexp.Regexp
+	if cmd.Grep != "" {
+		var err error
+		filter, err = regexp.Compile(cmd.Grep)
+		if err != nil {
+			return fmt.Errorf("parse --grep: %w", err)
+		}
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	stdout := &bytes.Buffer{}
+	err = runSSHCommand(ctx, cmd.GlobalFlags, client.Workspace(), "", shellHistoryScript, nil, stdout, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("read shell history: %w", err)
+	}
+
+	shell, history, _ := strings.Cut(stdout.String(), "\n")
+	entries := filterHistory(parseShellHistory(strings.TrimSpace(shell), history), filter, cmd.Lines)
+	printHistory(os.Stdout, entries)
+	return nil
+}
+
+// parseShellHistory parses the history file of the given shell.
+func parseShellHistory(shell, history string) []historyEntry {
+	switch shell {
+	case "zsh":
+		return parseZshHistory(history)
+	case "fish":
+		return parseFishHistory(history)
+	default:
+		return parseBashHistory(history)
+	}
+}
+
+// parseBashHistory parses bash history, timestamps are written as #<unix> comments
+// if HISTTIMEFORMAT was set.
+func parseBashHistory(history string) []historyEntry {
+	entries := []historyEntry{}
+	var timestamp *time.Time
+	scanner := bufio.NewScanner(strings.NewReader(history))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "#") {
+			if t := parseUnixTime(line[1:]); t != nil {
+				timestamp = t
+				continue
+			}
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entries = append(entries, historyEntry{Time: timestamp, Command: line})
+		timestamp = nil
+	}
+
+	return entries
+}
+
+// parseZshHistory parses zsh history, with EXTENDED_HISTORY lines look like ": <unix>:<duration>;<command>".
+func parseZshHistory(history string) []historyEntry {
+	entries := []historyEntry{}
+	scanner := bufio.NewScanner(strings.NewReader(history))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entry := historyEntry{Command: line}
+		if meta, command, ok := strings.Cut(line, ";"); ok && strings.HasPrefix(meta, ": ") {
+			timestamp, _, _ := strings.Cut(strings.TrimPrefix(meta, ": "), ":")
+			if t := parseUnixTime(timestamp); t != nil {
+				entry = historyEntry{Time: t, Command: command}
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// parseFishHistory parses the yaml like fish history with "- cmd:" and "  when:" lines.
+func parseFishHistory(history string) []historyEntry {
+	entries := []historyEntry{}
+	scanner := bufio.NewScanner(strings.NewReader(history))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if command, ok := strings.CutPrefix(line, "- cmd: "); ok {
+			entries = append(entries, historyEntry{Command: command})
+		} else if when, ok := strings.CutPrefix(strings.TrimSpace(line), "when: "); ok && len(entries) > 0 {
+			entries[len(entries)-1].Time = parseUnixTime(when)
+		}
+	}
+
+	return entries
+}
+
+func parseUnixTime(value string) *time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	t := time.Unix(seconds, 0)
+	return &t
+}
+
+// filterHistory keeps the entries matching filter and returns the last lines entries.
+func filterHistory(entries []historyEntry, filter *regexp.Regexp, lines int) []historyEntry {
+	if filter != nil {
+		filtered := []historyEntry{}
+		for _, entry := range entries {
+			if filter.MatchString(entry.Command) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	if lines > 0 && len(entries) > lines {
+		entries = entries[len(entries)-lines:]
+	}
+
+	return entries
+}
+
+func printHistory(w io.Writer, entries []historyEntry) {
+	for _, entry := range entries {
+		if entry.Time != nil {
+			_, _ = fmt.Fprintf(w, "%s  %s\n", entry.Time.Local().Format(time.DateTime), entry.Command)
+		} else {
+			_, _ = fmt.Fprintln(w, entry.Command)
+		}
+	}
+}
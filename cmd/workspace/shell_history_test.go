@@ -0,0 +1,42 @@
+package workspace
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShellHistory(t *testing.T) {
+	bash := parseShellHistory("bash", "ls -la\n#1700000000\ngit status\n\n")
+	require.Len(t, bash, 2)
+	assert.Nil(t, bash[0].Time)
+	assert.Equal(t, "git status", bash[1].Command)
+	require.NotNil(t, bash[1].Time)
+	assert.Equal(t, int64(1700000000), bash[1].Time.Unix())
+
+	zsh := parseShellHistory("zsh", ": 1700000001:0;make test\nplain\n")
+	require.Len(t, zsh, 2)
+	assert.Equal(t, "make test", zsh[0].Command)
+	assert.Equal(t, int64(1700000001), zsh[0].Time.Unix())
+	assert.Equal(t, "plain", zsh[1].Command)
+	assert.Nil(t, zsh[1].Time)
+
+	fish := parseShellHistory("fish", "- cmd: go build\n  when: 1700000002\n- cmd: exit\n")
+	require.Len(t, fish, 2)
+	assert.Equal(t, "go build", fish[0].Command)
+	assert.Equal(t, int64(1700000002), fish[0].Time.Unix())
+	assert.Nil(t, fish[1].Time)
+}
+
+func TestFilterHistory(t *testing.T) {
+	entries := parseShellHistory("bash", "git status\nls\ngit log\ngit diff\n")
+
+	filtered := filterHistory(entries, regexp.MustCompile("^git"), 2)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "git log", filtered[0].Command)
+	assert.Equal(t, "git diff", filtered[1].Command)
+
+	assert.Len(t, filterHistory(entries, nil, 0), 4)
+}
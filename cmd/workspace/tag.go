@@ -0,0 +1,137 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// TagCmd holds the configuration.
+type TagCmd struct {
+	*flags.GlobalFlags
+
+	remove bool
+}
+
+// NewTagCmd creates a new tag command.
+func NewTagCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TagCmd{
+		GlobalFlags: flags,
+	}
+	return &cobra.Command{
+		Use:   "tag [workspace-path|workspace-name] [tags...]",
+		Short: "Adds tags to a workspace",
+		Long: `Adds tags to a workspace. Tags are stored in the workspace config and can be
+used to filter workspaces, e.g. devpod list --filter tag=frontend`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1:])
+		},
+		ValidArgsFunction: cmd.validArgs,
+	}
+}
+
+// NewUntagCmd creates a new untag command.
+func NewUntagCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TagCmd{
+		GlobalFlags: flags,
+		remove:      true,
+	}
+	return &cobra.Command{
+		Use:               "untag [workspace-path|workspace-name] [tags...]",
+		Short:             "Removes tags from a workspace",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: cmd.validArgs,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args[0], args[1:])
+		},
+	}
+}
+
+func (cmd *TagCmd) validArgs(
+	rootCmd *cobra.Command, args []string, toComplete string,
+) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return completion.GetWorkspaceSuggestions(
+		rootCmd,
+		cmd.Context,
+		cmd.Provider,
+		args,
+		toComplete,
+		cmd.Owner,
+		log.Default,
+	)
+}
+
+// Run runs the command logic.
+func (cmd *TagCmd) Run(ctx context.Context, workspaceName string, tags []string) error {
+	for _, tag := range tags {
+		if tag == "" || strings.ContainsAny(tag, ", ") {
+			return fmt.Errorf("invalid tag %q, tags must not be empty or contain commas or spaces", tag)
+		}
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         []string{workspaceName},
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	if cmd.remove {
+		workspaceConfig.Tags = removeTags(workspaceConfig.Tags, tags)
+	} else {
+		workspaceConfig.Tags = addTags(workspaceConfig.Tags, tags)
+	}
+
+	err = provider.SaveWorkspaceConfig(workspaceConfig)
+	if err != nil {
+		return fmt.Errorf("save workspace: %w", err)
+	}
+
+	if len(workspaceConfig.Tags) == 0 {
+		log.Default.Donef("Workspace %s has no tags", workspaceConfig.ID)
+		return nil
+	}
+
+	log.Default.Donef("Workspace %s has tags: %s", workspaceConfig.ID, strings.Join(workspaceConfig.Tags, ", "))
+	log.Default.Info("The container label is updated the next time the workspace container is recreated")
+	return nil
+}
+
+func addTags(existing []string, tags []string) []string {
+	for _, tag := range tags {
+		if !slices.Contains(existing, tag) {
+			existing = append(existing, tag)
+		}
+	}
+
+	return existing
+}
+
+func removeTags(existing []string, tags []string) []string {
+	return slices.DeleteFunc(existing, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+}
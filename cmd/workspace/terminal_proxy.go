@@ -0,0 +1,214 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	"github.com/skevetter/devpod/pkg/terminalproxy"
+	"github.com/skevetter/devpod/pkg/tunnel"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/term"
+)
+
+const terminalProxySocket = "terminal.sock"
+
+// TerminalProxyCmd holds the configuration.
+type TerminalProxyCmd struct {
+	*flags.GlobalFlags
+
+	Socket  string
+	Session string
+	Attach  bool
+}
+
+// NewTerminalProxyCmd creates a new terminal-proxy command.
+func NewTerminalProxyCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TerminalProxyCmd{
+		GlobalFlags: flags,
+	}
+	terminalProxyCmd := &cobra.Command{
+		Use:   "terminal-proxy [flags] [workspace-path|workspace-name]",
+		Short: "Keeps terminals of a workspace alive across disconnects",
+		Long: `Starts a local proxy that holds a single ssh connection to the workspace and accepts
+terminals on a unix socket. Every terminal is a session on the shared connection attached to a
+tmux session in the workspace, so the terminal state survives when a client disconnects. The
+proxy reconnects automatically if the connection to the workspace drops. Clients send a JSON
+line with term, rows and cols followed by the raw terminal stream, --attach does this for the
+current terminal. Without tmux in the workspace a plain login shell is started.`,
+		Example: `  devpod workspace terminal-proxy my-workspace
+  devpod workspace terminal-proxy my-workspace --attach`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	terminalProxyCmd.Flags().StringVar(&cmd.Socket, "socket", "",
+		"The unix socket to listen on. Defaults to terminal.sock in the workspace folder")
+	terminalProxyCmd.Flags().StringVar(&cmd.Session, "session", terminalproxy.DefaultSession,
+		"The name of the tmux session in the workspace")
+	terminalProxyCmd.Flags().BoolVar(&cmd.Attach, "attach", false,
+		"Attach the current terminal to a running proxy instead of starting one")
+	return terminalProxyCmd
+}
+
+// Run runs the command logic.
+func (cmd *TerminalProxyCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	socket := cmd.Socket
+	if socket == "" {
+		workspaceDir, err := provider.GetWorkspaceDir(client.Context(), client.Workspace())
+		if err != nil {
+			return err
+		}
+		socket = filepath.Join(workspaceDir, terminalProxySocket)
+	}
+	if cmd.Attach {
+		return attachTerminal(socket)
+	}
+
+	listener, err := listenUnix(socket)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(socket) }()
+
+	log.Default.Infof("Terminal proxy for workspace %s listening on %s", client.Workspace(), socket)
+	proxy := &terminalproxy.Proxy{
+		Dial:    stdioDialer(client),
+		Session: cmd.Session,
+		Log:     log.Default,
+	}
+	return proxy.Serve(ctx, listener)
+}
+
+// stdioDialer connects to the workspace through devpod ssh --stdio.
+func stdioDialer(client clientpkg.BaseWorkspaceClient) terminalproxy.Dialer {
+	return func(ctx context.Context) (*ssh.Client, func(), error) {
+		sshCmd, err := tunnel.CreateSSHCommand(ctx, client, log.Default, []string{"--stdio"})
+		if err != nil {
+			return nil, nil, err
+		}
+		stdout, err := sshCmd.StdoutPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		stdin, err := sshCmd.StdinPipe()
+		if err != nil {
+			return nil, nil, err
+		}
+		writer := log.Default.Writer(logrus.DebugLevel, false)
+		sshCmd.Stderr = writer
+
+		err = sshCmd.Start()
+		if err != nil {
+			_ = writer.Close()
+			return nil, nil, fmt.Errorf("start ssh: %w", err)
+		}
+		release := func() {
+			_ = sshCmd.Process.Kill()
+			_ = sshCmd.Wait()
+			_ = writer.Close()
+		}
+
+		sshClient, err := devssh.StdioClient(stdout, stdin, false)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+
+		return sshClient, release, nil
+	}
+}
+
+// listenUnix listens on the socket and removes a stale socket of a proxy that is gone.
+func listenUnix(socket string) (net.Listener, error) {
+	conn, err := net.Dial("unix", socket)
+	if err == nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("a terminal proxy is already listening on %s", socket)
+	}
+	err = os.Remove(socket)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", socket, err)
+	}
+
+	return listener, nil
+}
+
+// attachTerminal connects the current terminal to the proxy listening on the socket.
+func attachTerminal(socket string) error {
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return fmt.Errorf("connect to terminal proxy, is it running: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	request := terminalproxy.Request{Term: os.Getenv("TERM")}
+	fd := int(os.Stdin.Fd()) // #nosec G115 -- file descriptors fit into an int
+	if term.IsTerminal(fd) {
+		request.Cols, request.Rows, _ = term.GetSize(fd)
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = term.Restore(fd, state) }()
+	}
+
+	err = json.NewEncoder(conn).Encode(request)
+	if err != nil {
+		return err
+	}
+
+	go func() { _, _ = io.Copy(conn, os.Stdin) }()
+	_, err = io.Copy(os.Stdout, conn)
+	return err
+}
@@ -0,0 +1,186 @@
+package workspace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/skevetter/devpod/pkg/table"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// TopCmd holds the configuration.
+type TopCmd struct {
+	*flags.GlobalFlags
+
+	Interval time.Duration
+	User     string
+	PID      int
+}
+
+// NewTopCmd creates a new top command.
+func NewTopCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TopCmd{
+		GlobalFlags: flags,
+	}
+	topCmd := &cobra.Command{
+		Use:   "top [flags] [workspace-path|workspace-name]",
+		Short: "Shows the processes running in the workspace container",
+		Long: `Shows the processes running in the workspace container sorted by CPU usage and
+refreshes them until interrupted.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	topCmd.Flags().DurationVar(&cmd.Interval, "interval", 2*time.Second, "The interval to refresh the processes")
+	topCmd.Flags().StringVar(&cmd.User, "user", "", "Only show processes of the given user")
+	topCmd.Flags().IntVar(&cmd.PID, "pid", 0, "Only show child processes of the given parent PID")
+	return topCmd
+}
+
+// Run runs the command logic.
+func (cmd *TopCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	baseClient, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	client, ok := baseClient.(clientpkg.WorkspaceClient)
+	if !ok {
+		return fmt.Errorf("this command is not supported for proxy providers")
+	}
+
+	agentCommand := fmt.Sprintf(
+		"'%s' agent workspace top --context '%s' --id '%s' --interval '%s'",
+		client.AgentPath(),
+		client.Context(),
+		client.Workspace(),
+		cmd.Interval,
+	)
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	reader, writer := io.Pipe()
+	printErr := make(chan error, 1)
+	go func() {
+		printErr <- cmd.printProcesses(reader, os.Stdout)
+		_ = reader.Close()
+	}()
+
+	err = RunAgentCommand(ctx, devPodConfig, client, agentCommand, writer, os.Stderr, log.Default)
+	_ = writer.Close()
+	if ctx.Err() != nil {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	return <-printErr
+}
+
+// printProcesses renders every json line the agent prints.
+func (cmd *TopCmd) printProcesses(reader io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		processes := []docker.ContainerProcess{}
+		err := json.Unmarshal(scanner.Bytes(), &processes)
+		if err != nil {
+			return fmt.Errorf("parse container processes: %w", err)
+		}
+
+		_, _ = fmt.Fprint(w, clearScreen)
+		printProcessTable(filterProcesses(processes, cmd.User, cmd.PID))
+	}
+
+	return scanner.Err()
+}
+
+// filterProcesses returns the processes of user and with parent pid, empty values match all processes.
+func filterProcesses(processes []docker.ContainerProcess, user string, pid int) []docker.ContainerProcess {
+	retProcesses := []docker.ContainerProcess{}
+	for _, process := range processes {
+		if user != "" && process.User != user {
+			continue
+		} else if pid != 0 && process.PPID != pid {
+			continue
+		}
+
+		retProcesses = append(retProcesses, process)
+	}
+
+	return retProcesses
+}
+
+func printProcessTable(processes []docker.ContainerProcess) {
+	tableEntries := [][]string{}
+	for _, process := range processes {
+		tableEntries = append(tableEntries, []string{
+			strconv.Itoa(process.PID),
+			strconv.Itoa(process.PPID),
+			process.User,
+			process.CPUPerc,
+			process.MemPerc,
+			process.RSS,
+			process.Stat,
+			process.Elapsed,
+			process.Command,
+		})
+	}
+
+	table.Print([]string{
+		"PID",
+		"PPID",
+		"User",
+		"CPU %",
+		"Mem %",
+		"RSS",
+		"Stat",
+		"Elapsed",
+		"Command",
+	}, tableEntries)
+}
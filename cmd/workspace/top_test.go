@@ -0,0 +1,22 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/docker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterProcesses(t *testing.T) {
+	processes := []docker.ContainerProcess{
+		{User: "root", PID: 1, PPID: 0},
+		{User: "vscode", PID: 20, PPID: 1},
+		{User: "vscode", PID: 21, PPID: 20},
+		{User: "root", PID: 30, PPID: 20},
+	}
+
+	assert.Len(t, filterProcesses(processes, "", 0), 4)
+	assert.Equal(t, []docker.ContainerProcess{processes[1], processes[2]}, filterProcesses(processes, "vscode", 0))
+	assert.Equal(t, []docker.ContainerProcess{processes[2], processes[3]}, filterProcesses(processes, "", 20))
+	assert.Equal(t, []docker.ContainerProcess{processes[3]}, filterProcesses(processes, "root", 20))
+}
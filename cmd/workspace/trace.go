@@ -0,0 +1,146 @@
+package workspace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/table"
+	"github.com/skevetter/devpod/pkg/telemetry"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// TraceCmd holds the configuration.
+type TraceCmd struct {
+	*flags.GlobalFlags
+
+	Output string
+}
+
+// NewTraceCmd creates a new trace command.
+func NewTraceCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &TraceCmd{
+		GlobalFlags: flags,
+	}
+	traceCmd := &cobra.Command{
+		Use:   "trace [flags] [workspace-path|workspace-name]",
+		Short: "Shows the latency breakdown of the last up of a workspace",
+		Long: `Shows the spans DevPod and the workspace agent recorded during the last up of a
+workspace with their start offset and duration. Spans are only recorded if
+OTEL_EXPORTER_OTLP_ENDPOINT was set for the up, the trace id links to the trace in the OTLP backend.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	traceCmd.Flags().StringVar(&cmd.Output, "output", "plain", "The output format to use. Can be json or plain")
+	return traceCmd
+}
+
+// Run runs the command logic.
+func (cmd *TraceCmd) Run(ctx context.Context, args []string) error {
+	if cmd.Output != "plain" && cmd.Output != "json" {
+		return fmt.Errorf("unexpected output format, choose either json or plain. Got %s", cmd.Output)
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	traceFile, err := telemetry.GetWorkspaceTraceFile(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	}
+	spans, err := telemetry.LoadWorkspaceTrace(traceFile)
+	if err != nil {
+		return fmt.Errorf("read workspace trace: %w", err)
+	} else if len(spans) == 0 {
+		return fmt.Errorf(
+			"no trace recorded for workspace %s, run devpod up with %s set",
+			client.Workspace(),
+			telemetry.EnvOTLPEndpoint,
+		)
+	}
+
+	if cmd.Output == "json" {
+		out, err := json.MarshalIndent(spans, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	fmt.Printf("Trace %s\n", spans[0].TraceID)
+	table.Print([]string{
+		"Span",
+		"Start",
+		"Duration",
+		"Error",
+	}, traceEntries(spans))
+	return nil
+}
+
+// traceEntries returns the table rows of the spans ordered by their start. Child spans are
+// indented below their parent and the start is relative to the first span.
+func traceEntries(spans []telemetry.TraceSpan) [][]string {
+	spans = slices.Clone(spans)
+	slices.SortStableFunc(spans, func(a, b telemetry.TraceSpan) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	parents := map[string]string{}
+	for _, span := range spans {
+		parents[span.SpanID] = span.ParentSpanID
+	}
+
+	tableEntries := [][]string{}
+	for _, span := range spans {
+		depth := 0
+		for parent := span.ParentSpanID; parent != "" && depth < len(spans); depth++ {
+			parent = parents[parent]
+		}
+
+		tableEntries = append(tableEntries, []string{
+			strings.Repeat("  ", depth) + span.Name,
+			"+" + span.Start.Sub(spans[0].Start).Round(time.Millisecond).String(),
+			span.End.Sub(span.Start).Round(time.Millisecond).String(),
+			span.Error,
+		})
+	}
+
+	return tableEntries
+}
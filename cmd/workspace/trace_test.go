@@ -0,0 +1,42 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/telemetry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraceEntries(t *testing.T) {
+	start := time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)
+	spans := []telemetry.TraceSpan{
+		{
+			SpanID:       "build",
+			ParentSpanID: "up",
+			Name:         telemetry.SpanBuildImage,
+			Start:        start.Add(2 * time.Second),
+			End:          start.Add(12 * time.Second),
+			Error:        "build failed",
+		},
+		{
+			SpanID: "up",
+			Name:   telemetry.SpanUp,
+			Start:  start,
+			End:    start.Add(15 * time.Second),
+		},
+		{
+			SpanID:       "inject",
+			ParentSpanID: "up",
+			Name:         telemetry.SpanAgentInject,
+			Start:        start.Add(500 * time.Millisecond),
+			End:          start.Add(time.Second),
+		},
+	}
+
+	assert.Equal(t, [][]string{
+		{telemetry.SpanUp, "+0s", "15s", ""},
+		{"  " + telemetry.SpanAgentInject, "+500ms", "500ms", ""},
+		{"  " + telemetry.SpanBuildImage, "+2s", "10s", "build failed"},
+	}, traceEntries(spans))
+}
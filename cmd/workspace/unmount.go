@@ -0,0 +1,104 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// UnmountCmd holds the configuration.
+type UnmountCmd struct {
+	*flags.GlobalFlags
+}
+
+// NewUnmountCmd creates a new unmount command.
+func NewUnmountCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &UnmountCmd{
+		GlobalFlags: flags,
+	}
+	unmountCmd := &cobra.Command{
+		Use:   "unmount [flags] [workspace-path|workspace-name]",
+		Short: "Unmounts the workspace folder mounted with mount-ssh-fs",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	return unmountCmd
+}
+
+// Run runs the command logic.
+func (cmd *UnmountCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	mountpoint, err := provider.LoadWorkspaceSSHFSMount(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	} else if mountpoint == "" {
+		return fmt.Errorf("workspace %s is not mounted", client.Workspace())
+	}
+
+	name, unmountArgs := unmountCommand(mountpoint)
+	// #nosec G204 -- the mountpoint was stored by mount-ssh-fs
+	unmountCmd := exec.CommandContext(ctx, name, unmountArgs...)
+	unmountCmd.Stdout = os.Stdout
+	unmountCmd.Stderr = os.Stderr
+	err = unmountCmd.Run()
+	if err != nil {
+		return fmt.Errorf("unmount %s: %w", mountpoint, err)
+	}
+
+	err = provider.DeleteWorkspaceSSHFSMount(client.Context(), client.Workspace())
+	if err != nil {
+		return err
+	}
+
+	log.Default.Donef("Unmounted workspace %s from %s", client.Workspace(), mountpoint)
+	return nil
+}
+
+// unmountCommand returns the command to unmount a fuse file system on the current platform.
+func unmountCommand(mountpoint string) (string, []string) {
+	if runtime.GOOS == "darwin" {
+		return "umount", []string{mountpoint}
+	}
+
+	return "fusermount", []string{"-u", mountpoint}
+}
@@ -0,0 +1,132 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/client/clientimplementation"
+	"github.com/skevetter/devpod/pkg/config"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// WaitTimeoutExitCode is the exit code of devpod workspace wait if the timeout is reached.
+const WaitTimeoutExitCode = 2
+
+// WaitTimeoutError is returned if the workspace did not reach the status in time.
+type WaitTimeoutError struct {
+	Workspace string
+	Status    clientpkg.Status
+	Timeout   time.Duration
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf(
+		"workspace %s did not reach status %s within %s",
+		e.Workspace,
+		e.Status,
+		e.Timeout,
+	)
+}
+
+// WaitCmd holds the configuration.
+type WaitCmd struct {
+	*flags.GlobalFlags
+
+	Status   string
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+// NewWaitCmd creates a new wait command.
+func NewWaitCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &WaitCmd{
+		GlobalFlags: flags,
+	}
+	waitCmd := &cobra.Command{
+		Use:   "wait [flags] [workspace-path|workspace-name]",
+		Short: "Waits until a workspace reaches a status",
+		Long: `Polls the workspace status until it matches --status. Exits with 0 once the status
+matches, with 2 if --timeout is reached and with 1 on any other error.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	waitCmd.Flags().StringVar(&cmd.Status, "status", clientpkg.StatusRunning,
+		"The status to wait for. Can be Running, Busy, Stopped or NotFound")
+	waitCmd.Flags().DurationVar(&cmd.Timeout, "timeout", 5*time.Minute, "The maximum time to wait")
+	waitCmd.Flags().
+		DurationVar(&cmd.Interval, "interval", 5*time.Second, "How often the status is polled")
+	return waitCmd
+}
+
+// Run runs the command logic.
+func (cmd *WaitCmd) Run(ctx context.Context, args []string) error {
+	status, err := clientpkg.ParseStatus(cmd.Status)
+	if err != nil {
+		return err
+	} else if cmd.Timeout <= 0 || cmd.Interval <= 0 {
+		return fmt.Errorf("--timeout and --interval need to be greater than zero")
+	}
+
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default.ErrorStreamOnly(),
+	})
+	if err != nil {
+		return err
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, cmd.Timeout)
+	defer cancel()
+
+	polled := false
+	onPoll := func(clientpkg.Status) {
+		// print a progress dot to indicate liveness
+		_, _ = fmt.Fprint(os.Stderr, ".")
+		polled = true
+	}
+	err = clientimplementation.WaitForStatus(timeoutCtx, client, status, cmd.Interval, onPoll)
+	if polled {
+		_, _ = fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			return &WaitTimeoutError{Workspace: client.Workspace(), Status: status, Timeout: cmd.Timeout}
+		}
+
+		return err
+	}
+
+	log.Default.Donef("workspace %s is %s", client.Workspace(), status)
+	return nil
+}
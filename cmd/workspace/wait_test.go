@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/cmd/flags"
+	clientpkg "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/client/clientimplementation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statusClient struct {
+	clientpkg.BaseClient
+
+	statuses []clientpkg.Status
+}
+
+func (c *statusClient) Status(context.Context, clientpkg.StatusOptions) (clientpkg.Status, error) {
+	status := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+
+	return status, nil
+}
+
+func TestWaitForStatus(t *testing.T) {
+	client := &statusClient{statuses: []clientpkg.Status{
+		clientpkg.StatusStopped,
+		clientpkg.StatusBusy,
+		clientpkg.StatusRunning,
+	}}
+
+	polls := 0
+	err := clientimplementation.WaitForStatus(
+		context.Background(), client, clientpkg.StatusRunning, time.Millisecond,
+		func(clientpkg.Status) { polls++ },
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 2, polls)
+}
+
+func TestWaitForStatusTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	client := &statusClient{statuses: []clientpkg.Status{clientpkg.StatusBusy}}
+	err := clientimplementation.WaitForStatus(ctx, client, clientpkg.StatusRunning, time.Millisecond, nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestWaitRejectsUnknownStatus(t *testing.T) {
+	waitCmd := NewWaitCmd(&flags.GlobalFlags{})
+	waitCmd.SetArgs([]string{"my-workspace", "--status", "paused"})
+	waitCmd.SilenceUsage = true
+	waitCmd.SilenceErrors = true
+	require.ErrorContains(t, waitCmd.Execute(), "unrecognized status")
+}
@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"github.com/skevetter/devpod/cmd/flags"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceCmd returns a new root command.
+func NewWorkspaceCmd(flags *flags.GlobalFlags) *cobra.Command {
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "DevPod Workspace commands",
+	}
+
+	workspaceCmd.AddCommand(NewAnnotationsCmd(flags))
+	workspaceCmd.AddCommand(NewAttachVolumeCmd(flags))
+	workspaceCmd.AddCommand(NewBenchmarkCmd(flags))
+	workspaceCmd.AddCommand(NewBookmarkCmd(flags))
+	workspaceCmd.AddCommand(NewBookmarksCmd(flags))
+	workspaceCmd.AddCommand(NewCancelScheduleCmd(flags))
+	workspaceCmd.AddCommand(NewCleanupTempCmd(flags))
+	workspaceCmd.AddCommand(NewCloneCmd(flags))
+	workspaceCmd.AddCommand(NewConfigDiffCmd(flags))
+	workspaceCmd.AddCommand(NewConvertImageCmd(flags))
+	workspaceCmd.AddCommand(NewConvertToGitCmd(flags))
+	workspaceCmd.AddCommand(NewCopyFileCmd(flags))
+	workspaceCmd.AddCommand(NewDiffCmd(flags))
+	workspaceCmd.AddCommand(NewDiffFeaturesCmd(flags))
+	workspaceCmd.AddCommand(NewEnvCmd(flags))
+	workspaceCmd.AddCommand(NewEventsCmd(flags))
+	workspaceCmd.AddCommand(NewExecCmd(flags))
+	workspaceCmd.AddCommand(NewExecAsCmd(flags))
+	workspaceCmd.AddCommand(NewExportSSHConfigCmd(flags))
+	workspaceCmd.AddCommand(NewGCCmd(flags))
+	workspaceCmd.AddCommand(NewInspectCmd(flags))
+	workspaceCmd.AddCommand(NewIPCmd(flags))
+	workspaceCmd.AddCommand(NewKillCmd(flags))
+	workspaceCmd.AddCommand(NewListSchedulesCmd(flags))
+	workspaceCmd.AddCommand(NewMigrateProviderCmd(flags))
+	workspaceCmd.AddCommand(NewMountSSHFSCmd(flags))
+	workspaceCmd.AddCommand(NewNetworkPolicyCmd(flags))
+	workspaceCmd.AddCommand(NewOpenInBrowserCmd(flags))
+	workspaceCmd.AddCommand(NewPinCmd(flags))
+	workspaceCmd.AddCommand(NewPortListCmd(flags))
+	workspaceCmd.AddCommand(NewRefreshCredentialsCmd(flags))
+	workspaceCmd.AddCommand(NewReplayLifecycleCmd(flags))
+	workspaceCmd.AddCommand(NewResetSSHKeyCmd(flags))
+	workspaceCmd.AddCommand(NewResourcesCmd(flags))
+	workspaceCmd.AddCommand(NewScheduleStopCmd(flags))
+	workspaceCmd.AddCommand(NewSealCmd(flags))
+	workspaceCmd.AddCommand(NewSetCPULimitCmd(flags))
+	workspaceCmd.AddCommand(NewSetDefaultIDECmd(flags))
+	workspaceCmd.AddCommand(NewSetFeatureOptionsCmd(flags))
+	workspaceCmd.AddCommand(NewSetGitConfigCmd(flags))
+	workspaceCmd.AddCommand(NewSetMemoryLimitCmd(flags))
+	workspaceCmd.AddCommand(NewSetProviderCmd(flags))
+	workspaceCmd.AddCommand(NewSetWorkspaceFolderCmd(flags))
+	workspaceCmd.AddCommand(NewShellHistoryCmd(flags))
+	workspaceCmd.AddCommand(NewTagCmd(flags))
+	workspaceCmd.AddCommand(NewTerminalProxyCmd(flags))
+	workspaceCmd.AddCommand(NewTopCmd(flags))
+	workspaceCmd.AddCommand(NewTraceCmd(flags))
+	workspaceCmd.AddCommand(NewUnbookmarkCmd(flags))
+	workspaceCmd.AddCommand(NewUnmountCmd(flags))
+	workspaceCmd.AddCommand(NewUntagCmd(flags))
+	workspaceCmd.AddCommand(NewUnpinCmd(flags))
+	workspaceCmd.AddCommand(NewWaitCmd(flags))
+	return workspaceCmd
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skevetter/devpod/cmd/completion"
+	"github.com/skevetter/devpod/cmd/flags"
+	client2 "github.com/skevetter/devpod/pkg/client"
+	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	workspace2 "github.com/skevetter/devpod/pkg/workspace"
+	"github.com/skevetter/log"
+	"github.com/spf13/cobra"
+)
+
+// WorkspaceAttachCmd holds the attach cmd flags.
+type WorkspaceAttachCmd struct {
+	*flags.GlobalFlags
+
+	GPGAgentForwarding bool
+}
+
+// NewWorkspaceAttachCmd creates a new workspace attach command.
+func NewWorkspaceAttachCmd(flags *flags.GlobalFlags) *cobra.Command {
+	cmd := &WorkspaceAttachCmd{
+		GlobalFlags: flags,
+	}
+	attachCmd := &cobra.Command{
+		Use:   "attach [flags] [workspace-path|workspace-name]",
+		Short: "Opens the IDE of a running workspace",
+		Long: `Opens the IDE of a running workspace without provisioning it again.
+The workspace has to be running, use devpod up to start a stopped workspace.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.Run(cobraCmd.Context(), args)
+		},
+		ValidArgsFunction: func(
+			rootCmd *cobra.Command, args []string, toComplete string,
+		) ([]string, cobra.ShellCompDirective) {
+			return completion.GetWorkspaceSuggestions(
+				rootCmd,
+				cmd.Context,
+				cmd.Provider,
+				args,
+				toComplete,
+				cmd.Owner,
+				log.Default,
+			)
+		},
+	}
+
+	attachCmd.Flags().BoolVar(&cmd.GPGAgentForwarding, "gpg-agent-forwarding", false,
+		"If true forward the local gpg-agent to the DevPod workspace")
+	return attachCmd
+}
+
+// Run runs the command logic.
+func (cmd *WorkspaceAttachCmd) Run(ctx context.Context, args []string) error {
+	devPodConfig, err := config.LoadConfig(cmd.Context, cmd.Provider)
+	if err != nil {
+		return err
+	}
+
+	client, err := workspace2.Get(ctx, workspace2.GetOptions{
+		DevPodConfig: devPodConfig,
+		Args:         args,
+		Owner:        cmd.Owner,
+		Log:          log.Default,
+	})
+	if err != nil {
+		return err
+	}
+
+	status, err := client.Status(ctx, client2.StatusOptions{})
+	if err != nil {
+		return fmt.Errorf("get workspace status: %w", err)
+	} else if status != client2.StatusRunning {
+		return fmt.Errorf(
+			"workspace %s is not running (status %s), use devpod up to start it",
+			client.Workspace(),
+			status,
+		)
+	}
+
+	workspaceConfig := client.WorkspaceConfig()
+	result, err := provider2.LoadWorkspaceResult(workspaceConfig.Context, workspaceConfig.ID)
+	if err != nil {
+		return fmt.Errorf("load workspace result: %w", err)
+	} else if result == nil {
+		return fmt.Errorf(
+			"workspace %s has no stored result, use devpod up to provision it",
+			client.Workspace(),
+		)
+	}
+
+	upCmd := &UpCmd{
+		GlobalFlags:        cmd.GlobalFlags,
+		OpenIDE:            true,
+		GPGAgentForwarding: cmd.GPGAgentForwarding,
+	}
+	err = upCmd.prepareWorkspace(client, log.Default)
+	if err != nil {
+		return err
+	}
+	return upCmd.openIDE(ctx, devPodConfig, client, newWorkspaceContext(client, result), log.Default)
+}
@@ -38,6 +38,7 @@ import {
 
 type TRawWorkspaces = readonly (Omit<TWorkspace, "status" | "id"> &
   Readonly<{ id: string | null }>)[]
+type TRawWorkspaceList = Readonly<{ version: number; workspaces: TRawWorkspaces | null }>
 
 export class WorkspaceCommands {
   static DEBUG = false
@@ -64,7 +65,7 @@ export class WorkspaceCommands {
       return result
     }
 
-    const rawWorkspaces = JSON.parse(result.val.stdout) as TRawWorkspaces
+    const rawWorkspaces = (JSON.parse(result.val.stdout) as TRawWorkspaceList).workspaces ?? []
 
     return Return.Value(
       rawWorkspaces.filter((workspace): workspace is TWorkspaceWithoutStatus =>
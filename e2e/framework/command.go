@@ -35,13 +35,15 @@ func (f *Framework) DevPodListParsed(ctx context.Context) ([]*provider2.Workspac
 		return nil, err
 	}
 
-	retList := []*provider2.Workspace{}
+	retList := struct {
+		Workspaces []*provider2.Workspace `json:"workspaces"`
+	}{}
 	err = json.Unmarshal([]byte(raw), &retList)
 	if err != nil {
 		return nil, err
 	}
 
-	return retList, nil
+	return retList.Workspaces, nil
 }
 
 // DevPodList executes the `devpod list` command in the test framework.
@@ -18,6 +18,7 @@ import (
 	"github.com/skevetter/devpod/pkg/compress"
 	"github.com/skevetter/devpod/pkg/config"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
+	sshServer "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/devpod/pkg/version"
 	"github.com/skevetter/log"
 )
@@ -398,11 +399,12 @@ func writeWorkspaceInfo(file string, workspaceInfo *provider2.AgentWorkspaceInfo
 	// copy workspace info
 	cloned := provider2.CloneAgentWorkspaceInfo(workspaceInfo)
 
-	// never save cli options
-	cloned.CLIOptions = provider2.CLIOptions{}
+	// never save the devcontainer env, it's only passed to the lifecycle hooks of the current up
+	// and commonly holds secrets
+	cloned.CLIOptions.DevContainerEnv = nil
 
 	// encode workspace info
-	encoded, err := json.Marshal(workspaceInfo)
+	encoded, err := json.Marshal(cloned)
 	if err != nil {
 		return err
 	}
@@ -483,6 +485,7 @@ func Tunnel(
 	stderr io.Writer,
 	log log.Logger,
 	timeout time.Duration,
+	algorithms sshServer.Algorithms,
 ) error {
 	err := InjectAgent(&InjectOptions{
 		Ctx: ctx,
@@ -501,7 +504,9 @@ func Tunnel(
 	}
 
 	// build command
-	command := fmt.Sprintf("'%s' helper ssh-server --stdio", ContainerDevPodHelperLocation)
+	command := algorithms.Command(
+		fmt.Sprintf("'%s' helper ssh-server --stdio", ContainerDevPodHelperLocation),
+	)
 	if log.GetLevel() == logrus.DebugLevel {
 		command += " --debug"
 	}
@@ -2,9 +2,11 @@ package agent
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/skevetter/devpod/pkg/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -43,6 +45,23 @@ func (s *AgentTestSuite) TestDefaultAgentDownloadURL_MultipleTrailingSlashes() {
 	s.Equal("https://example.com/releases/latest/download", result)
 }
 
+func (s *AgentTestSuite) TestWriteWorkspaceInfoDropsDevContainerEnv() {
+	file := filepath.Join(s.T().TempDir(), provider2.WorkspaceConfigFile)
+	workspaceInfo := &provider2.AgentWorkspaceInfo{
+		CLIOptions: provider2.CLIOptions{
+			WorkspaceEnv:    []string{"FOO=bar"},
+			DevContainerEnv: []string{"TOKEN=secret"},
+		},
+	}
+	s.Require().NoError(writeWorkspaceInfo(file, workspaceInfo))
+
+	written, err := ParseAgentWorkspaceInfo(file)
+	s.Require().NoError(err)
+	s.Equal([]string{"FOO=bar"}, written.CLIOptions.WorkspaceEnv)
+	s.Empty(written.CLIOptions.DevContainerEnv)
+	s.Equal([]string{"TOKEN=secret"}, workspaceInfo.CLIOptions.DevContainerEnv)
+}
+
 func TestAgentSuite(t *testing.T) {
 	suite.Run(t, new(AgentTestSuite))
 }
@@ -72,6 +72,8 @@ type InjectOptions struct {
 	// SkipVersionCheck disables the validation of the remote agent's version.
 	// Defaults to false, unless DEVPOD_AGENT_URL is set.
 	SkipVersionCheck bool
+	// ForceReinstall overwrites the remote agent binary even if it already exists in the expected version.
+	ForceReinstall bool
 }
 
 func (o *InjectOptions) ApplyDefaults() {
@@ -188,6 +190,10 @@ func InjectAgent(opts *InjectOptions) error {
 		Cap:      60 * time.Second,
 	}
 
+	if opts.ForceReinstall {
+		opts.Log.Infof("Reinstalling agent at %s", opts.RemoteAgentPath)
+	}
+
 	opts.Log.Debug("starting agent injection")
 	return retry.OnError(backoff, func(err error) bool {
 		if opts.Ctx.Err() != nil {
@@ -335,20 +341,25 @@ func (e *InjectError) Unwrap() error {
 }
 
 type versionChecker struct {
-	localVersion  string
-	remoteVersion string
-	skipCheck     bool
+	localVersion   string
+	remoteVersion  string
+	skipCheck      bool
+	forceReinstall bool
 }
 
 func newVersionChecker(opts *InjectOptions) *versionChecker {
 	return &versionChecker{
-		localVersion:  opts.LocalVersion,
-		remoteVersion: opts.RemoteVersion,
-		skipCheck:     opts.SkipVersionCheck,
+		localVersion:   opts.LocalVersion,
+		remoteVersion:  opts.RemoteVersion,
+		skipCheck:      opts.SkipVersionCheck,
+		forceReinstall: opts.ForceReinstall,
 	}
 }
 
 func (vc *versionChecker) buildExistsCheck(agentPath string) string {
+	if vc.forceReinstall {
+		return "true"
+	}
 	if vc.skipCheck {
 		return fmt.Sprintf(`! [ -x "%s" ]`, agentPath)
 	}
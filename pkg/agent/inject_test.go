@@ -73,6 +73,17 @@ func (s *InjectTestSuite) TestVersionChecker() {
 	})
 }
 
+func (s *InjectTestSuite) TestExistsCheck() {
+	vc := &versionChecker{remoteVersion: "v1.0.0"}
+	s.Contains(vc.buildExistsCheck("/path"), `"v1.0.0"`)
+
+	vc.skipCheck = true
+	s.Equal(`! [ -x "/path" ]`, vc.buildExistsCheck("/path"))
+
+	vc.forceReinstall = true
+	s.Equal("true", vc.buildExistsCheck("/path"), "Forced reinstall should always inject the binary")
+}
+
 // MockExecFunc is a helper for testing.
 type MockExecFunc struct {
 	CapturedCmd string
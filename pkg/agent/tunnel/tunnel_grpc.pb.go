@@ -24,6 +24,7 @@ const (
 	Tunnel_Ping_FullMethodName              = "/tunnel.Tunnel/Ping"
 	Tunnel_Log_FullMethodName               = "/tunnel.Tunnel/Log"
 	Tunnel_SendResult_FullMethodName        = "/tunnel.Tunnel/SendResult"
+	Tunnel_ExportTraces_FullMethodName      = "/tunnel.Tunnel/ExportTraces"
 	Tunnel_DockerCredentials_FullMethodName = "/tunnel.Tunnel/DockerCredentials"
 	Tunnel_GitCredentials_FullMethodName    = "/tunnel.Tunnel/GitCredentials"
 	Tunnel_GitSSHSignature_FullMethodName   = "/tunnel.Tunnel/GitSSHSignature"
@@ -44,6 +45,7 @@ type TunnelClient interface {
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	Log(ctx context.Context, in *LogMessage, opts ...grpc.CallOption) (*Empty, error)
 	SendResult(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Empty, error)
+	ExportTraces(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Empty, error)
 	DockerCredentials(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Message, error)
 	GitCredentials(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Message, error)
 	GitSSHSignature(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Message, error)
@@ -95,6 +97,16 @@ func (c *tunnelClient) SendResult(ctx context.Context, in *Message, opts ...grpc
 	return out, nil
 }
 
+func (c *tunnelClient) ExportTraces(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Tunnel_ExportTraces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tunnelClient) DockerCredentials(ctx context.Context, in *Message, opts ...grpc.CallOption) (*Message, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Message)
@@ -230,6 +242,7 @@ type TunnelServer interface {
 	Ping(context.Context, *Empty) (*Empty, error)
 	Log(context.Context, *LogMessage) (*Empty, error)
 	SendResult(context.Context, *Message) (*Empty, error)
+	ExportTraces(context.Context, *Message) (*Empty, error)
 	DockerCredentials(context.Context, *Message) (*Message, error)
 	GitCredentials(context.Context, *Message) (*Message, error)
 	GitSSHSignature(context.Context, *Message) (*Message, error)
@@ -260,6 +273,9 @@ func (UnimplementedTunnelServer) Log(context.Context, *LogMessage) (*Empty, erro
 func (UnimplementedTunnelServer) SendResult(context.Context, *Message) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendResult not implemented")
 }
+func (UnimplementedTunnelServer) ExportTraces(context.Context, *Message) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportTraces not implemented")
+}
 func (UnimplementedTunnelServer) DockerCredentials(context.Context, *Message) (*Message, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DockerCredentials not implemented")
 }
@@ -368,6 +384,24 @@ func _Tunnel_SendResult_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Tunnel_ExportTraces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Message)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TunnelServer).ExportTraces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tunnel_ExportTraces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TunnelServer).ExportTraces(ctx, req.(*Message))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Tunnel_DockerCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Message)
 	if err := dec(in); err != nil {
@@ -571,6 +605,10 @@ var Tunnel_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendResult",
 			Handler:    _Tunnel_SendResult_Handler,
 		},
+		{
+			MethodName: "ExportTraces",
+			Handler:    _Tunnel_ExportTraces_Handler,
+		},
 		{
 			MethodName: "DockerCredentials",
 			Handler:    _Tunnel_DockerCredentials_Handler,
@@ -26,6 +26,7 @@ import (
 	"github.com/skevetter/devpod/pkg/platform"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
 	"github.com/skevetter/devpod/pkg/stdio"
+	"github.com/skevetter/devpod/pkg/telemetry"
 	"github.com/skevetter/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -413,6 +414,18 @@ func (t *tunnelServer) SendResult(
 	return &tunnel.Empty{}, nil
 }
 
+func (t *tunnelServer) ExportTraces(
+	ctx context.Context,
+	message *tunnel.Message,
+) (*tunnel.Empty, error) {
+	err := telemetry.UploadTraces(ctx, message.Message)
+	if err != nil {
+		return nil, fmt.Errorf("export traces: %w", err)
+	}
+
+	return &tunnel.Empty{}, nil
+}
+
 func (t *tunnelServer) Ping(context.Context, *tunnel.Empty) (*tunnel.Empty, error) {
 	t.log.Debug("received ping from agent")
 	return &tunnel.Empty{}, nil
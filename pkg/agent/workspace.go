@@ -463,6 +463,9 @@ func getGitOptions(options provider2.CLIOptions) []git.Option {
 	if options.GitCloneRecursiveSubmodules {
 		gitOpts = append(gitOpts, git.WithRecursiveSubmodules())
 	}
+	if options.GitCloneDepth > 0 {
+		gitOpts = append(gitOpts, git.WithCloneDepth(options.GitCloneDepth))
+	}
 	return gitOpts
 }
 
@@ -0,0 +1,126 @@
+package clientimplementation
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
+)
+
+// maxCommandLogSize is the size after which the provider command log is rotated.
+const maxCommandLogSize = 10 * 1024 * 1024
+
+// streamingCommands carry the tunnel protocol on stdout, so only their stderr is logged.
+var streamingCommands = map[string]bool{
+	"command": true,
+	"ssh":     true,
+	"up":      true,
+}
+
+// commandLog appends the output of a single provider command to the provider command log.
+type commandLog struct {
+	m    sync.Mutex
+	file *os.File
+}
+
+func (l *commandLog) Write(p []byte) (int, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	return l.file.Write(p)
+}
+
+func (l *commandLog) finish(err error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if err != nil {
+		_, _ = fmt.Fprintf(l.file, "--- exited with error: %v\n", err)
+	} else {
+		_, _ = fmt.Fprintln(l.file, "--- exited successfully")
+	}
+	_ = l.file.Close()
+}
+
+// providerLogContexts caches the PROVIDER_LOG context option by context, so the config is
+// loaded at most once per context instead of for every provider command.
+var providerLogContexts sync.Map
+
+// providerLogEnabled checks the DEVPOD_PROVIDER_LOG env var and the PROVIDER_LOG context option.
+func providerLogEnabled(context string) bool {
+	if value, ok := os.LookupEnv(config.EnvProviderLog); ok {
+		return value == config.BoolTrue
+	}
+
+	if enabled, ok := providerLogContexts.Load(context); ok {
+		return enabled.(bool)
+	}
+
+	enabled := false
+	devPodConfig, err := config.LoadConfig(context, "")
+	if err == nil {
+		enabled = devPodConfig.ContextOption(config.ContextOptionProviderLog) == config.BoolTrue
+	}
+	providerLogContexts.Store(context, enabled)
+	return enabled
+}
+
+// openCommandLog returns the log for the given provider command or nil if provider logging is disabled.
+func openCommandLog(opts CommandOptions) *commandLog {
+	if opts.Config == nil || opts.Config.Name == "" || !providerLogEnabled(opts.Context) {
+		return nil
+	}
+
+	logFile, err := provider.GetProviderCommandLogFile(opts.Context, opts.Config.Name)
+	if err != nil {
+		return nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(logFile), 0o755)
+	if err != nil {
+		return nil
+	}
+
+	if stat, err := os.Stat(logFile); err == nil && stat.Size() > maxCommandLogSize {
+		_ = os.Rename(logFile, logFile+".1")
+	}
+
+	// #nosec G304 -- path is built from the provider directory
+	file, err := os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		if opts.Log != nil {
+			opts.Log.Debugf("open provider command log: %v", err)
+		}
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(
+		file,
+		"--- %s provider=%s command=%s\n",
+		time.Now().Format(time.RFC3339),
+		opts.Config.Name,
+		opts.Name,
+	)
+	return &commandLog{file: file}
+}
+
+// tee redirects stdout and stderr of the command through the log.
+func (l *commandLog) tee(opts *CommandOptions) {
+	opts.Stderr = teeWriter(opts.Stderr, l)
+	if !streamingCommands[opts.Name] {
+		opts.Stdout = teeWriter(opts.Stdout, l)
+	}
+}
+
+func teeWriter(w io.Writer, log io.Writer) io.Writer {
+	if w == nil {
+		return log
+	}
+
+	return io.MultiWriter(w, log)
+}
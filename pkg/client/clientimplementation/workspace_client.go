@@ -216,6 +216,7 @@ func (s *workspaceClient) agentInfo(cliOptions provider.CLIOptions) *provider.Ag
 	// try to load last devcontainer.json
 	var lastDevContainerConfig *config2.DevContainerConfigWithPath
 	var workspaceOrigin string
+	var networkPolicies []provider.NetworkPolicy
 	if s.workspace != nil {
 		result, err := provider.LoadWorkspaceResult(s.workspace.Context, s.workspace.ID)
 		if err != nil {
@@ -225,6 +226,14 @@ func (s *workspaceClient) agentInfo(cliOptions provider.CLIOptions) *provider.Ag
 		}
 
 		workspaceOrigin = s.workspace.Origin
+
+		networkPolicies, err = provider.LoadWorkspaceNetworkPolicies(
+			s.workspace.Context,
+			s.workspace.ID,
+		)
+		if err != nil {
+			s.log.Warnf("error loading network policies: %v", err)
+		}
 	}
 
 	// build struct
@@ -240,7 +249,8 @@ func (s *workspaceClient) agentInfo(cliOptions provider.CLIOptions) *provider.Ag
 			s.workspace,
 			s.machine,
 		),
-		Options: s.devPodConfig.ProviderOptions(s.Provider()),
+		Options:         s.devPodConfig.ProviderOptions(s.Provider()),
+		NetworkPolicies: networkPolicies,
 	}
 
 	// if we are running platform mode
@@ -763,7 +773,12 @@ func RunCommandWithBinaries(opts CommandOptions) error {
 		return err
 	}
 
-	return RunCommand(RunCommandOptions{
+	commandLog := openCommandLog(opts)
+	if commandLog != nil {
+		commandLog.tee(&opts)
+	}
+
+	err = RunCommand(RunCommandOptions{
 		Ctx:     opts.Ctx,
 		Command: opts.Command,
 		Environ: environ,
@@ -772,6 +787,11 @@ func RunCommandWithBinaries(opts CommandOptions) error {
 		Stderr:  opts.Stderr,
 		Log:     opts.Log,
 	})
+	if commandLog != nil {
+		commandLog.finish(err)
+	}
+
+	return err
 }
 
 type RunCommandOptions struct {
@@ -916,6 +936,35 @@ func StartWait(
 	}
 }
 
+// WaitForStatus polls the workspace status every interval until it matches the given status or
+// the context is done. onPoll is called after every poll that did not match.
+func WaitForStatus(
+	ctx context.Context,
+	workspaceClient client.BaseClient,
+	status client.Status,
+	interval time.Duration,
+	onPoll func(client.Status),
+) error {
+	for {
+		instanceStatus, err := workspaceClient.Status(ctx, client.StatusOptions{})
+		if err != nil {
+			return err
+		} else if instanceStatus == status {
+			return nil
+		}
+
+		if onPoll != nil {
+			onPoll(instanceStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 func handleBusyStatus(startWaiting *time.Time, log log.Logger) bool {
 	if time.Since(*startWaiting) > logThreshold {
 		log.Info("workspace is busy, waiting for workspace to become ready")
@@ -990,6 +1039,7 @@ func BuildAgentClient(ctx context.Context, opts BuildAgentClientOptions) (*confi
 		stdin:           stdinReader,
 		stdout:          stdoutWriter,
 		timeout:         wInfo.InjectTimeout,
+		forceReinstall:  opts.CLIOptions.AgentForceReinstall,
 		log:             opts.Log,
 		cancel:          cancel,
 	})
@@ -1039,6 +1089,7 @@ type agentInjectionOptions struct {
 	stdin           *os.File
 	stdout          *os.File
 	timeout         time.Duration
+	forceReinstall  bool
 	log             log.Logger
 	cancel          context.CancelFunc
 }
@@ -1071,6 +1122,7 @@ func runAgentInjection(opts agentInjectionOptions) chan error {
 			Stderr:          writer,
 			Log:             opts.log.ErrorStreamOnly(),
 			Timeout:         opts.timeout,
+			ForceReinstall:  opts.forceReinstall,
 		})
 	}()
 	return errChan
@@ -56,6 +56,18 @@ func StartBackgroundOnce(commandName string, createCommand CreateCommand) error
 	return startCommand(cmd, pidFile, streamsFile)
 }
 
+// ForgetBackground removes the PID file of the background process with the given
+// commandName, so the next StartBackgroundOnce starts a new process. It is meant to be called
+// by the background process itself right before it exits.
+func ForgetBackground(commandName string) error {
+	err := os.Remove(filepath.Join(os.TempDir(), commandName+".pid"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
 func startCommand(cmd *exec.Cmd, pidFile, streamsFile string) error {
 	streamsF, err := openStreamsFile(cmd, streamsFile)
 	if err != nil {
@@ -23,6 +23,18 @@ const (
 	ContextOptionAgentInjectTimeout         = "AGENT_INJECT_TIMEOUT"
 	ContextOptionRegistryCache              = "REGISTRY_CACHE"
 	ContextOptionSSHStrictHostKeyChecking   = "SSH_STRICT_HOST_KEY_CHECKING"
+	ContextOptionSSHControlPath             = "SSH_CONTROL_PATH"
+	ContextOptionSSHKnownHostsFile          = "SSH_KNOWN_HOSTS_FILE"
+	ContextOptionSSHDAllowedCiphers         = "SSHD_ALLOWED_CIPHERS"
+	ContextOptionSSHDAllowedKeyExchanges    = "SSHD_ALLOWED_KEY_EXCHANGES"
+	ContextOptionDaemonMinActiveProcesses   = "DAEMON_MIN_ACTIVE_PROCESSES"
+	ContextOptionCheckImageUpdates          = "CHECK_IMAGE_UPDATES"
+	ContextOptionProviderLog                = "PROVIDER_LOG"
+	ContextOptionImagePullPolicy            = "IMAGE_PULL_POLICY"
+	ContextOptionRegistryMirror             = "REGISTRY_MIRROR"
+	ContextOptionSSHCompress                = "SSH_COMPRESS"
+	ContextOptionSSHAcceptEnvPattern        = "SSH_ACCEPT_ENV_PATTERN"
+	ContextOptionProviderVerifyChecksum     = "PROVIDER_VERIFY_CHECKSUM"
 )
 
 var ContextOptions = []ContextOption{
@@ -110,6 +122,66 @@ var ContextOptions = []ContextOption{
 		Default:     "false",
 		Enum:        []string{"true", "false"},
 	},
+	{
+		Name:        ContextOptionSSHControlPath,
+		Description: "Specifies the ControlPath socket used by 'devpod ssh --multiplexed', %w is replaced with the workspace id. Defaults to ~/.devpod/sockets/<workspace>.sock",
+	},
+	{
+		Name:        ContextOptionSSHKnownHostsFile,
+		Description: "Specifies the known hosts file DevPod uses to verify workspace host keys. Defaults to $DEVPOD_KNOWN_HOSTS_FILE or ~/.devpod/known_hosts",
+	},
+	{
+		Name:        ContextOptionSSHDAllowedCiphers,
+		Description: "Specifies a comma separated list of ciphers the DevPod ssh server allows, e.g. aes256-gcm@openssh.com,aes128-gcm@openssh.com. Defaults to the golang crypto/ssh defaults",
+	},
+	{
+		Name:        ContextOptionSSHDAllowedKeyExchanges,
+		Description: "Specifies a comma separated list of key exchange algorithms the DevPod ssh server allows, e.g. ecdh-sha2-nistp256,ecdh-sha2-nistp384. Defaults to the golang crypto/ssh defaults",
+	},
+	{
+		Name:        ContextOptionDaemonMinActiveProcesses,
+		Description: "Specifies the number of active processes in the workspace container that keeps the machine from being stopped due to inactivity. 0 disables the check",
+		Default:     "0",
+	},
+	{
+		Name:        ContextOptionCheckImageUpdates,
+		Description: "Specifies if 'devpod up' should check whether the workspace image was updated upstream",
+		Default:     "false",
+		Enum:        []string{"true", "false"},
+	},
+	{
+		Name:        ContextOptionProviderLog,
+		Description: "Specifies if DevPod should write the output of provider commands to the provider command log",
+		Default:     "false",
+		Enum:        []string{"true", "false"},
+	},
+	{
+		Name:        ContextOptionImagePullPolicy,
+		Description: "Specifies when DevPod should pull the base image of the workspace. Can be always, if-not-present or never",
+		Default:     "if-not-present",
+		Enum:        []string{"always", "if-not-present", "never"},
+	},
+	{
+		Name:        ContextOptionRegistryMirror,
+		Description: "Specifies a registry mirror for the docker daemon of the workspace host and container",
+	},
+	{
+		Name:        ContextOptionSSHCompress,
+		Description: "Specifies if 'devpod ssh' should enable ssh compression. Helps on slow networks but hurts performance on fast local networks",
+		Default:     "false",
+		Enum:        []string{"true", "false"},
+	},
+	{
+		Name:        ContextOptionSSHAcceptEnvPattern,
+		Description: "Specifies a comma separated list of env variable patterns the DevPod ssh server in the workspace accepts from 'devpod ssh --send-env' and '--set-env', e.g. LANG,LC_*. Defaults to all variables",
+		Default:     "*",
+	},
+	{
+		Name:        ContextOptionProviderVerifyChecksum,
+		Description: "Specifies if provider binaries need a checksum in provider.yaml or via 'devpod provider add --verify-checksum'. Binaries without a checksum are rejected",
+		Default:     "false",
+		Enum:        []string{"true", "false"},
+	},
 }
 
 func MergeContextOptions(contextConfig *ContextConfig, environ []string) {
@@ -25,9 +25,15 @@ const (
 	// EnvDebug enables debug logging.
 	EnvDebug = "DEVPOD_DEBUG"
 
+	// EnvProviderLog enables writing provider command output to the provider command log.
+	EnvProviderLog = "DEVPOD_PROVIDER_LOG"
+
 	// EnvDisableTelemetry disables telemetry collection.
 	EnvDisableTelemetry = "DEVPOD_DISABLE_TELEMETRY"
 
+	// EnvKnownHostsFile overrides the known hosts file DevPod verifies workspace host keys against.
+	EnvKnownHostsFile = "DEVPOD_KNOWN_HOSTS_FILE"
+
 	// EnvAgentURL overrides the agent download URL.
 	EnvAgentURL = "DEVPOD_AGENT_URL"
 
@@ -49,6 +55,21 @@ const (
 	// EnvWorkspaceUID is the current workspace unique identifier.
 	EnvWorkspaceUID = "DEVPOD_WORKSPACE_UID"
 
+	// EnvVersion is set to the version of DevPod that created the workspace container.
+	EnvVersion = "DEVPOD_VERSION"
+
+	// EnvDefaultShell is the shell configured for workspace sessions via devpod up --shell.
+	EnvDefaultShell = "DEVPOD_DEFAULT_SHELL"
+
+	// EnvSSHHost is the ssh host of the workspace, set for devpod up --post-up-command.
+	EnvSSHHost = "DEVPOD_SSH_HOST"
+
+	// EnvWorkspaceFolder is the workspace folder in the container, set for devpod up --post-up-command.
+	EnvWorkspaceFolder = "DEVPOD_WORKSPACE_FOLDER"
+
+	// EnvRemoteUser is the remote user of the workspace, set for devpod up --post-up-command.
+	EnvRemoteUser = "DEVPOD_REMOTE_USER"
+
 	// EnvWorkspaceDaemonConfig holds the workspace daemon configuration.
 	EnvWorkspaceDaemonConfig = "DEVPOD_WORKSPACE_DAEMON_CONFIG"
 
@@ -36,6 +36,14 @@ func (m *mockTunnelClient) SendResult(
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (m *mockTunnelClient) ExportTraces(
+	ctx context.Context,
+	in *tunnel.Message,
+	opts ...grpc.CallOption,
+) (*tunnel.Empty, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (m *mockTunnelClient) DockerCredentials(
 	ctx context.Context,
 	in *tunnel.Message,
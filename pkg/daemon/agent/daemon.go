@@ -20,8 +20,10 @@ import (
 )
 
 type SshConfig struct {
-	Workdir string `json:"workdir,omitempty"`
-	User    string `json:"user,omitempty"`
+	Workdir      string `json:"workdir,omitempty"`
+	User         string `json:"user,omitempty"`
+	Ciphers      string `json:"ciphers,omitempty"`
+	KeyExchanges string `json:"keyExchanges,omitempty"`
 }
 
 type DaemonConfig struct {
@@ -31,7 +33,7 @@ type DaemonConfig struct {
 }
 
 func BuildWorkspaceDaemonConfig(
-	platformOptions devsy.PlatformOptions,
+	options provider2.CLIOptions,
 	workspaceConfig *provider2.Workspace,
 	substitutionContext *config.SubstitutionContext,
 	mergedConfig *config.MergedDevContainerConfig,
@@ -58,13 +60,16 @@ func BuildWorkspaceDaemonConfig(
 	}
 
 	// build info isn't required in the workspace and can be omitted
+	platformOptions := options.Platform
 	platformOptions.Build = nil
 
 	daemonConfig := &DaemonConfig{
 		Platform: platformOptions,
 		Ssh: SshConfig{
-			Workdir: workdir,
-			User:    user,
+			Workdir:      workdir,
+			User:         user,
+			Ciphers:      options.SSHDAllowedCiphers,
+			KeyExchanges: options.SSHDAllowedKeyExchanges,
 		},
 	}
 
@@ -72,13 +77,13 @@ func BuildWorkspaceDaemonConfig(
 }
 
 func GetEncodedWorkspaceDaemonConfig(
-	platformOptions devsy.PlatformOptions,
+	options provider2.CLIOptions,
 	workspaceConfig *provider2.Workspace,
 	substitutionContext *config.SubstitutionContext,
 	mergedConfig *config.MergedDevContainerConfig,
 ) (string, error) {
 	daemonConfig, err := BuildWorkspaceDaemonConfig(
-		platformOptions,
+		options,
 		workspaceConfig,
 		substitutionContext,
 		mergedConfig,
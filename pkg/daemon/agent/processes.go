@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/driver"
+)
+
+// activeProcessesCommand lists the state and command name of every process in the devcontainer.
+const activeProcessesCommand = "ps -e -o stat= -o comm="
+
+// CountActiveProcesses returns the number of non-idle processes running inside the devcontainer
+// of the given workspace. A stopped or missing container has no active processes.
+func CountActiveProcesses(
+	ctx context.Context,
+	workspaceDriver driver.Driver,
+	workspaceID string,
+) (int, error) {
+	containerDetails, err := workspaceDriver.FindDevContainer(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("find dev container: %w", err)
+	} else if containerDetails == nil ||
+		!strings.EqualFold(containerDetails.State.Status, "running") {
+		return 0, nil
+	}
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err = workspaceDriver.CommandDevContainer(
+		ctx,
+		workspaceID,
+		"root",
+		activeProcessesCommand,
+		nil,
+		stdout,
+		stderr,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("list processes: %s: %w", stderr.String(), err)
+	}
+
+	return parseActiveProcesses(stdout.String()), nil
+}
+
+// parseActiveProcesses counts processes that are running (R) or in uninterruptible
+// sleep (D). The ps process itself is not counted.
+func parseActiveProcesses(out string) int {
+	count := 0
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[1] == "ps" {
+			continue
+		}
+
+		switch fields[0][0] {
+		case 'R', 'D':
+			count++
+		}
+	}
+
+	return count
+}
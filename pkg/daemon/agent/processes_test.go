@@ -0,0 +1,20 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseActiveProcesses(t *testing.T) {
+	out := `Ss   sh
+S    sleep
+R+   go
+Rl   compile
+D    cp
+Z    defunct
+R    ps
+`
+	assert.Equal(t, 3, parseActiveProcesses(out))
+	assert.Equal(t, 0, parseActiveProcesses(""))
+}
@@ -0,0 +1,120 @@
+package host
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/command"
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/log"
+)
+
+// DaemonProcessName is the name of the background process that runs the schedules.
+const DaemonProcessName = config.BinaryName + ".schedules"
+
+// RunFunc runs the action of a due schedule.
+type RunFunc func(ctx context.Context, schedule Schedule) error
+
+// StartDaemon starts the host daemon in the background unless it is already running.
+func StartDaemon() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	err = command.StartBackgroundOnce(DaemonProcessName, func() (*exec.Cmd, error) {
+		//nolint:gosec // executable is from os.Executable()
+		return exec.Command(executable, "helper", "schedule-daemon"), nil
+	})
+	if err != nil {
+		return fmt.Errorf("start schedule daemon: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeDaemon starts the host daemon if schedules are pending but it is not running, e.g.
+// because the host was rebooted.
+func ResumeDaemon() error {
+	schedules, err := LoadSchedules()
+	if err != nil {
+		return err
+	} else if len(schedules) == 0 {
+		return nil
+	}
+
+	return StartDaemon()
+}
+
+// RunDaemon runs the due schedules on every tick and returns once no schedules are pending.
+func RunDaemon(ctx context.Context, interval time.Duration, run RunFunc, log log.Logger) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := runDueSchedules(ctx, run, log)
+		if err != nil {
+			return err
+		}
+
+		idle, err := stopIfIdle()
+		if err != nil {
+			return err
+		} else if idle {
+			log.Debugf("no pending schedules, stopping")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopIfIdle forgets the daemon process if no schedules are pending. It holds the schedules
+// lock while doing so, a schedule that is added concurrently either is seen here and keeps
+// the daemon running, or is added afterwards and starts a new daemon.
+func stopIfIdle() (bool, error) {
+	idle := false
+	err := UpdateSchedules(func(schedules []Schedule) ([]Schedule, error) {
+		if len(schedules) > 0 {
+			return schedules, nil
+		}
+
+		idle = true
+		return schedules, command.ForgetBackground(DaemonProcessName)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return idle, nil
+}
+
+// runDueSchedules runs the due schedules.
+func runDueSchedules(ctx context.Context, run RunFunc, log log.Logger) error {
+	due, err := TakeDueSchedules(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, schedule := range due {
+		log.Infof("running scheduled %s of workspace %s", schedule.Action, schedule.Workspace)
+		err = run(ctx, schedule)
+		if err != nil {
+			log.Errorf(
+				"error running scheduled %s of workspace %s: %v",
+				schedule.Action,
+				schedule.Workspace,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
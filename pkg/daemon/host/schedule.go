@@ -0,0 +1,189 @@
+package host
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/skevetter/devpod/pkg/config"
+)
+
+const (
+	// SchedulesFile holds the pending scheduled actions below the DevPod config dir.
+	SchedulesFile = "schedules.json"
+
+	// ActionStop stops the workspace.
+	ActionStop = "stop"
+)
+
+// Schedule is a one-shot action that the host daemon runs for a workspace.
+type Schedule struct {
+	// Workspace is the id of the workspace
+	Workspace string `json:"workspace"`
+
+	// Context is the DevPod context of the workspace
+	Context string `json:"context"`
+
+	// Action is the action to run, currently only stop
+	Action string `json:"action"`
+
+	// At is the time the action runs at
+	At time.Time `json:"at"`
+}
+
+// GetSchedulesFile returns the path of the schedules file.
+func GetSchedulesFile() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, SchedulesFile), nil
+}
+
+// LoadSchedules returns all pending schedules.
+func LoadSchedules() ([]Schedule, error) {
+	schedulesFile, err := GetSchedulesFile()
+	if err != nil {
+		return nil, err
+	}
+
+	return loadSchedules(schedulesFile)
+}
+
+// UpdateSchedules replaces the pending schedules with the result of update while holding the
+// schedules lock.
+func UpdateSchedules(update func(schedules []Schedule) ([]Schedule, error)) error {
+	schedulesFile, err := GetSchedulesFile()
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(filepath.Dir(schedulesFile), 0o755)
+	if err != nil {
+		return err
+	}
+
+	fileLock := flock.New(schedulesFile + ".lock")
+	err = fileLock.Lock()
+	if err != nil {
+		return fmt.Errorf("lock schedules: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	schedules, err := loadSchedules(schedulesFile)
+	if err != nil {
+		return err
+	}
+
+	schedules, err = update(schedules)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write atomically as the schedules are read without holding the lock
+	tmpFile := schedulesFile + ".tmp"
+	err = os.WriteFile(tmpFile, out, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, schedulesFile)
+}
+
+// TakeDueSchedules removes the schedules that are due at now and returns them.
+func TakeDueSchedules(now time.Time) ([]Schedule, error) {
+	due := []Schedule{}
+	err := UpdateSchedules(func(schedules []Schedule) ([]Schedule, error) {
+		return slices.DeleteFunc(schedules, func(schedule Schedule) bool {
+			if schedule.At.After(now) {
+				return false
+			}
+
+			due = append(due, schedule)
+			return true
+		}), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
+
+// ParseScheduleTime parses an absolute time like "2025-01-20T18:00:00Z", a time of day like
+// "18:00" or "18:00 UTC" that refers to its next occurrence, or a relative time like "in 2h".
+func ParseScheduleTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if duration, ok := strings.CutPrefix(value, "in "); ok {
+		parsed, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse relative time %s: %w", value, err)
+		} else if parsed <= 0 {
+			return time.Time{}, fmt.Errorf("relative time %s needs to be in the future", value)
+		}
+
+		return now.Add(parsed), nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	clock, zone, _ := strings.Cut(value, " ")
+	location := now.Location()
+	if zone != "" {
+		var err error
+		location, err = time.LoadLocation(zone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse time zone %s: %w", zone, err)
+		}
+	}
+
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"parse time %s, expected e.g. \"18:00 UTC\", \"2025-01-20T18:00:00Z\" or \"in 2h\"",
+			value,
+		)
+	}
+
+	localNow := now.In(location)
+	at := time.Date(
+		localNow.Year(), localNow.Month(), localNow.Day(),
+		parsed.Hour(), parsed.Minute(), 0, 0, location,
+	)
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+
+	return at, nil
+}
+
+func loadSchedules(schedulesFile string) ([]Schedule, error) {
+	out, err := os.ReadFile(schedulesFile) // #nosec G304: not user input
+	if os.IsNotExist(err) {
+		return []Schedule{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	schedules := []Schedule{}
+	err = json.Unmarshal(out, &schedules)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", schedulesFile, err)
+	}
+
+	return schedules, nil
+}
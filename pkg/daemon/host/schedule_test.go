@@ -0,0 +1,86 @@
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	now := time.Date(2025, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	at, err := ParseScheduleTime("in 2h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Hour), at)
+
+	at, err = ParseScheduleTime("2025-01-20T18:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 20, 18, 0, 0, 0, time.UTC), at)
+
+	at, err = ParseScheduleTime("18:00 UTC", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 20, 18, 0, 0, 0, time.UTC), at)
+
+	// a time of day that already passed refers to the next day
+	at, err = ParseScheduleTime("09:30 UTC", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 21, 9, 30, 0, 0, time.UTC), at)
+
+	for _, value := range []string{"in -1h", "tomorrow", "18:00 Nowhere/Invalid"} {
+		_, err = ParseScheduleTime(value, now)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTakeDueSchedules(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	now := time.Now()
+	err := UpdateSchedules(func(schedules []Schedule) ([]Schedule, error) {
+		return append(schedules,
+			Schedule{Workspace: "due", Context: "default", Action: ActionStop, At: now.Add(-time.Minute)},
+			Schedule{Workspace: "later", Context: "default", Action: ActionStop, At: now.Add(time.Hour)},
+		), nil
+	})
+	require.NoError(t, err)
+
+	due, err := TakeDueSchedules(now)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, "due", due[0].Workspace)
+
+	schedules, err := LoadSchedules()
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "later", schedules[0].Workspace)
+}
+
+func TestStopIfIdle(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+	t.Setenv("TMPDIR", t.TempDir())
+	pidFile := filepath.Join(os.TempDir(), DaemonProcessName+".pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte("1"), 0o600))
+
+	err := UpdateSchedules(func(schedules []Schedule) ([]Schedule, error) {
+		return append(schedules, Schedule{Workspace: "later", At: time.Now().Add(time.Hour)}), nil
+	})
+	require.NoError(t, err)
+
+	idle, err := stopIfIdle()
+	require.NoError(t, err)
+	assert.False(t, idle)
+	assert.FileExists(t, pidFile)
+
+	_, err = TakeDueSchedules(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+
+	idle, err = stopIfIdle()
+	require.NoError(t, err)
+	assert.True(t, idle)
+	assert.NoFileExists(t, pidFile, "a new schedule starts a new daemon")
+}
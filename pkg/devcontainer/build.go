@@ -19,6 +19,7 @@ import (
 	"github.com/skevetter/devpod/pkg/driver"
 	"github.com/skevetter/devpod/pkg/image"
 	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/telemetry"
 )
 
 func (r *runner) build(
@@ -26,9 +27,9 @@ func (r *runner) build(
 	parsedConfig *config.SubstitutedConfig,
 	substitutionContext *config.SubstitutionContext,
 	options provider.BuildOptions,
-) (*config.BuildInfo, error) {
-	var buildInfo *config.BuildInfo
-	var err error
+) (buildInfo *config.BuildInfo, err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SpanBuildImage)
+	defer func() { telemetry.EndSpan(span, err) }()
 
 	if isDockerFileConfig(parsedConfig.Config) {
 		buildInfo, err = r.buildAndExtendImage(ctx, parsedConfig, substitutionContext, options)
@@ -57,6 +58,29 @@ func (r *runner) build(
 	return buildInfo, nil
 }
 
+// getExtendedBuildInfo fetches the features of the dev container and prepares their
+// installation into the image.
+func (r *runner) getExtendedBuildInfo(
+	ctx context.Context,
+	substitutionContext *config.SubstitutionContext,
+	imageBuildInfo *config.ImageBuildInfo,
+	target string,
+	parsedConfig *config.SubstitutedConfig,
+	forceBuild bool,
+) (*feature.ExtendedBuildInfo, error) {
+	_, span := telemetry.StartSpan(ctx, telemetry.SpanInstallFeatures)
+	extendedBuildInfo, err := feature.GetExtendedBuildInfo(
+		substitutionContext,
+		imageBuildInfo,
+		target,
+		parsedConfig,
+		r.Log,
+		forceBuild,
+	)
+	telemetry.EndSpan(span, err)
+	return extendedBuildInfo, err
+}
+
 func (r *runner) extendImage(
 	ctx context.Context,
 	parsedConfig *config.SubstitutedConfig,
@@ -70,12 +94,12 @@ func (r *runner) extendImage(
 	}
 
 	// get extend image build info
-	extendedBuildInfo, err := feature.GetExtendedBuildInfo(
+	extendedBuildInfo, err := r.getExtendedBuildInfo(
+		ctx,
 		substitutionContext,
 		imageBuildInfo,
 		imageBase,
 		parsedConfig,
-		r.Log,
 		options.ForceBuild,
 	)
 	if err != nil {
@@ -88,13 +112,14 @@ func (r *runner) extendImage(
 			ImageDetails:  imageBuildInfo.ImageDetails,
 			ImageMetadata: extendedBuildInfo.MetadataConfig,
 			ImageName:     imageBase,
+			BaseImageID:   imageBuildInfo.ImageDetails.ID,
 			RegistryCache: options.RegistryCache,
 			Tags:          options.Tag,
 		}, nil
 	}
 
 	// build the image
-	return r.buildImage(
+	buildInfo, err := r.buildImage(
 		ctx,
 		parsedConfig,
 		substitutionContext,
@@ -104,6 +129,12 @@ func (r *runner) extendImage(
 		"",
 		options,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	buildInfo.BaseImageID = imageBuildInfo.ImageDetails.ID
+	return buildInfo, nil
 }
 
 func (r *runner) buildAndExtendImage(
@@ -152,12 +183,12 @@ func (r *runner) buildAndExtendImage(
 	}
 
 	// get extend image build info
-	extendedBuildInfo, err := feature.GetExtendedBuildInfo(
+	extendedBuildInfo, err := r.getExtendedBuildInfo(
+		ctx,
 		substitutionContext,
 		imageBuildInfo,
 		imageBase,
 		parsedConfig,
-		r.Log,
 		options.ForceBuild,
 	)
 	if err != nil {
@@ -366,6 +397,13 @@ func (r *runner) buildImage(
 		}
 	}
 
+	if remoteContext := config.GetRemoteBuildContext(parsedConfig.Config); remoteContext != "" {
+		err = validateRemoteBuildContext(ctx, remoteContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if options.CLIOptions.Platform.Enabled {
 		buildInfo, err := buildkit.BuildRemote(ctx, buildkit.BuildRemoteOptions{
 			PrebuildHash:         prebuildHash,
@@ -393,6 +431,8 @@ func (r *runner) buildImage(
 			return nil, fmt.Errorf(
 				"cannot build devcontainer because driver is non-docker and dockerless fallback is disabled",
 			)
+		} else if config.GetRemoteBuildContext(parsedConfig.Config) != "" {
+			return nil, fmt.Errorf("dockerless builds don't support a remote build context")
 		}
 
 		return dockerlessFallback(
@@ -1,6 +1,7 @@
 package build
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"os"
@@ -52,6 +53,8 @@ type BuildOptions struct {
 	Push bool
 	// Upload controls whether to upload the build context. Used for remote builds.
 	Upload bool
+	// RegistryMirror is a mirror for docker hub that base images are pulled through.
+	RegistryMirror string
 }
 
 // NewOptionsParams contains the parameters needed to create BuildOptions.
@@ -92,7 +95,8 @@ func NewOptions(params NewOptionsParams) (*BuildOptions, error) {
 		// Push controls whether BuildKit pushes directly to the registry during build.
 		// When true, BuildKit uses the --push flag instead of --load, streaming the image
 		// directly to the registry. This is mutually exclusive with Load.
-		Push: params.Options.PushDuringBuild,
+		Push:           params.Options.PushDuringBuild,
+		RegistryMirror: params.Options.RegistryMirror,
 	}
 
 	// get build args and target
@@ -115,6 +119,16 @@ func NewOptions(params NewOptionsParams) (*BuildOptions, error) {
 		buildOptions.Dockerfile = params.DockerfilePath
 	}
 
+	// add cache mounts
+	buildOptions.Dockerfile, err = addCacheMounts(
+		buildOptions.Dockerfile,
+		params.DockerfilePath,
+		params.ParsedConfig.Config,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// add label
 	if params.ExtendedBuildInfo != nil && params.ExtendedBuildInfo.MetadataLabel != "" {
 		buildOptions.Labels[metadata.ImageMetadataLabel] = params.ExtendedBuildInfo.MetadataLabel
@@ -134,6 +148,12 @@ func NewOptions(params NewOptionsParams) (*BuildOptions, error) {
 		buildOptions.Images = append(buildOptions.Images, prebuildRepository+":"+prebuildHash)
 	}
 	buildOptions.Context = config.GetContextPath(params.ParsedConfig.Config)
+	if remoteContext := config.GetRemoteBuildContext(params.ParsedConfig.Config); remoteContext != "" {
+		err = useRemoteBuildContext(buildOptions, remoteContext, params.ExtendedBuildInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// add build arg
 	if buildOptions.BuildArgs == nil {
@@ -157,10 +177,112 @@ func NewOptions(params NewOptionsParams) (*BuildOptions, error) {
 	} else {
 		buildOptions.BuildArgs["BUILDKIT_INLINE_CACHE"] = "1"
 	}
+	buildOptions.CacheFrom = append(buildOptions.CacheFrom, params.ParsedConfig.Config.GetCacheFrom()...)
 
 	return buildOptions, nil
 }
 
+// featuresBuildContext is the named build context the features folder is passed as for remote build contexts.
+const featuresBuildContext = "devpod_features"
+
+// useRemoteBuildContext builds from the remote context and passes the local features folder
+// as a named build context, as it can't be copied into the remote one.
+func useRemoteBuildContext(
+	buildOptions *BuildOptions,
+	remoteContext string,
+	extendedBuildInfo *feature.ExtendedBuildInfo,
+) error {
+	buildOptions.Context = remoteContext
+	if extendedBuildInfo == nil || extendedBuildInfo.FeaturesBuildInfo == nil {
+		return nil
+	}
+
+	dockerfileContent, err := os.ReadFile(buildOptions.Dockerfile)
+	if err != nil {
+		return fmt.Errorf("read Dockerfile: %w", err)
+	}
+
+	rewritten := strings.ReplaceAll(
+		string(dockerfileContent),
+		"COPY ./"+config.DevPodContextFeatureFolder+"/ ",
+		"COPY --from="+featuresBuildContext+" / ",
+	)
+	err = os.WriteFile(buildOptions.Dockerfile, []byte(rewritten), 0o600)
+	if err != nil {
+		return fmt.Errorf("write Dockerfile: %w", err)
+	}
+
+	buildOptions.Contexts[featuresBuildContext] = extendedBuildInfo.FeaturesBuildInfo.FeaturesFolder
+	return nil
+}
+
+// addCacheMounts writes a copy of the Dockerfile with the configured build.cacheMount
+// entries added to every RUN instruction and returns its path.
+func addCacheMounts(
+	dockerfilePath, originalDockerfilePath string,
+	devContainerConfig *config.DevContainerConfig,
+) (string, error) {
+	cacheMounts := devContainerConfig.GetCacheMount()
+	if len(cacheMounts) == 0 {
+		return dockerfilePath, nil
+	}
+
+	dockerfileContent, err := os.ReadFile(dockerfilePath)
+	if err != nil {
+		return "", fmt.Errorf("read Dockerfile: %w", err)
+	}
+
+	devPodInternalFolder := filepath.Join(
+		config.GetContextPath(devContainerConfig),
+		config.DevPodContextFeatureFolder,
+	)
+	return WriteDockerfileWithCacheMounts(
+		string(dockerfileContent),
+		originalDockerfilePath,
+		devPodInternalFolder,
+		cacheMounts,
+	)
+}
+
+// WriteDockerfileWithCacheMounts writes the Dockerfile content with the cache mounts added to
+// every RUN instruction into folder and returns its path. The Dockerfile specific .dockerignore
+// of the original Dockerfile is copied along, so it still applies.
+func WriteDockerfileWithCacheMounts(
+	dockerfileContent, originalDockerfilePath, folder string,
+	cacheMounts []string,
+) (string, error) {
+	finalDockerfileContent, err := dockerfile.AddCacheMounts(dockerfileContent, cacheMounts)
+	if err != nil {
+		return "", fmt.Errorf("add cache mounts: %w", err)
+	}
+
+	// #nosec G301 -- same permissions as the features folder
+	err = os.MkdirAll(folder, 0o755)
+	if err != nil {
+		return "", fmt.Errorf("create devpod folder: %w", err)
+	}
+
+	finalDockerfilePath := filepath.Join(folder, "Dockerfile-with-cache-mounts")
+	err = os.WriteFile(finalDockerfilePath, []byte(finalDockerfileContent), 0o600)
+	if err != nil {
+		return "", fmt.Errorf("write Dockerfile with cache mounts: %w", err)
+	}
+
+	dockerignore, err := os.ReadFile(originalDockerfilePath + ".dockerignore")
+	if errors.Is(err, os.ErrNotExist) {
+		return finalDockerfilePath, nil
+	} else if err != nil {
+		return "", fmt.Errorf("read Dockerfile .dockerignore: %w", err)
+	}
+
+	err = os.WriteFile(finalDockerfilePath+".dockerignore", dockerignore, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("write Dockerfile .dockerignore: %w", err)
+	}
+
+	return finalDockerfilePath, nil
+}
+
 func GetBuildArgsAndTarget(
 	parsedConfig *config.SubstitutedConfig,
 	extendedBuildInfo *feature.ExtendedBuildInfo,
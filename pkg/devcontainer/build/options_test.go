@@ -0,0 +1,35 @@
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteDockerfileWithCacheMounts(t *testing.T) {
+	dir := t.TempDir()
+	dockerfilePath := filepath.Join(dir, "Dockerfile.dev")
+	require.NoError(t, os.WriteFile(dockerfilePath+".dockerignore", []byte("node_modules"), 0o600))
+
+	out, err := WriteDockerfileWithCacheMounts(
+		"FROM alpine\nRUN apk add git",
+		dockerfilePath,
+		filepath.Join(dir, "out"),
+		[]string{"/var/cache/apk"},
+	)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"FROM alpine\nRUN --mount=type=cache,target=/var/cache/apk apk add git",
+		string(content),
+	)
+	dockerignore, err := os.ReadFile(out + ".dockerignore")
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules", string(dockerignore))
+}
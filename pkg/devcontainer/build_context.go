@@ -0,0 +1,56 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// validateRemoteBuildContext checks that an http build context is reachable before handing it
+// to the build. Git contexts are fetched by the builder and not checked here.
+func validateRemoteBuildContext(ctx context.Context, buildContext string) error {
+	url := buildContext
+	if strings.HasPrefix(url, "github.com/") {
+		url = "https://" + url
+	} else if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil
+	}
+
+	// strip the git ref and subdirectory, e.g. https://github.com/org/repo.git#main:dir
+	url, _, _ = strings.Cut(url, "#")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	status, err := requestStatus(ctx, client, http.MethodHead, url)
+	if err == nil && status == http.StatusMethodNotAllowed {
+		status, err = requestStatus(ctx, client, http.MethodGet, url)
+	}
+	if err != nil {
+		return fmt.Errorf("build context %s is not reachable: %w", buildContext, err)
+	} else if status >= http.StatusBadRequest {
+		return fmt.Errorf(
+			"build context %s is not reachable: server returned %d %s",
+			buildContext,
+			status,
+			http.StatusText(status),
+		)
+	}
+
+	return nil
+}
+
+func requestStatus(ctx context.Context, client *http.Client, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
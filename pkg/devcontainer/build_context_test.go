@@ -0,0 +1,37 @@
+package devcontainer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRemoteBuildContext(t *testing.T) {
+	assert.True(t, config.IsRemoteBuildContext("https://example.com/context.tar.gz"))
+	assert.True(t, config.IsRemoteBuildContext("git://example.com/repo.git"))
+	assert.True(t, config.IsRemoteBuildContext("github.com/org/repo#main"))
+	assert.False(t, config.IsRemoteBuildContext(".."))
+	assert.False(t, config.IsRemoteBuildContext("/home/user/github.com/repo"))
+}
+
+func TestValidateRemoteBuildContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	require.NoError(t, validateRemoteBuildContext(context.Background(), server.URL+"/context#main:dir"))
+
+	err := validateRemoteBuildContext(context.Background(), server.URL+"/missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+
+	require.NoError(t, validateRemoteBuildContext(context.Background(), "git://example.invalid/repo.git"))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/moby/buildkit/session"
 	"github.com/moby/buildkit/session/auth/authprovider"
 	"github.com/skevetter/devpod/pkg/devcontainer/build"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/docker"
 	"github.com/skevetter/log"
 )
@@ -52,15 +53,13 @@ func Build(
 
 	// create solve options
 	solveOptions := buildkit.SolveOpt{
-		Frontend: "dockerfile.v0",
-		FrontendAttrs: map[string]string{
-			"filename": filepath.Base(options.Dockerfile),
-			"context":  options.Context,
-		},
-		Session:      attachable,
-		CacheImports: cacheFrom,
-		CacheExports: cacheTo,
+		Frontend:      "dockerfile.v0",
+		FrontendAttrs: map[string]string{},
+		Session:       attachable,
+		CacheImports:  cacheFrom,
+		CacheExports:  cacheTo,
 	}
+	setBuildContext(&solveOptions, options)
 
 	// set options target
 	if options.Target != "" {
@@ -72,11 +71,6 @@ func Build(
 		solveOptions.FrontendAttrs["platform"] = platform
 	}
 
-	// add context and dockerfile to local dirs
-	solveOptions.LocalDirs = map[string]string{}
-	solveOptions.LocalDirs["context"] = options.Context
-	solveOptions.LocalDirs["dockerfile"] = filepath.Dir(options.Dockerfile)
-
 	// multi contexts
 	for k, v := range options.Contexts {
 		st, err := os.Stat(v)
@@ -139,3 +133,20 @@ func Build(
 
 	return nil
 }
+
+// setBuildContext adds the build context and the Dockerfile to the solve options. A remote
+// context is passed to the frontend as is, which fetches it itself, while the Dockerfile is
+// always read from the local dockerfile dir.
+func setBuildContext(solveOptions *buildkit.SolveOpt, options *build.BuildOptions) {
+	solveOptions.FrontendAttrs["filename"] = filepath.Base(options.Dockerfile)
+	solveOptions.FrontendAttrs["context"] = options.Context
+	solveOptions.LocalDirs = map[string]string{
+		"dockerfile": filepath.Dir(options.Dockerfile),
+	}
+	if config.IsRemoteBuildContext(options.Context) {
+		solveOptions.FrontendAttrs["dockerfilekey"] = "dockerfile"
+		return
+	}
+
+	solveOptions.LocalDirs["context"] = options.Context
+}
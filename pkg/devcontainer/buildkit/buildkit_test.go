@@ -0,0 +1,56 @@
+package buildkit
+
+import (
+	"testing"
+
+	buildkit "github.com/moby/buildkit/client"
+	"github.com/skevetter/devpod/pkg/devcontainer/build"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBuildContext(t *testing.T) {
+	tests := []struct {
+		name          string
+		context       string
+		wantLocalDirs map[string]string
+		wantAttrs     map[string]string
+	}{
+		{
+			name:    "local context",
+			context: "/workspace",
+			wantLocalDirs: map[string]string{
+				"context":    "/workspace",
+				"dockerfile": "/workspace/.devcontainer",
+			},
+			wantAttrs: map[string]string{
+				"filename": "Dockerfile",
+				"context":  "/workspace",
+			},
+		},
+		{
+			name:    "git context",
+			context: "https://github.com/org/repo.git#main:dir",
+			wantLocalDirs: map[string]string{
+				"dockerfile": "/workspace/.devcontainer",
+			},
+			wantAttrs: map[string]string{
+				"filename":      "Dockerfile",
+				"context":       "https://github.com/org/repo.git#main:dir",
+				"dockerfilekey": "dockerfile",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			solveOptions := buildkit.SolveOpt{FrontendAttrs: map[string]string{}}
+			setBuildContext(&solveOptions, &build.BuildOptions{
+				Dockerfile: "/workspace/.devcontainer/Dockerfile",
+				Context:    tt.context,
+			})
+
+			assert.Equal(t, tt.wantLocalDirs, solveOptions.LocalDirs)
+			assert.Equal(t, tt.wantAttrs, solveOptions.FrontendAttrs)
+		})
+	}
+}
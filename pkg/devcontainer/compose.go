@@ -3,6 +3,7 @@ package devcontainer
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
@@ -15,11 +16,14 @@ import (
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
 	"github.com/skevetter/devpod/pkg/compose"
+	pkgconfig "github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer/build"
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/feature"
 	"github.com/skevetter/devpod/pkg/devcontainer/metadata"
 	"github.com/skevetter/devpod/pkg/dockerfile"
 	"github.com/skevetter/devpod/pkg/driver"
+	"github.com/skevetter/devpod/pkg/version"
 	"gopkg.in/yaml.v2"
 )
 
@@ -182,6 +186,8 @@ func (r *runner) runDockerCompose(
 		return nil, fmt.Errorf("find dev container: %w", err)
 	}
 
+	wasRunning := containerDetails != nil && containerDetails.State.Status == "running" && !options.Recreate
+
 	// does the container already exist or is it not running?
 	if containerDetails == nil || containerDetails.State.Status != "running" || options.Recreate {
 		didStartProject := false
@@ -305,6 +311,7 @@ func (r *runner) runDockerCompose(
 		mergedConfig:        mergedConfig,
 		substitutionContext: substitutionContext,
 		timeout:             timeout,
+		wasRunning:          wasRunning,
 	})
 }
 
@@ -491,6 +498,15 @@ func (r *runner) startContainer(
 			metadata.ImageMetadataLabel: extendResult.metadataLabel,
 			config.UserLabel:            imageDetails.Config.User,
 		}
+		if tags := r.workspaceTags(); tags != "" {
+			additionalLabels[config.TagsLabel] = tags
+		}
+
+		err = composeHelper.Docker.EnsureNetwork(ctx, options.Network, options.CreateNetwork)
+		if err != nil {
+			return nil, err
+		}
+
 		overrideComposeUpFilePath, err := r.extendedDockerComposeUp(
 			parsedConfig,
 			mergedConfig,
@@ -649,12 +665,12 @@ func (r *runner) buildAndExtendDockerCompose(
 	dockerfileContents = buildInfo.dockerfileContents
 	buildTarget = buildInfo.buildTarget
 
-	extendImageBuildInfo, err := feature.GetExtendedBuildInfo(
+	extendImageBuildInfo, err := r.getExtendedBuildInfo(
+		ctx,
 		substitutionContext,
 		imageBuildInfo,
 		buildTarget,
 		parsedConfig,
-		r.Log,
 		false,
 	)
 	if err != nil {
@@ -696,6 +712,13 @@ func (r *runner) buildAndExtendDockerCompose(
 			dockerFilePath,
 			dockerfileContents,
 		)
+		extendedDockerfileContent, err = dockerfile.AddCacheMounts(
+			extendedDockerfileContent,
+			parsedConfig.Config.GetCacheMount(),
+		)
+		if err != nil {
+			return composeExtendResult{}, fmt.Errorf("add cache mounts: %w", err)
+		}
 
 		r.Log.Debugf(
 			"Creating extended Dockerfile %s with content: \n %s",
@@ -712,6 +735,16 @@ func (r *runner) buildAndExtendDockerCompose(
 			extendedDockerfilePath,
 			extendedDockerfileContent,
 			extendImageBuildInfo.FeaturesBuildInfo,
+			parsedConfig.Config.GetCacheFrom(),
+		)
+		if err != nil {
+			return composeExtendResult{buildImageName: buildImageName}, err
+		}
+	} else if composeService.Build != nil && (len(parsedConfig.Config.GetCacheFrom()) > 0 ||
+		len(parsedConfig.Config.GetCacheMount()) > 0) {
+		dockerComposeFilePath, err = r.writeComposeCacheOverride(
+			composeService,
+			parsedConfig.Config,
 		)
 		if err != nil {
 			return composeExtendResult{buildImageName: buildImageName}, err
@@ -847,6 +880,7 @@ func (r *runner) extendedDockerComposeBuild(
 	dockerFilePath string,
 	dockerfileContent string,
 	featuresBuildInfo *feature.BuildInfo,
+	cacheFrom []string,
 ) (string, error) {
 	result, err := r.prepareBuildContext(
 		composeService, dockerFilePath, dockerfileContent, featuresBuildInfo,
@@ -866,6 +900,7 @@ func (r *runner) extendedDockerComposeBuild(
 		result.context,
 		featuresBuildInfo,
 	)
+	service.Build.CacheFrom = cacheFrom
 	return r.writeComposeFile(service)
 }
 
@@ -955,6 +990,43 @@ func composeBuildImageName(
 	return composeHelper.GetDefaultImage(projectName, composeService.Name)
 }
 
+// writeComposeCacheOverride writes a docker-compose override that only adds the cache sources
+// and the cache mounts to the service build.
+func (r *runner) writeComposeCacheOverride(
+	composeService *composetypes.ServiceConfig,
+	devContainerConfig *config.DevContainerConfig,
+) (string, error) {
+	service := &composetypes.ServiceConfig{
+		Name: composeService.Name,
+		Build: &composetypes.BuildConfig{
+			CacheFrom: composetypes.StringList(devContainerConfig.GetCacheFrom()),
+		},
+	}
+
+	if cacheMounts := devContainerConfig.GetCacheMount(); len(cacheMounts) > 0 {
+		dockerfilePath := composeService.Build.Dockerfile
+		if !filepath.IsAbs(dockerfilePath) {
+			dockerfilePath = filepath.Join(composeService.Build.Context, dockerfilePath)
+		}
+		dockerfileContent, err := os.ReadFile(dockerfilePath)
+		if err != nil {
+			return "", err
+		}
+
+		service.Build.Dockerfile, err = build.WriteDockerfileWithCacheMounts(
+			string(dockerfileContent),
+			dockerfilePath,
+			GetDockerComposeFolder(r.WorkspaceConfig.Origin),
+			cacheMounts,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return r.writeComposeFile(service)
+}
+
 func (r *runner) writeComposeFile(service *composetypes.ServiceConfig) (string, error) {
 	project := &composetypes.Project{
 		Services: map[string]composetypes.ServiceConfig{
@@ -962,7 +1034,7 @@ func (r *runner) writeComposeFile(service *composetypes.ServiceConfig) (string,
 		},
 	}
 
-	dockerComposeFolder := getDockerComposeFolder(r.WorkspaceConfig.Origin)
+	dockerComposeFolder := GetDockerComposeFolder(r.WorkspaceConfig.Origin)
 	if err := os.MkdirAll(dockerComposeFolder, 0o750); err != nil {
 		return "", err
 	}
@@ -1024,7 +1096,7 @@ func (r *runner) extendedDockerComposeUp(
 		return "", err
 	}
 
-	dockerComposeFolder := getDockerComposeFolder(r.WorkspaceConfig.Origin)
+	dockerComposeFolder := GetDockerComposeFolder(r.WorkspaceConfig.Origin)
 	err = os.MkdirAll(dockerComposeFolder, 0o750)
 	if err != nil {
 		return "", err
@@ -1105,10 +1177,17 @@ exec "$$@"
 		labels.Add(k, escapeComposeLabelValue(v))
 	}
 
+	// the merged config is stored with the result, so the version is only added to the service
+	environment := maps.Clone(mergedConfig.ContainerEnv)
+	if environment == nil {
+		environment = map[string]string{}
+	}
+	environment[pkgconfig.EnvVersion] = version.GetVersion()
+
 	overrideService := &composetypes.ServiceConfig{
 		Name:        composeService.Name,
 		Entrypoint:  entrypoint,
-		Environment: mappingFromMap(mergedConfig.ContainerEnv),
+		Environment: mappingFromMap(environment),
 		Init:        mergedConfig.Init,
 		CapAdd:      mergedConfig.CapAdd,
 		SecurityOpt: mergedConfig.SecurityOpt,
@@ -1133,6 +1212,7 @@ exec "$$@"
 
 	gpuSupportEnabled, _ := composeHelper.Docker.GPUSupportEnabled()
 	r.configureGPUResources(parsedConfig, gpuSupportEnabled, overrideService)
+	r.configureResourceLimits(overrideService)
 
 	for _, mount := range mergedConfig.Mounts {
 		overrideService.Volumes = append(overrideService.Volumes, composetypes.ServiceVolumeConfig{
@@ -1166,9 +1246,39 @@ exec "$$@"
 		project.Volumes[volumeMount.Name] = volumeMount
 	}
 
+	r.configureNetwork(project, composeService, overrideService.Name)
 	return project
 }
 
+// configureNetwork attaches the service to the external network passed via --network.
+func (r *runner) configureNetwork(
+	project *composetypes.Project,
+	composeService *composetypes.ServiceConfig,
+	serviceName string,
+) {
+	if r.WorkspaceConfig == nil || r.WorkspaceConfig.CLIOptions.Network == "" {
+		return
+	}
+
+	network := r.WorkspaceConfig.CLIOptions.Network
+	service := project.Services[serviceName]
+	service.Networks = map[string]*composetypes.ServiceNetworkConfig{
+		network: nil,
+	}
+	// keep the implicit default network, otherwise the service loses access to the other services
+	if len(composeService.Networks) == 0 {
+		service.Networks["default"] = nil
+	}
+	project.Services[serviceName] = service
+
+	project.Networks = composetypes.Networks{
+		network: composetypes.NetworkConfig{
+			Name:     network,
+			External: true,
+		},
+	}
+}
+
 func isReadOnlyMount(mount *config.Mount) bool {
 	for _, option := range mount.Other {
 		if option == readOnlyMountOption || option == "ro" {
@@ -1226,7 +1336,8 @@ func checkForPersistedFile(
 	return persistedFileResult{}
 }
 
-func getDockerComposeFolder(workspaceOriginFolder string) string {
+// GetDockerComposeFolder returns the folder the temporary compose override files of a workspace are written to.
+func GetDockerComposeFolder(workspaceOriginFolder string) string {
 	return filepath.Join(workspaceOriginFolder, ".docker-compose")
 }
 
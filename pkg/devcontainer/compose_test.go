@@ -7,9 +7,12 @@ import (
 	composetypes "github.com/compose-spec/compose-go/v2/types"
 	"github.com/sirupsen/logrus"
 	"github.com/skevetter/devpod/pkg/compose"
+	pkgconfig "github.com/skevetter/devpod/pkg/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/feature"
 	"github.com/skevetter/devpod/pkg/docker"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/version"
 	logLib "github.com/skevetter/log"
 	"github.com/stretchr/testify/suite"
 )
@@ -220,6 +223,48 @@ func (s *ComposeSuite) TestGenerateDockerComposeUpProjectPreservesReadOnlyMountO
 	s.False(service.Volumes[2].ReadOnly)
 }
 
+func (s *ComposeSuite) TestGenerateDockerComposeUpProjectAddsVersion() {
+	r := &runner{}
+	mergedConfig := &config.MergedDevContainerConfig{
+		NonComposeBase: config.NonComposeBase{ContainerEnv: map[string]string{"FOO": "bar"}},
+	}
+
+	project := r.generateDockerComposeUpProject(
+		&config.SubstitutedConfig{Config: &config.DevContainerConfig{}},
+		mergedConfig,
+		&compose.ComposeHelper{Docker: &docker.DockerHelper{DockerCommand: "true"}},
+		&composetypes.ServiceConfig{Name: "app"},
+		"mcr.microsoft.com/devcontainers/base:noble",
+		"mcr.microsoft.com/devcontainers/base:noble",
+		&config.ImageDetails{},
+		nil,
+	)
+
+	environment := project.Services["app"].Environment
+	s.Require().NotNil(environment[pkgconfig.EnvVersion])
+	s.Equal(version.GetVersion(), *environment[pkgconfig.EnvVersion])
+	s.Require().NotNil(environment["FOO"])
+	s.Equal("bar", *environment["FOO"])
+	s.Equal(map[string]string{"FOO": "bar"}, mergedConfig.ContainerEnv)
+}
+
+func (s *ComposeSuite) TestConfigureNetworkKeepsDefaultNetwork() {
+	r := &runner{WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+		CLIOptions: provider2.CLIOptions{Network: "services"},
+	}}
+
+	project := &composetypes.Project{Services: composetypes.Services{
+		"app": composetypes.ServiceConfig{Name: "app"},
+	}}
+	r.configureNetwork(project, &composetypes.ServiceConfig{Name: "app"}, "app")
+
+	service := project.Services["app"]
+	s.Contains(service.Networks, "services")
+	s.Contains(service.Networks, "default")
+	s.Require().Contains(project.Networks, "services")
+	s.True(bool(project.Networks["services"].External))
+}
+
 func (s *ComposeSuite) requireBuildArgValue(
 	args composetypes.MappingWithEquals,
 	key, want string,
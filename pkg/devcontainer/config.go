@@ -13,6 +13,7 @@ import (
 	pkgconfig "github.com/skevetter/devpod/pkg/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/devpod/pkg/devcontainer/crane"
+	"github.com/skevetter/devpod/pkg/devcontainer/feature"
 	"github.com/skevetter/devpod/pkg/language"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
 )
@@ -198,17 +199,11 @@ func (r *runner) substitute(
 
 	// merge additional features from CLI flag
 	if options.AdditionalFeatures != "" {
-		additionalFeatures := make(map[string]any)
-		if err := json.Unmarshal(
-			[]byte(options.AdditionalFeatures),
-			&additionalFeatures,
-		); err != nil {
+		additionalFeatures, err := feature.ParseFeatures(options.AdditionalFeatures)
+		if err != nil {
 			return nil, nil, fmt.Errorf("parse --additional-features JSON: %w", err)
 		}
-		if parsedConfig.Features == nil {
-			parsedConfig.Features = make(map[string]any)
-		}
-		maps.Copy(parsedConfig.Features, additionalFeatures)
+		parsedConfig.Features = feature.MergeFeatures(parsedConfig.Features, additionalFeatures)
 		r.Log.Infof(
 			"Merged %d additional feature(s): %v",
 			len(additionalFeatures),
@@ -216,6 +211,7 @@ func (r *runner) substitute(
 		)
 	}
 
+	r.applyFeatureOptions(parsedConfig)
 	parsedConfig.Origin = configFile
 	return &config.SubstitutedConfig{
 		Config: parsedConfig,
@@ -321,3 +317,19 @@ func mergeCLIMounts(
 	mergedConfig.Mounts = filteredMounts
 	return nil
 }
+
+// applyFeatureOptions merges the feature options set via devpod workspace set-feature-options.
+func (r *runner) applyFeatureOptions(parsedConfig *config.DevContainerConfig) {
+	if len(r.WorkspaceConfig.Workspace.FeatureOptions) == 0 {
+		return
+	}
+
+	features, ignored := feature.ApplyOptionOverrides(
+		parsedConfig.Features,
+		r.WorkspaceConfig.Workspace.FeatureOptions,
+	)
+	parsedConfig.Features = features
+	for _, featureID := range ignored {
+		r.Log.Warnf("Ignoring options of feature %s, it is not enabled in the config", featureID)
+	}
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	pkgconfig "github.com/skevetter/devpod/pkg/config"
 	"github.com/skevetter/devpod/pkg/dockerfile"
 )
@@ -17,11 +19,36 @@ func GetDockerLabelForID(id string) []string {
 	return []string{DockerIDLabel + "=" + id}
 }
 
+// remoteBuildContextPrefixes are the build context prefixes docker build fetches itself.
+var remoteBuildContextPrefixes = []string{"http://", "https://", "git://", "git@", "github.com/"}
+
+// IsRemoteBuildContext returns true if the build context is a URL instead of a local path.
+func IsRemoteBuildContext(context string) bool {
+	for _, prefix := range remoteBuildContextPrefixes {
+		if strings.HasPrefix(context, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRemoteBuildContext returns the build context if it is a URL, otherwise an empty string.
+func GetRemoteBuildContext(parsedConfig *DevContainerConfig) string {
+	if context := parsedConfig.GetContext(); IsRemoteBuildContext(context) {
+		return context
+	}
+
+	return ""
+}
+
 type BuildInfo struct {
 	ImageDetails  *ImageDetails
 	ImageMetadata *ImageMetadataConfig
 	ImageName     string
 	PrebuildHash  string
+	// BaseImageID is the id of the image the features were installed on
+	BaseImageID   string
 	RegistryCache string
 	Tags          []string
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/skevetter/devpod/pkg/types"
 )
@@ -271,6 +272,13 @@ func (d DockerfileContainer) GetCacheFrom() types.StrArray {
 	return nil
 }
 
+func (d DockerfileContainer) GetCacheMount() types.StrArray {
+	if d.Build != nil {
+		return d.Build.CacheMount
+	}
+	return nil
+}
+
 type ConfigBuildOptions struct {
 	// The location of the Dockerfile that defines the contents of the container. The path is relative to the folder containing the `devcontainer.json` file.
 	Dockerfile string `json:"dockerfile,omitempty"`
@@ -287,6 +295,9 @@ type ConfigBuildOptions struct {
 	// The image to consider as a cache. Use an array to specify multiple images.
 	CacheFrom types.StrArray `json:"cacheFrom,omitempty"`
 
+	// Paths to mount as BuildKit cache into every RUN instruction. Use an array to specify multiple paths.
+	CacheMount types.StrArray `json:"cacheMount,omitempty"`
+
 	// Build cli options
 	Options []string `json:"options,omitempty"`
 }
@@ -341,6 +352,30 @@ type PortAttribute struct {
 
 	// The protocol to use when forwarding this port.
 	Protocol string `json:"protocol,omitempty"`
+
+	// DevPod specific: A process pattern that needs to be running in the container before the port is forwarded.
+	WaitFor string `json:"waitFor,omitempty"`
+
+	// DevPod specific: How long to wait for the waitFor process before forwarding the port anyway.
+	// default=60s
+	WaitTimeout string `json:"waitTimeout,omitempty"`
+}
+
+// DefaultPortWaitTimeout is the default time to wait for the waitFor process of a port.
+const DefaultPortWaitTimeout = 60 * time.Second
+
+// GetWaitTimeout returns the parsed waitTimeout or the default if it is empty or invalid.
+func (p PortAttribute) GetWaitTimeout() time.Duration {
+	if p.WaitTimeout == "" {
+		return DefaultPortWaitTimeout
+	}
+
+	timeout, err := time.ParseDuration(p.WaitTimeout)
+	if err != nil || timeout <= 0 {
+		return DefaultPortWaitTimeout
+	}
+
+	return timeout
 }
 
 type DevPodCustomizations struct {
@@ -395,7 +430,8 @@ func GetContextPath(parsedConfig *DevContainerConfig) string {
 		return filepath.FromSlash(path.Join(configDir, p))
 	}
 
-	if context := parsedConfig.GetContext(); context != "" {
+	// remote contexts are passed to the build as is, local files still go next to the Dockerfile
+	if context := parsedConfig.GetContext(); context != "" && !IsRemoteBuildContext(context) {
 		return resolvePath(context)
 	}
 
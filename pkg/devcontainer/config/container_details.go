@@ -15,6 +15,7 @@ type ImageDetailsConfig struct {
 
 type ContainerDetails struct {
 	ID      string                 `json:"ID,omitempty"`
+	ImageID string                 `json:"Image,omitempty"`
 	Created string                 `json:"Created,omitempty"`
 	State   ContainerDetailsState  `json:"State"`
 	Config  ContainerDetailsConfig `json:"Config"`
@@ -34,6 +35,9 @@ type ContainerDetailsConfig struct {
 }
 
 type ContainerDetailsState struct {
-	Status    string `json:"Status,omitempty"`
-	StartedAt string `json:"StartedAt,omitempty"`
+	Status     string `json:"Status,omitempty"`
+	StartedAt  string `json:"StartedAt,omitempty"`
+	FinishedAt string `json:"FinishedAt,omitempty"`
+	ExitCode   int    `json:"ExitCode,omitempty"`
+	OOMKilled  bool   `json:"OOMKilled,omitempty"`
 }
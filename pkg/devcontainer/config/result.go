@@ -9,6 +9,13 @@ import (
 
 const UserLabel = pkgconfig.BinaryName + ".user"
 
+// TagsLabel holds the comma separated user defined workspace tags.
+const TagsLabel = pkgconfig.BinaryName + ".sh/tags"
+
+// BaseImageIDLabel holds the id of the image from the devcontainer.json the container was
+// created from. The container might run an image extended with features.
+const BaseImageIDLabel = pkgconfig.BinaryName + ".sh/base-image-id"
+
 type Result struct {
 	DevContainerConfigWithPath *DevContainerConfigWithPath `json:"DevContainerConfigWithPath"`
 	MergedConfig               *MergedDevContainerConfig   `json:"MergedConfig"`
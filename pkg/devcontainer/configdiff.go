@@ -0,0 +1,105 @@
+package devcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log/hash"
+)
+
+// ResolveConfig merges the current devcontainer.json with the image metadata of the existing
+// container without building or starting anything. Without a container only the
+// devcontainer.json is used.
+func (r *runner) ResolveConfig(
+	ctx context.Context,
+	options provider2.CLIOptions,
+) (*config.MergedDevContainerConfig, error) {
+	substitutedConfig, substitutionContext, err := r.getSubstitutedConfig(options)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanupBuildInformation(substitutedConfig.Config)
+
+	containerDetails, err := r.Driver.FindDevContainer(ctx, r.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find dev container: %w", err)
+	}
+
+	return r.mergeExistingContainerConfig(containerDetails, &resolveParams{
+		parsedConfig:        substitutedConfig,
+		substitutionContext: substitutionContext,
+		options:             UpOptions{CLIOptions: options},
+	})
+}
+
+// DiffMergedConfigs returns a unified diff of the JSON representations of the two configs.
+// Features are compared by the digest of their options. The diff is empty if the configs match.
+func DiffMergedConfigs(oldConfig, newConfig *config.MergedDevContainerConfig) (string, error) {
+	oldJSON, err := marshalConfigForDiff(oldConfig)
+	if err != nil {
+		return "", err
+	}
+	newJSON, err := marshalConfigForDiff(newConfig)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(oldJSON),
+		B:        difflib.SplitLines(newJSON),
+		FromFile: "last up",
+		ToFile:   "current",
+		Context:  3,
+	})
+}
+
+func marshalConfigForDiff(mergedConfig *config.MergedDevContainerConfig) (string, error) {
+	if mergedConfig == nil {
+		mergedConfig = &config.MergedDevContainerConfig{}
+	}
+
+	out, err := json.Marshal(mergedConfig)
+	if err != nil {
+		return "", err
+	}
+	normalized := map[string]any{}
+	err = json.Unmarshal(out, &normalized)
+	if err != nil {
+		return "", err
+	}
+
+	if len(mergedConfig.Features) > 0 {
+		featureDigests, err := FeatureDigests(mergedConfig.Features)
+		if err != nil {
+			return "", err
+		}
+		normalized["features"] = featureDigests
+	}
+
+	out, err = json.MarshalIndent(normalized, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out) + "\n", nil
+}
+
+// FeatureDigests maps each feature id to the digest of its options.
+func FeatureDigests(features map[string]any) (map[string]string, error) {
+	featureDigests := map[string]string{}
+	for _, featureID := range slices.Sorted(maps.Keys(features)) {
+		out, err := json.Marshal(features[featureID])
+		if err != nil {
+			return nil, fmt.Errorf("marshal options of feature %s: %w", featureID, err)
+		}
+		featureDigests[featureID] = hash.String(string(out))
+	}
+
+	return featureDigests, nil
+}
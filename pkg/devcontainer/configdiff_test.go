@@ -0,0 +1,47 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfigDiffTestConfig(remoteUser string, features map[string]any) *config.MergedDevContainerConfig {
+	return &config.MergedDevContainerConfig{
+		DevContainerConfigBase: config.DevContainerConfigBase{
+			Name:       "test",
+			RemoteUser: remoteUser,
+			Features:   features,
+		},
+	}
+}
+
+func TestDiffMergedConfigs_Unchanged(t *testing.T) {
+	features := map[string]any{"ghcr.io/devcontainers/features/go:1": map[string]any{"version": "1.25"}}
+
+	diff, err := DiffMergedConfigs(
+		newConfigDiffTestConfig("vscode", features),
+		newConfigDiffTestConfig("vscode", features),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
+
+func TestDiffMergedConfigs_Changed(t *testing.T) {
+	diff, err := DiffMergedConfigs(
+		newConfigDiffTestConfig("vscode", map[string]any{
+			"ghcr.io/devcontainers/features/go:1": map[string]any{"version": "1.24"},
+		}),
+		newConfigDiffTestConfig("root", map[string]any{
+			"ghcr.io/devcontainers/features/go:1": map[string]any{"version": "1.25"},
+		}),
+	)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- last up\n+++ current\n")
+	assert.Contains(t, diff, `-  "remoteUser": "vscode"`)
+	assert.Contains(t, diff, `+  "remoteUser": "root"`)
+	assert.Contains(t, diff, `-    "ghcr.io/devcontainers/features/go:1": "`)
+	assert.Contains(t, diff, `+    "ghcr.io/devcontainers/features/go:1": "`)
+}
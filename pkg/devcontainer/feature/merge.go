@@ -0,0 +1,96 @@
+package feature
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+)
+
+// ParseFeatures parses a JSON object in the format of the features section of devcontainer.json.
+func ParseFeatures(featuresJSON string) (map[string]any, error) {
+	features := map[string]any{}
+	err := json.Unmarshal([]byte(featuresJSON), &features)
+	if err != nil {
+		return nil, err
+	}
+
+	return features, nil
+}
+
+// MergeFeatures adds the overrides to the features. If a feature exists in both and both
+// specify options as an object, the options are merged with the override options taking
+// precedence, otherwise the override replaces the feature.
+func MergeFeatures(features, overrides map[string]any) map[string]any {
+	merged := maps.Clone(features)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	for featureID, overrideOptions := range overrides {
+		options, ok := merged[featureID].(map[string]any)
+		overrideOptionsMap, overrideOk := overrideOptions.(map[string]any)
+		if !ok || !overrideOk {
+			merged[featureID] = overrideOptions
+			continue
+		}
+
+		mergedOptions := maps.Clone(options)
+		maps.Copy(mergedOptions, overrideOptionsMap)
+		merged[featureID] = mergedOptions
+	}
+
+	return merged
+}
+
+// ApplyOptionOverrides merges the per-workspace option overrides into the options of the
+// features. A version string is kept as the version option, features that are disabled or not
+// part of the features are left untouched and their ids are returned.
+func ApplyOptionOverrides(
+	features map[string]any,
+	overrides map[string]map[string]string,
+) (map[string]any, []string) {
+	merged := maps.Clone(features)
+	ignored := []string{}
+	for featureID, overrideOptions := range overrides {
+		options, ok := merged[featureID]
+		if !ok || options == false {
+			ignored = append(ignored, featureID)
+			continue
+		}
+
+		mergedOptions := map[string]any{}
+		switch options := options.(type) {
+		case map[string]any:
+			maps.Copy(mergedOptions, options)
+		case string:
+			mergedOptions["version"] = options
+		}
+		for key, value := range overrideOptions {
+			mergedOptions[key] = value
+		}
+		merged[featureID] = mergedOptions
+	}
+
+	return merged, ignored
+}
+
+// ValidateFeatures returns an error if featuresJSON is not a valid features object.
+func ValidateFeatures(featuresJSON string) error {
+	features, err := ParseFeatures(featuresJSON)
+	if err != nil {
+		return err
+	}
+
+	for featureID, options := range features {
+		switch options.(type) {
+		case map[string]any, string, bool:
+		default:
+			return fmt.Errorf(
+				"feature %s: options must be an object, a version string or a boolean",
+				featureID,
+			)
+		}
+	}
+
+	return nil
+}
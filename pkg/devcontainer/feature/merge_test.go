@@ -0,0 +1,85 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeTestSuite struct {
+	suite.Suite
+}
+
+func TestMergeTestSuite(t *testing.T) {
+	suite.Run(t, new(MergeTestSuite))
+}
+
+func (suite *MergeTestSuite) TestMergeFeaturesMergesOptions() {
+	features := map[string]any{
+		"ghcr.io/devcontainers/features/node:1": map[string]any{"version": "18", "nvmVersion": "0.39"},
+		"ghcr.io/devcontainers/features/git:1":  "latest",
+	}
+
+	merged := MergeFeatures(features, map[string]any{
+		"ghcr.io/devcontainers/features/node:1": map[string]any{"version": "22"},
+		"ghcr.io/devcontainers/features/go:1":   map[string]any{},
+	})
+
+	suite.Equal(map[string]any{
+		"ghcr.io/devcontainers/features/node:1": map[string]any{"version": "22", "nvmVersion": "0.39"},
+		"ghcr.io/devcontainers/features/git:1":  "latest",
+		"ghcr.io/devcontainers/features/go:1":   map[string]any{},
+	}, merged)
+	suite.Equal("18", features["ghcr.io/devcontainers/features/node:1"].(map[string]any)["version"])
+}
+
+func (suite *MergeTestSuite) TestMergeFeaturesReplacesNonObjectOptions() {
+	merged := MergeFeatures(
+		map[string]any{"ghcr.io/devcontainers/features/git:1": "latest"},
+		map[string]any{"ghcr.io/devcontainers/features/git:1": map[string]any{"version": "2.40"}},
+	)
+
+	suite.Equal(map[string]any{
+		"ghcr.io/devcontainers/features/git:1": map[string]any{"version": "2.40"},
+	}, merged)
+}
+
+func (suite *MergeTestSuite) TestValidateFeatures() {
+	suite.NoError(ValidateFeatures(`{"ghcr.io/devcontainers/features/go:1": {"version": "1.25"}}`))
+	suite.Error(ValidateFeatures(`{invalid`))
+	suite.Error(ValidateFeatures(`["ghcr.io/devcontainers/features/go:1"]`))
+	suite.Error(ValidateFeatures(`{"ghcr.io/devcontainers/features/go:1": 1}`))
+}
+
+func (suite *MergeTestSuite) TestApplyOptionOverrides() {
+	const (
+		node   = "ghcr.io/devcontainers/features/node:1"
+		git    = "ghcr.io/devcontainers/features/git:1"
+		python = "ghcr.io/devcontainers/features/python:1"
+		golang = "ghcr.io/devcontainers/features/go:1"
+		rust   = "ghcr.io/devcontainers/features/rust:1"
+	)
+	features := map[string]any{
+		node:   map[string]any{"version": "18", "nvmVersion": "0.39"},
+		git:    "latest",
+		python: true,
+		golang: false,
+	}
+
+	merged, ignored := ApplyOptionOverrides(features, map[string]map[string]string{
+		node:   {"version": "22"},
+		git:    {"ppa": "false"},
+		python: {"version": "3.12"},
+		golang: {"version": "1.23"},
+		rust:   {"version": "1.80"},
+	})
+
+	suite.Equal(map[string]any{
+		node:   map[string]any{"version": "22", "nvmVersion": "0.39"},
+		git:    map[string]any{"version": "latest", "ppa": "false"},
+		python: map[string]any{"version": "3.12"},
+		golang: false,
+	}, merged)
+	suite.ElementsMatch([]string{golang, rust}, ignored)
+	suite.Equal("18", features[node].(map[string]any)["version"])
+}
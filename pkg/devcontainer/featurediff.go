@@ -0,0 +1,152 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+)
+
+// DiffFeatures resolves the features of the devcontainer.json and the desired features on
+// top of the same base image and returns a unified diff of the two generated Dockerfiles.
+// Nothing is built, the diff is empty if both feature sets generate the same Dockerfile.
+func (r *runner) DiffFeatures(
+	ctx context.Context,
+	options provider2.CLIOptions,
+	desiredFeatures map[string]any,
+) (string, error) {
+	substitutedConfig, substitutionContext, err := r.getSubstitutedConfig(options)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupBuildInformation(substitutedConfig.Config)
+
+	imageBuildInfo, target, err := r.getBaseImageBuildInfo(
+		ctx,
+		substitutedConfig,
+		substitutionContext,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	currentDockerfile, err := r.featuresDockerfile(
+		ctx,
+		substitutedConfig,
+		substitutionContext,
+		imageBuildInfo,
+		target,
+	)
+	if err != nil {
+		return "", fmt.Errorf("resolve current features: %w", err)
+	}
+
+	desiredConfig := *substitutedConfig.Config
+	desiredConfig.Features = desiredFeatures
+	desiredDockerfile, err := r.featuresDockerfile(
+		ctx,
+		&config.SubstitutedConfig{Config: &desiredConfig, Raw: substitutedConfig.Raw},
+		substitutionContext,
+		imageBuildInfo,
+		target,
+	)
+	if err != nil {
+		return "", fmt.Errorf("resolve desired features: %w", err)
+	}
+
+	return DiffDockerfiles(currentDockerfile, desiredDockerfile)
+}
+
+// DiffDockerfiles returns a unified diff of the two Dockerfiles.
+func DiffDockerfiles(currentDockerfile, desiredDockerfile string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(currentDockerfile),
+		B:        difflib.SplitLines(desiredDockerfile),
+		FromFile: "current features",
+		ToFile:   "desired features",
+		Context:  3,
+	})
+}
+
+// getBaseImageBuildInfo returns the build info of the image the features are installed on
+// and the build target of the features.
+func (r *runner) getBaseImageBuildInfo(
+	ctx context.Context,
+	parsedConfig *config.SubstitutedConfig,
+	substitutionContext *config.SubstitutionContext,
+) (*config.ImageBuildInfo, string, error) {
+	switch {
+	case isDockerFileConfig(parsedConfig.Config):
+		dockerFilePath, err := r.getDockerfilePath(parsedConfig.Config)
+		if err != nil {
+			return nil, "", err
+		}
+		dockerFileContent, err := os.ReadFile(dockerFilePath)
+		if err != nil {
+			return nil, "", err
+		}
+
+		imageBuildInfo, err := r.getImageBuildInfoFromDockerfile(
+			substitutionContext,
+			string(dockerFileContent),
+			parsedConfig.Config.GetArgs(),
+			parsedConfig.Config.GetTarget(),
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("get image build info: %w", err)
+		}
+
+		target := parsedConfig.Config.GetTarget()
+		if target == "" {
+			target = config.DockerfileDefaultTarget
+		}
+		return imageBuildInfo, target, nil
+	case parsedConfig.Config.Image != "":
+		imageBuildInfo, err := r.getImageBuildInfoFromImage(
+			ctx,
+			substitutionContext,
+			parsedConfig.Config.Image,
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("get image build info: %w", err)
+		}
+
+		return imageBuildInfo, parsedConfig.Config.Image, nil
+	default:
+		return nil, "", fmt.Errorf(
+			"diffing features is only supported for image and Dockerfile based dev containers",
+		)
+	}
+}
+
+// featuresDockerfile returns the Dockerfile that installs the features of the config.
+func (r *runner) featuresDockerfile(
+	ctx context.Context,
+	parsedConfig *config.SubstitutedConfig,
+	substitutionContext *config.SubstitutionContext,
+	imageBuildInfo *config.ImageBuildInfo,
+	target string,
+) (string, error) {
+	extendedBuildInfo, err := r.getExtendedBuildInfo(
+		ctx,
+		substitutionContext,
+		imageBuildInfo,
+		target,
+		parsedConfig,
+		false,
+	)
+	if err != nil {
+		return "", err
+	} else if extendedBuildInfo == nil || extendedBuildInfo.FeaturesBuildInfo == nil {
+		return "", nil
+	}
+
+	return strings.TrimSpace(strings.Join([]string{
+		extendedBuildInfo.FeaturesBuildInfo.DockerfilePrefixContent,
+		extendedBuildInfo.FeaturesBuildInfo.DockerfileContent,
+	}, "\n")) + "\n", nil
+}
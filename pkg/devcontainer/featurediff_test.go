@@ -0,0 +1,22 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDockerfiles(t *testing.T) {
+	current := "FROM base AS dev_containers_target_stage\nRUN install go 1.24\n"
+
+	diff, err := DiffDockerfiles(current, current)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = DiffDockerfiles(current, "FROM base AS dev_containers_target_stage\nRUN install go 1.25\n")
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- current features\n+++ desired features\n")
+	assert.Contains(t, diff, "-RUN install go 1.24\n")
+	assert.Contains(t, diff, "+RUN install go 1.25\n")
+}
@@ -0,0 +1,83 @@
+package devcontainer
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/provider"
+)
+
+// NetworkPolicyChain is the iptables chain holding the egress rules applied by DevPod.
+const NetworkPolicyChain = "DEVPOD-EGRESS"
+
+// ParseNetworkPolicy validates the action and cidr of an egress rule.
+func ParseNetworkPolicy(action, cidr string) (provider.NetworkPolicy, error) {
+	if action != provider.NetworkPolicyAllow && action != provider.NetworkPolicyDeny {
+		return provider.NetworkPolicy{}, fmt.Errorf(
+			"unknown network policy action %s, choose either %s or %s",
+			action, provider.NetworkPolicyAllow, provider.NetworkPolicyDeny,
+		)
+	}
+
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return provider.NetworkPolicy{}, fmt.Errorf("parse cidr %s: %w", cidr, err)
+	}
+
+	return provider.NetworkPolicy{Action: action, CIDR: ipNet.String()}, nil
+}
+
+// ClearNetworkPolicyScript returns a shell script that removes the DevPod egress chain.
+func ClearNetworkPolicyScript() string {
+	return fmt.Sprintf(
+		"for t in iptables ip6tables; do command -v $t >/dev/null 2>&1 || continue; "+
+			"$t -D OUTPUT -j %[1]s 2>/dev/null; $t -F %[1]s 2>/dev/null; "+
+			"$t -X %[1]s 2>/dev/null; done; true",
+		NetworkPolicyChain,
+	)
+}
+
+// ListNetworkPolicyScript returns a shell script that prints the DevPod egress rules.
+func ListNetworkPolicyScript() string {
+	return fmt.Sprintf(
+		"for t in iptables ip6tables; do command -v $t >/dev/null 2>&1 || continue; "+
+			"$t -S %s 2>/dev/null; done; true",
+		NetworkPolicyChain,
+	)
+}
+
+// ApplyNetworkPolicyScript returns a shell script that replaces the DevPod egress chain with
+// the given rules. Rules are evaluated in order and loopback traffic is always allowed.
+func ApplyNetworkPolicyScript(policies []provider.NetworkPolicy) (string, error) {
+	commands := []string{ClearNetworkPolicyScript(), "set -e"}
+	chains := map[string]bool{}
+	for _, policy := range policies {
+		ip, _, err := net.ParseCIDR(policy.CIDR)
+		if err != nil {
+			return "", fmt.Errorf("parse cidr %s: %w", policy.CIDR, err)
+		}
+
+		binary := "iptables"
+		if ip.To4() == nil {
+			binary = "ip6tables"
+		}
+		if !chains[binary] {
+			chains[binary] = true
+			commands = append(commands,
+				fmt.Sprintf("%s -N %s", binary, NetworkPolicyChain),
+				fmt.Sprintf("%s -A %s -o lo -j ACCEPT", binary, NetworkPolicyChain),
+				fmt.Sprintf("%s -I OUTPUT 1 -j %s", binary, NetworkPolicyChain),
+			)
+		}
+
+		target := "ACCEPT"
+		if policy.Action == provider.NetworkPolicyDeny {
+			target = "DROP"
+		}
+		commands = append(commands,
+			fmt.Sprintf("%s -A %s -d %s -j %s", binary, NetworkPolicyChain, policy.CIDR, target))
+	}
+
+	return strings.Join(commands, "\n"), nil
+}
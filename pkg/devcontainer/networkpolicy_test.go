@@ -0,0 +1,38 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNetworkPolicy(t *testing.T) {
+	policy, err := ParseNetworkPolicy(provider.NetworkPolicyAllow, "10.1.2.3/8")
+	require.NoError(t, err)
+	assert.Equal(t, provider.NetworkPolicy{Action: "allow", CIDR: "10.0.0.0/8"}, policy)
+
+	_, err = ParseNetworkPolicy(provider.NetworkPolicyDeny, "10.0.0.1")
+	require.Error(t, err)
+
+	_, err = ParseNetworkPolicy("reject", "10.0.0.0/8")
+	require.Error(t, err)
+}
+
+func TestApplyNetworkPolicyScript(t *testing.T) {
+	script, err := ApplyNetworkPolicyScript([]provider.NetworkPolicy{
+		{Action: provider.NetworkPolicyAllow, CIDR: "10.0.0.0/8"},
+		{Action: provider.NetworkPolicyDeny, CIDR: "0.0.0.0/0"},
+		{Action: provider.NetworkPolicyDeny, CIDR: "::/0"},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, script, ClearNetworkPolicyScript())
+	assert.Contains(t, script, "iptables -I OUTPUT 1 -j DEVPOD-EGRESS\n"+
+		"iptables -A DEVPOD-EGRESS -d 10.0.0.0/8 -j ACCEPT\n"+
+		"iptables -A DEVPOD-EGRESS -d 0.0.0.0/0 -j DROP\n"+
+		"ip6tables -N DEVPOD-EGRESS")
+	assert.Contains(t, script, "ip6tables -A DEVPOD-EGRESS -d ::/0 -j DROP")
+	assert.Contains(t, script, "iptables -A DEVPOD-EGRESS -o lo -j ACCEPT")
+}
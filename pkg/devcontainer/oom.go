@@ -0,0 +1,91 @@
+package devcontainer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+)
+
+// OOMKilledError is returned when the container or one of its processes was killed
+// because it ran out of memory.
+type OOMKilledError struct {
+	ContainerID string
+	Time        time.Time
+	Err         error
+}
+
+func (e *OOMKilledError) Error() string {
+	return fmt.Sprintf(
+		"container %s ran out of memory and was killed by the OOM killer at %s. "+
+			"Try increasing hostRequirements.memory in your devcontainer.json or the memory "+
+			"available to Docker: %v",
+		e.ContainerID,
+		e.Time.Format(time.RFC3339),
+		e.Err,
+	)
+}
+
+func (e *OOMKilledError) Unwrap() error {
+	return e.Err
+}
+
+// checkOOMKilled inspects the container after a failed setup and replaces the error with
+// an OOMKilledError if the failure was caused by the container running out of memory.
+func (r *runner) checkOOMKilled(
+	ctx context.Context,
+	containerDetails *config.ContainerDetails,
+	err error,
+) error {
+	if containerDetails == nil || containerDetails.ID == "" {
+		return err
+	}
+
+	current := r.findContainerByID(ctx, containerDetails.ID)
+	if current == nil {
+		current = containerDetails
+	}
+
+	return oomKilledError(current, err, time.Now())
+}
+
+// findContainerByID returns fresh container details, either through the compose helper
+// for compose setups or through the driver for single containers.
+func (r *runner) findContainerByID(ctx context.Context, containerID string) *config.ContainerDetails {
+	if composeHelper, err := r.composeHelper(); err == nil {
+		details, err := composeHelper.Docker.FindContainerByID(ctx, []string{containerID})
+		if err == nil && details != nil {
+			return details
+		}
+	}
+
+	details, err := r.Driver.FindDevContainer(ctx, r.ID)
+	if err != nil || details == nil || details.ID != containerID {
+		r.Log.Debugf("error inspecting container %s after failed setup: %v", containerID, err)
+		return nil
+	}
+
+	return details
+}
+
+// oomKilledError only trusts the OOMKilled flag of docker. A SIGKILL from a canceled context,
+// Ctrl-C or kill -9 also exits with 137, so the exit code alone doesn't indicate an OOM kill.
+func oomKilledError(containerDetails *config.ContainerDetails, err error, now time.Time) error {
+	state := containerDetails.State
+	if !state.OOMKilled {
+		return err
+	}
+
+	oomTime := now
+	finishedAt, parseErr := time.Parse(time.RFC3339Nano, state.FinishedAt)
+	if parseErr == nil && finishedAt.Year() > 1 {
+		oomTime = finishedAt
+	}
+
+	return &OOMKilledError{
+		ContainerID: containerDetails.ID,
+		Time:        oomTime,
+		Err:         err,
+	}
+}
@@ -0,0 +1,72 @@
+package devcontainer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOOMKilledError(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	setupErr := errors.New("run postCreateCommand: exit status 1")
+
+	t.Run("container oom killed", func(t *testing.T) {
+		details := &config.ContainerDetails{
+			ID: "abc",
+			State: config.ContainerDetailsState{
+				Status:     "exited",
+				ExitCode:   137,
+				OOMKilled:  true,
+				FinishedAt: "2024-01-01T10:00:00.123456789Z",
+			},
+		}
+
+		err := oomKilledError(details, setupErr, now)
+		oomErr := &OOMKilledError{}
+		require.ErrorAs(t, err, &oomErr)
+		assert.Equal(t, "abc", oomErr.ContainerID)
+		assert.Equal(t, 2024, oomErr.Time.Year())
+		assert.Equal(t, 10, oomErr.Time.Hour())
+		assert.ErrorIs(t, err, setupErr)
+		assert.Contains(t, err.Error(), "hostRequirements.memory")
+	})
+
+	t.Run("lifecycle command oom killed", func(t *testing.T) {
+		details := &config.ContainerDetails{
+			ID: "abc",
+			State: config.ContainerDetailsState{
+				Status:     "running",
+				OOMKilled:  true,
+				FinishedAt: "0001-01-01T00:00:00Z",
+			},
+		}
+
+		err := oomKilledError(details, errors.New("run postCreateCommand: exit status 137"), now)
+		oomErr := &OOMKilledError{}
+		require.ErrorAs(t, err, &oomErr)
+		assert.Equal(t, now, oomErr.Time)
+	})
+
+	t.Run("killed without oom", func(t *testing.T) {
+		killedErr := errors.New("run postCreateCommand: signal: killed")
+		details := &config.ContainerDetails{
+			ID:    "abc",
+			State: config.ContainerDetailsState{Status: "exited", ExitCode: 137},
+		}
+
+		assert.Equal(t, killedErr, oomKilledError(details, killedErr, now))
+	})
+
+	t.Run("other failure", func(t *testing.T) {
+		details := &config.ContainerDetails{
+			ID:    "abc",
+			State: config.ContainerDetailsState{Status: "running"},
+		}
+
+		assert.Equal(t, setupErr, oomKilledError(details, setupErr, now))
+	})
+}
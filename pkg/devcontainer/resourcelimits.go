@@ -0,0 +1,71 @@
+package devcontainer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	"github.com/docker/go-units"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+)
+
+// ParseCPULimit parses a cpu limit in docker notation, e.g. 1.5.
+func ParseCPULimit(cpus string) (float64, error) {
+	value, err := strconv.ParseFloat(cpus, 64)
+	if err != nil || value <= 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid cpu limit %q, expected a positive number, e.g. 1.5", cpus)
+	}
+
+	return value, nil
+}
+
+// ParseMemoryLimit parses a memory limit in docker notation, e.g. 512m or 4g.
+func ParseMemoryLimit(memory string) (int64, error) {
+	value, err := units.RAMInBytes(memory)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid memory limit %q, expected a size, e.g. 512m or 4g", memory)
+	}
+
+	return value, nil
+}
+
+// configureResourceLimits adds the workspace resource limits to the deploy section of the
+// compose override service.
+func (r *runner) configureResourceLimits(overrideService *composetypes.ServiceConfig) {
+	if r.WorkspaceConfig == nil || r.WorkspaceConfig.Workspace == nil ||
+		r.WorkspaceConfig.Workspace.ResourceLimits == nil {
+		return
+	}
+
+	limits, err := composeResourceLimits(r.WorkspaceConfig.Workspace.ResourceLimits)
+	if err != nil {
+		r.Log.Warnf("skip resource limits: %v", err)
+		return
+	}
+
+	if overrideService.Deploy == nil {
+		overrideService.Deploy = &composetypes.DeployConfig{}
+	}
+	overrideService.Deploy.Resources.Limits = limits
+}
+
+func composeResourceLimits(limits *provider2.ResourceLimits) (*composetypes.Resource, error) {
+	resource := &composetypes.Resource{}
+	if limits.CPUs != "" {
+		cpus, err := ParseCPULimit(limits.CPUs)
+		if err != nil {
+			return nil, err
+		}
+		resource.NanoCPUs = composetypes.NanoCPUs(cpus)
+	}
+	if limits.Memory != "" {
+		memory, err := ParseMemoryLimit(limits.Memory)
+		if err != nil {
+			return nil, err
+		}
+		resource.MemoryBytes = composetypes.UnitBytes(memory)
+	}
+
+	return resource, nil
+}
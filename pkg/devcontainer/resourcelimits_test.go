@@ -0,0 +1,31 @@
+package devcontainer
+
+import (
+	"testing"
+
+	composetypes "github.com/compose-spec/compose-go/v2/types"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposeResourceLimits(t *testing.T) {
+	resource, err := composeResourceLimits(&provider2.ResourceLimits{CPUs: "1.5", Memory: "512m"})
+	require.NoError(t, err)
+	assert.Equal(t, composetypes.NanoCPUs(1.5), resource.NanoCPUs)
+	assert.Equal(t, composetypes.UnitBytes(512*1024*1024), resource.MemoryBytes)
+
+	_, err = composeResourceLimits(&provider2.ResourceLimits{CPUs: "-1"})
+	require.Error(t, err)
+}
+
+func TestParseCPULimit(t *testing.T) {
+	value, err := ParseCPULimit("0.5")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, value, 0)
+
+	for _, cpus := range []string{"0", "-1", "NaN", "Inf", "+Inf", "abc"} {
+		_, err = ParseCPULimit(cpus)
+		assert.Error(t, err, cpus)
+	}
+}
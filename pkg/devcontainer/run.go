@@ -27,6 +27,17 @@ type Runner interface {
 
 	Find(ctx context.Context) (*config.ContainerDetails, error)
 
+	ResolveConfig(
+		ctx context.Context,
+		options provider2.CLIOptions,
+	) (*config.MergedDevContainerConfig, error)
+
+	DiffFeatures(
+		ctx context.Context,
+		options provider2.CLIOptions,
+		desiredFeatures map[string]any,
+	) (string, error)
+
 	Command(
 		ctx context.Context,
 		user string,
@@ -22,6 +22,8 @@ import (
 	"github.com/skevetter/devpod/pkg/driver"
 	"github.com/skevetter/devpod/pkg/ide"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
+	sshServer "github.com/skevetter/devpod/pkg/ssh/server"
+	"github.com/skevetter/devpod/pkg/telemetry"
 	"github.com/skevetter/log"
 )
 
@@ -37,12 +39,15 @@ type setupContainerParams struct {
 	mergedConfig        *config.MergedDevContainerConfig
 	substitutionContext *config.SubstitutionContext
 	timeout             time.Duration
+	// wasRunning is true if the container was already running before this up
+	wasRunning bool
 }
 
 type setupInfo struct {
 	result                    *config.Result
 	compressed                string
 	workspaceConfigCompressed string
+	skipLifecycleCommands     bool
 }
 
 func (r *runner) setupContainer(
@@ -60,9 +65,16 @@ func (r *runner) setupContainer(
 		return nil, err
 	}
 
-	setupCommand := r.buildSetupCommand(info.compressed, info.workspaceConfigCompressed)
+	setupCommand := r.buildSetupCommand(info)
 
-	return r.executeSetup(ctx, info.result, setupCommand)
+	hooksCtx, span := telemetry.StartSpan(ctx, telemetry.SpanLifecycleHooks)
+	result, err := r.executeSetup(hooksCtx, info.result, setupCommand)
+	telemetry.EndSpan(span, err)
+	if err != nil {
+		return nil, r.checkOOMKilled(ctx, params.containerDetails, err)
+	}
+
+	return result, nil
 }
 
 func (r *runner) injectAgentIntoContainer(ctx context.Context, timeout time.Duration) error {
@@ -85,6 +97,7 @@ func (r *runner) injectAgentIntoContainer(ctx context.Context, timeout time.Dura
 		PreferDownloadFromRemoteUrl: agent.Bool(false),
 		Log:                         r.Log,
 		Timeout:                     timeout,
+		ForceReinstall:              r.WorkspaceConfig.CLIOptions.AgentForceReinstall,
 	})
 	if err != nil {
 		return fmt.Errorf("inject agent: %w", err)
@@ -109,6 +122,7 @@ func (r *runner) prepareSetupInfo(params *setupContainerParams) (*setupInfo, err
 		result:                    result,
 		compressed:                compressed,
 		workspaceConfigCompressed: workspaceConfigCompressed,
+		skipLifecycleCommands:     r.WorkspaceConfig.CLIOptions.SkipLifecycleCommands && params.wasRunning,
 	}, nil
 }
 
@@ -173,16 +187,19 @@ func (r *runner) compressWorkspaceConfig() (string, error) {
 	return compressed, nil
 }
 
-func (r *runner) buildSetupCommand(compressed, workspaceConfigCompressed string) string {
+func (r *runner) buildSetupCommand(info *setupInfo) string {
 	r.Log.Infof("setting up container")
 	args := []string{
 		shellescape.Quote(agent.ContainerDevPodHelperLocation),
 		"agent", "container", "setup",
-		"--setup-info", shellescape.Quote(compressed),
-		"--container-workspace-info", shellescape.Quote(workspaceConfigCompressed),
+		"--setup-info", shellescape.Quote(info.compressed),
+		"--container-workspace-info", shellescape.Quote(info.workspaceConfigCompressed),
 	}
 
 	r.addSetupFlags(&args)
+	if info.skipLifecycleCommands {
+		args = append(args, "--skip-lifecycle-commands")
+	}
 	return strings.Join(args, " ")
 }
 
@@ -251,7 +268,10 @@ func (r *runner) executeSetup(
 		)
 	}
 
-	sshTunnelCmd := r.buildSSHTunnelCommand()
+	sshTunnelCmd, err := r.buildSSHTunnelCommand()
+	if err != nil {
+		return nil, err
+	}
 
 	agentInjectFunc := func(
 		cancelCtx context.Context,
@@ -281,11 +301,22 @@ func (r *runner) executeSetup(
 	})
 }
 
-func (r *runner) buildSSHTunnelCommand() string {
+func (r *runner) buildSSHTunnelCommand() (string, error) {
+	algorithms, err := sshServer.ParseAlgorithms(
+		r.WorkspaceConfig.CLIOptions.SSHDAllowedCiphers,
+		r.WorkspaceConfig.CLIOptions.SSHDAllowedKeyExchanges,
+	)
+	if err != nil {
+		return "", err
+	}
+
 	args := []string{
 		shellescape.Quote(agent.ContainerDevPodHelperLocation),
 		"helper", "ssh-server", "--stdio",
 	}
+	for _, arg := range algorithms.Args() {
+		args = append(args, shellescape.Quote(arg))
+	}
 
 	if ide.ReusesAuthSock(r.WorkspaceConfig.Workspace.IDE.Name) {
 		args = append(
@@ -297,7 +328,7 @@ func (r *runner) buildSSHTunnelCommand() string {
 	if r.isDebugMode() {
 		args = append(args, "--debug")
 	}
-	return strings.Join(args, " ")
+	return strings.Join(args, " "), nil
 }
 
 func getRelativeDevContainerJson(origin, localWorkspaceFolder string) string {
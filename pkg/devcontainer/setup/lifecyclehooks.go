@@ -51,9 +51,16 @@ func resolveLifecycleEnv(
 }
 
 // RunPreAttachHooks runs lifecycle hooks up to and including postStartCommand.
-// These must complete before the IDE can be opened.
-func RunPreAttachHooks(ctx context.Context, setupInfo *config.Result, log log.Logger) error {
+// These must complete before the IDE can be opened. lifecycleEnv are KEY=VALUE pairs
+// that are only set for these hooks and never persisted in the container environment.
+func RunPreAttachHooks(
+	ctx context.Context,
+	setupInfo *config.Result,
+	lifecycleEnv []string,
+	log log.Logger,
+) error {
 	env := resolveLifecycleEnv(ctx, setupInfo, log)
+	env.remoteEnv = withLifecycleEnv(env.remoteEnv, lifecycleEnv, log)
 	containerDetails := setupInfo.ContainerDetails
 	mergedConfig := setupInfo.MergedConfig
 
@@ -122,6 +129,26 @@ func RunPostAttachHooks(ctx context.Context, setupInfo *config.Result, log log.L
 	)
 }
 
+// withLifecycleEnv returns a copy of remoteEnv extended by the given KEY=VALUE pairs.
+func withLifecycleEnv(remoteEnv map[string]string, lifecycleEnv []string, log log.Logger) map[string]string {
+	if len(lifecycleEnv) == 0 {
+		return remoteEnv
+	}
+
+	env := maps.Clone(remoteEnv)
+	if env == nil {
+		env = map[string]string{}
+	}
+	extraEnv := config.ListToObject(lifecycleEnv)
+	for _, key := range slices.Sorted(maps.Keys(extraEnv)) {
+		// only the key is logged, the values are commonly secrets
+		log.Debugf("setting devcontainer env %s for lifecycle hooks", key)
+		env[key] = extraEnv[key]
+	}
+
+	return env
+}
+
 func run(
 	commands []types.LifecycleHook,
 	remoteUser, dir string,
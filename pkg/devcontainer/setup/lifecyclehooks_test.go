@@ -95,13 +95,21 @@ func (s *LifecycleHookTestSuite) TestLifecycleHooksNoOpWithEmptyConfig() {
 	}
 
 	// Both functions should return nil with empty config (no commands to run)
-	err := RunPreAttachHooks(ctx, result, log.Default)
+	err := RunPreAttachHooks(ctx, result, nil, log.Default)
 	assert.NoError(s.T(), err)
 
 	err = RunPostAttachHooks(ctx, result, log.Default)
 	assert.NoError(s.T(), err)
 }
 
+func (s *LifecycleHookTestSuite) TestWithLifecycleEnvDoesNotModifyRemoteEnv() {
+	remoteEnv := map[string]string{"FOO": "bar"}
+
+	env := withLifecycleEnv(remoteEnv, []string{"TOKEN=a=b", "FOO=baz"}, log.Default)
+	assert.Equal(s.T(), map[string]string{"FOO": "baz", "TOKEN": "a=b"}, env)
+	assert.Equal(s.T(), map[string]string{"FOO": "bar"}, remoteEnv)
+}
+
 func TestLifecycleHookTestSuite(t *testing.T) {
 	suite.Run(t, new(LifecycleHookTestSuite))
 }
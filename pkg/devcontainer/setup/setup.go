@@ -21,6 +21,7 @@ import (
 	pkgconfig "github.com/skevetter/devpod/pkg/config"
 	copy2 "github.com/skevetter/devpod/pkg/copy"
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/docker"
 	"github.com/skevetter/devpod/pkg/envfile"
 	"github.com/skevetter/devpod/pkg/gitcredentials"
 	"github.com/skevetter/log"
@@ -31,10 +32,15 @@ import (
 type ContainerSetupConfig struct {
 	SetupInfo         *config.Result
 	ExtraWorkspaceEnv []string
+	LifecycleEnv      []string
 	ChownProjects     bool
 	PlatformOptions   *devsy.PlatformOptions
+	RegistryMirror    string
 	TunnelClient      tunnel.TunnelClient
 	Log               log.Logger
+
+	// SkipLifecycleCommands skips the lifecycle hooks up to and including postStartCommand
+	SkipLifecycleCommands bool
 }
 
 // SetupContainerPreAttach runs container setup up to and including postStartCommand.
@@ -56,9 +62,13 @@ func SetupContainerPreAttach(ctx context.Context, cfg *ContainerSetupConfig) err
 
 	setupOptionalFeatures(ctx, cfg)
 
-	cfg.Log.Debugf("running pre-attach lifecycle hooks")
-	if err := RunPreAttachHooks(ctx, cfg.SetupInfo, cfg.Log); err != nil {
-		return fmt.Errorf("lifecycle hooks pre-attach: %w", err)
+	if cfg.SkipLifecycleCommands {
+		cfg.Log.Infof("skipping pre-attach lifecycle hooks because the container is already running")
+	} else {
+		cfg.Log.Debugf("running pre-attach lifecycle hooks")
+		if err := RunPreAttachHooks(ctx, cfg.SetupInfo, cfg.LifecycleEnv, cfg.Log); err != nil {
+			return fmt.Errorf("lifecycle hooks pre-attach: %w", err)
+		}
 	}
 
 	cfg.Log.Debugf("pre-attach setup completed")
@@ -167,6 +177,26 @@ func setupOptionalFeatures(ctx context.Context, cfg *ContainerSetupConfig) {
 			cfg.Log.Errorf("setup platform git credentials: %v", err)
 		}
 	}
+
+	if cfg.RegistryMirror != "" {
+		if err := setupRegistryMirror(ctx, cfg.RegistryMirror); err != nil {
+			cfg.Log.Errorf("setup registry mirror: %v", err)
+		}
+	}
+}
+
+// setupRegistryMirror adds the registry mirror to the docker daemon config of the container,
+// which is picked up by docker in docker setups, e.g. the docker-in-docker feature. The daemon
+// may already be started by the entrypoint of the container, so it reloads its config.
+func setupRegistryMirror(ctx context.Context, registryMirror string) error {
+	err := docker.UpdateDaemonConfig(docker.DaemonConfigFile, func(config map[string]any) {
+		docker.AddRegistryMirror(config, registryMirror)
+	})
+	if err != nil {
+		return err
+	}
+
+	return docker.ReloadDaemon(ctx)
 }
 
 func linkRootHome(setupInfo *config.Result) error {
@@ -0,0 +1,65 @@
+package devcontainer
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareSetupInfoSkipLifecycleCommands(t *testing.T) {
+	tests := []struct {
+		name       string
+		skip       bool
+		wasRunning bool
+		expected   bool
+	}{
+		{name: "flag not set", skip: false, wasRunning: true, expected: false},
+		{name: "container started by up", skip: true, wasRunning: false, expected: false},
+		{name: "container already running", skip: true, wasRunning: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &runner{
+				WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+					Workspace:  &provider2.Workspace{ID: "my-workspace"},
+					CLIOptions: provider2.CLIOptions{SkipLifecycleCommands: tt.skip},
+				},
+			}
+
+			info, err := r.prepareSetupInfo(&setupContainerParams{
+				rawConfig:    &config.DevContainerConfig{},
+				mergedConfig: &config.MergedDevContainerConfig{},
+				wasRunning:   tt.wasRunning,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, info.skipLifecycleCommands)
+		})
+	}
+}
+
+func TestBuildSSHTunnelCommandAlgorithms(t *testing.T) {
+	r := &runner{
+		WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+			Workspace: &provider2.Workspace{ID: "my-workspace"},
+			CLIOptions: provider2.CLIOptions{
+				SSHDAllowedCiphers:      "aes256-gcm@openssh.com",
+				SSHDAllowedKeyExchanges: "curve25519-sha256",
+			},
+		},
+		Log: log.Discard,
+	}
+
+	command, err := r.buildSSHTunnelCommand()
+	require.NoError(t, err)
+	assert.Contains(t, command, "helper ssh-server --stdio --ciphers aes256-gcm@openssh.com "+
+		"--key-exchanges curve25519-sha256")
+
+	r.WorkspaceConfig.CLIOptions.SSHDAllowedCiphers = "unknown"
+	_, err = r.buildSSHTunnelCommand()
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/skevetter/devpod/pkg/devcontainer/metadata"
 	"github.com/skevetter/devpod/pkg/driver"
 	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/version"
 )
 
 var dockerlessImage = "ghcr.io/loft-sh/dockerless:0.2.0"
@@ -83,6 +84,8 @@ func (r *runner) runSingleContainer(
 		options:             options,
 	}
 
+	wasRunning := !options.Recreate && containerDetails != nil &&
+		strings.ToLower(containerDetails.State.Status) == "running"
 	if options.Recreate && parsedConfig.Config.ContainerID != "" {
 		return nil, fmt.Errorf("cannot recreate container not created by DevPod")
 	} else if !options.Recreate && containerDetails != nil {
@@ -100,6 +103,7 @@ func (r *runner) runSingleContainer(
 		mergedConfig:        resolved.mergedConfig,
 		substitutionContext: substitutionContext,
 		timeout:             timeout,
+		wasRunning:          wasRunning,
 	})
 }
 
@@ -294,7 +298,7 @@ func (r *runner) injectDaemonEntrypoint(
 	r.Log.Debugf("Platform config detected, injecting DevPod daemon entrypoint.")
 
 	data, err := agent.GetEncodedWorkspaceDaemonConfig(
-		p.options.Platform,
+		p.options.CLIOptions,
 		r.WorkspaceConfig.Workspace,
 		p.substitutionContext,
 		mergedConfig,
@@ -474,6 +478,12 @@ func (r *runner) getRunOptions(
 		metadata.ImageMetadataLabel + "=" + string(marshalled),
 		config.UserLabel + "=" + imageUser,
 	}
+	if tags := r.workspaceTags(); tags != "" {
+		labels = append(labels, config.TagsLabel+"="+tags)
+	}
+	if buildInfo.BaseImageID != "" {
+		labels = append(labels, config.BaseImageIDLabel+"="+buildInfo.BaseImageID)
+	}
 
 	user := imageUser
 	if mergedConfig.ContainerUser != "" {
@@ -485,26 +495,49 @@ func (r *runner) getRunOptions(
 		uid = r.WorkspaceConfig.Workspace.UID
 	}
 
+	network, createNetwork, imagePullPolicy := "", false, ""
+	if r.WorkspaceConfig != nil {
+		network = r.WorkspaceConfig.CLIOptions.Network
+		createNetwork = r.WorkspaceConfig.CLIOptions.CreateNetwork
+		imagePullPolicy = r.WorkspaceConfig.CLIOptions.ImagePullPolicy
+	}
+	// images built or extended by DevPod only exist locally
+	if buildInfo.ImageName != mergedConfig.Image {
+		imagePullPolicy = ""
+	}
+
 	return &driver.RunOptions{
-		UID:            uid,
-		Image:          buildInfo.ImageName,
-		User:           user,
-		Entrypoint:     entrypoint,
-		Cmd:            cmd,
-		Env:            mergedConfig.ContainerEnv,
-		CapAdd:         mergedConfig.CapAdd,
-		Labels:         labels,
-		Privileged:     mergedConfig.Privileged,
-		Init:           mergedConfig.Init,
-		WorkspaceMount: &workspaceMountParsed,
-		SecurityOpt:    mergedConfig.SecurityOpt,
-		Mounts:         mergedConfig.Mounts,
-		Userns:         substitutionContext.Userns,
-		UidMap:         substitutionContext.UidMap,
-		GidMap:         substitutionContext.GidMap,
+		UID:             uid,
+		Network:         network,
+		CreateNetwork:   createNetwork,
+		ImagePullPolicy: imagePullPolicy,
+		Image:           buildInfo.ImageName,
+		User:            user,
+		Entrypoint:      entrypoint,
+		Cmd:             cmd,
+		Env:             mergedConfig.ContainerEnv,
+		CapAdd:          mergedConfig.CapAdd,
+		Labels:          labels,
+		Privileged:      mergedConfig.Privileged,
+		Init:            mergedConfig.Init,
+		WorkspaceMount:  &workspaceMountParsed,
+		SecurityOpt:     mergedConfig.SecurityOpt,
+		Mounts:          mergedConfig.Mounts,
+		Userns:          substitutionContext.Userns,
+		UidMap:          substitutionContext.UidMap,
+		GidMap:          substitutionContext.GidMap,
 	}, nil
 }
 
+// workspaceTags returns the workspace tags in the format of the tags label.
+func (r *runner) workspaceTags() string {
+	if r.WorkspaceConfig == nil || r.WorkspaceConfig.Workspace == nil {
+		return ""
+	}
+
+	return strings.Join(r.WorkspaceConfig.Workspace.Tags, ",")
+}
+
 // add environment variables that signals that we are in a remote container
 // (vscode compatibility) and specifically that we are using devpod.
 func (r *runner) addExtraEnvVars(env map[string]string) map[string]string {
@@ -514,6 +547,7 @@ func (r *runner) addExtraEnvVars(env map[string]string) map[string]string {
 
 	env[DevPodExtraEnvVar] = "true"
 	env[RemoteContainersExtraEnvVar] = "true"
+	env[pkgconfig.EnvVersion] = version.GetVersion()
 	if r.WorkspaceConfig != nil && r.WorkspaceConfig.Workspace != nil &&
 		r.WorkspaceConfig.Workspace.ID != "" {
 		env[pkgconfig.EnvWorkspaceID] = r.WorkspaceConfig.Workspace.ID
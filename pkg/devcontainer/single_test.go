@@ -0,0 +1,82 @@
+package devcontainer
+
+import (
+	"testing"
+
+	pkgconfig "github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/devcontainer/config"
+	"github.com/skevetter/devpod/pkg/driver"
+	provider2 "github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddExtraEnvVars(t *testing.T) {
+	r := &runner{
+		WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+			Workspace: &provider2.Workspace{ID: "my-workspace"},
+		},
+	}
+
+	env := r.addExtraEnvVars(map[string]string{"FOO": "bar"})
+	assert.Equal(t, "bar", env["FOO"])
+	assert.Equal(t, "true", env[DevPodExtraEnvVar])
+	assert.Equal(t, "my-workspace", env[pkgconfig.EnvWorkspaceID])
+	assert.Equal(t, version.GetVersion(), env[pkgconfig.EnvVersion])
+	assert.NotContains(t, env, pkgconfig.EnvWorkspaceUID)
+}
+
+func TestGetRunOptionsRecordsBaseImageID(t *testing.T) {
+	r := &runner{
+		WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+			Workspace: &provider2.Workspace{ID: "my-workspace"},
+		},
+	}
+
+	runOptions, err := r.getRunOptions(
+		&config.MergedDevContainerConfig{},
+		&config.SubstitutionContext{},
+		&config.BuildInfo{
+			ImageMetadata: &config.ImageMetadataConfig{},
+			ImageName:     "vsc-my-workspace-features",
+			BaseImageID:   "sha256:base",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "vsc-my-workspace-features", runOptions.Image)
+	assert.Contains(t, runOptions.Labels, config.BaseImageIDLabel+"=sha256:base")
+}
+
+func TestGetRunOptionsImagePullPolicy(t *testing.T) {
+	r := &runner{
+		WorkspaceConfig: &provider2.AgentWorkspaceInfo{
+			Workspace:  &provider2.Workspace{ID: "my-workspace"},
+			CLIOptions: provider2.CLIOptions{ImagePullPolicy: driver.ImagePullPolicyAlways},
+		},
+	}
+	mergedConfig := &config.MergedDevContainerConfig{}
+	mergedConfig.Image = "mcr.microsoft.com/devcontainers/go"
+
+	runOptions, err := r.getRunOptions(
+		mergedConfig,
+		&config.SubstitutionContext{},
+		&config.BuildInfo{
+			ImageMetadata: &config.ImageMetadataConfig{},
+			ImageName:     "mcr.microsoft.com/devcontainers/go",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, driver.ImagePullPolicyAlways, runOptions.ImagePullPolicy)
+
+	runOptions, err = r.getRunOptions(
+		mergedConfig,
+		&config.SubstitutionContext{},
+		&config.BuildInfo{
+			ImageMetadata: &config.ImageMetadataConfig{},
+			ImageName:     "vsc-my-workspace-features",
+		},
+	)
+	require.NoError(t, err)
+	assert.Empty(t, runOptions.ImagePullPolicy, "extended images only exist locally")
+}
@@ -0,0 +1,115 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DaemonConfigFile is the configuration file of a rootful docker daemon.
+const DaemonConfigFile = "/etc/docker/daemon.json"
+
+// UpdateDaemonConfig reads the docker daemon config at configPath, applies update
+// and writes it back. A missing config file is created.
+func UpdateDaemonConfig(configPath string, update func(config map[string]any)) error {
+	config := make(map[string]any)
+	// #nosec G304 -- configPath is controlled by the application
+	data, err := os.ReadFile(configPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read existing config: %w", err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &config); err != nil {
+			return fmt.Errorf("parse existing config: %w", err)
+		}
+	}
+
+	update(config)
+
+	mergedData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+
+	// #nosec G301 -- directory needs to be accessible by docker daemon
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+
+	// #nosec G306 -- daemon.json needs to be readable by docker daemon
+	if err := os.WriteFile(configPath, mergedData, 0o644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+
+	return nil
+}
+
+// AddRegistryMirror adds mirror to the registry-mirrors of the docker daemon config,
+// mirrors that are configured already are kept.
+func AddRegistryMirror(config map[string]any, mirror string) {
+	mirrors := []any{}
+	if existing, ok := config["registry-mirrors"].([]any); ok {
+		mirrors = existing
+	}
+	if slices.Contains(mirrors, any(mirror)) {
+		return
+	}
+
+	config["registry-mirrors"] = append(mirrors, mirror)
+}
+
+// ReloadDaemon makes a running docker daemon reload its config, which includes the
+// registry mirrors. A daemon that isn't running yet reads the config on start.
+func ReloadDaemon(ctx context.Context) error {
+	err := exec.CommandContext(ctx, "pkill", "-HUP", "dockerd").Run()
+	if err != nil {
+		// pkill returns exit code 1 if no processes matched
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil // No dockerd process found, nothing to reload
+		}
+		return err
+	}
+	return nil
+}
+
+// BuildkitdConfig returns a buildkitd.toml that pulls docker hub images through mirror.
+func BuildkitdConfig(mirror string) (string, error) {
+	parsed, err := url.Parse(mirror)
+	if err != nil {
+		return "", fmt.Errorf("parse registry mirror: %w", err)
+	}
+
+	config := &strings.Builder{}
+	fmt.Fprintf(config, "[registry.\"docker.io\"]\n  mirrors = [%q]\n", parsed.Host)
+	if parsed.Scheme == "http" {
+		fmt.Fprintf(config, "\n[registry.%q]\n  http = true\n", parsed.Host)
+	}
+	return config.String(), nil
+}
+
+// ValidateRegistryMirror returns an error if mirror is not an http or https url,
+// which is what the docker daemon expects.
+func ValidateRegistryMirror(mirror string) error {
+	if mirror == "" {
+		return nil
+	}
+
+	parsed, err := url.Parse(mirror)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf(
+			"invalid registry mirror %q, expected an url like https://mirror.example.com",
+			mirror,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDaemonConfigAddRegistryMirror(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "docker", "daemon.json")
+	addMirror := func(config map[string]any) {
+		AddRegistryMirror(config, "https://mirror.example.com")
+	}
+
+	require.NoError(t, UpdateDaemonConfig(configPath, addMirror))
+	existing := `{"debug":true,"registry-mirrors":["https://other.example.com"]}`
+	require.NoError(t, os.WriteFile(configPath, []byte(existing), 0o600))
+	require.NoError(t, UpdateDaemonConfig(configPath, addMirror))
+	require.NoError(t, UpdateDaemonConfig(configPath, addMirror))
+
+	out, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"debug": true,
+		"registry-mirrors": ["https://other.example.com", "https://mirror.example.com"]
+	}`, string(out))
+}
+
+func TestValidateRegistryMirror(t *testing.T) {
+	assert.NoError(t, ValidateRegistryMirror(""))
+	assert.NoError(t, ValidateRegistryMirror("https://mirror.example.com"))
+	assert.NoError(t, ValidateRegistryMirror("http://10.0.0.1:5000"))
+	assert.Error(t, ValidateRegistryMirror("mirror.example.com"))
+	assert.Error(t, ValidateRegistryMirror("ftp://mirror.example.com"))
+}
+
+func TestBuildkitdConfig(t *testing.T) {
+	config, err := BuildkitdConfig("https://mirror.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "[registry.\"docker.io\"]\n  mirrors = [\"mirror.example.com\"]\n", config)
+
+	config, err = BuildkitdConfig("http://10.0.0.1:5000")
+	require.NoError(t, err)
+	assert.Equal(t, `[registry."docker.io"]
+  mirrors = ["10.0.0.1:5000"]
+
+[registry."10.0.0.1:5000"]
+  http = true
+`, config)
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
@@ -124,6 +125,36 @@ func (r *DockerHelper) DeleteVolume(ctx context.Context, volume string) error {
 	return nil
 }
 
+// EnsureNetwork verifies that the given network exists. If it doesn't and create is true
+// the network is created, otherwise an error is returned.
+func (r *DockerHelper) EnsureNetwork(ctx context.Context, network string, create bool) error {
+	if network == "" {
+		return nil
+	}
+
+	// the name filter is a regular expression
+	nameFilter := "name=^" + regexp.QuoteMeta(network) + "$"
+	out, err := r.buildCmd(ctx, "network", "ls", "-q", "--filter", nameFilter).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("list networks: %s: %w", string(out), err)
+	} else if len(strings.TrimSpace(string(out))) > 0 {
+		return nil
+	} else if !create {
+		return fmt.Errorf(
+			"docker network %s doesn't exist, create it or use --create-network",
+			network,
+		)
+	}
+
+	r.Log.Infof("creating docker network %s", network)
+	out, err = r.buildCmd(ctx, "network", "create", network).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("create network %s: %s: %w", network, string(out), err)
+	}
+
+	return nil
+}
+
 func (r *DockerHelper) Stop(ctx context.Context, id string) error {
 	out, err := r.buildCmd(ctx, "stop", id).CombinedOutput()
 	if err != nil {
@@ -133,6 +164,62 @@ func (r *DockerHelper) Stop(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *DockerHelper) Kill(ctx context.Context, id string) error {
+	out, err := r.buildCmd(ctx, "kill", id).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", string(out), err)
+	}
+
+	return nil
+}
+
+// Copy copies files between the container and the local filesystem with docker cp. One of src
+// and dst must be in the form container:path.
+func (r *DockerHelper) Copy(ctx context.Context, src, dst string) error {
+	out, err := r.buildCmd(ctx, "cp", src, dst).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(string(out)), err)
+	}
+
+	return nil
+}
+
+// UpdateResources changes the cpu and memory limits of the container. Empty limits are left
+// unchanged.
+func (r *DockerHelper) UpdateResources(ctx context.Context, id, cpus, memory string) error {
+	args := []string{"update"}
+	if cpus != "" {
+		args = append(args, "--cpus", cpus)
+	}
+	if memory != "" {
+		args = append(args, "--memory", memory)
+	}
+	args = append(args, id)
+
+	out, err := r.buildCmd(ctx, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", string(out), err)
+	}
+
+	return nil
+}
+
+// Commit creates the image from the container, applying the given Dockerfile instructions.
+func (r *DockerHelper) Commit(ctx context.Context, id, image string, changes []string) error {
+	args := []string{"commit"}
+	for _, change := range changes {
+		args = append(args, "--change", change)
+	}
+	args = append(args, id, image)
+
+	out, err := r.buildCmd(ctx, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", string(out), err)
+	}
+
+	return nil
+}
+
 func (r *DockerHelper) Pull(
 	ctx context.Context,
 	image string,
@@ -451,6 +538,34 @@ func (r *DockerHelper) GetContainerLogs(
 	return cmd.Run()
 }
 
+// ContainerChange is a single filesystem change reported by docker diff.
+type ContainerChange struct {
+	// Kind is either C (changed), A (added) or D (deleted)
+	Kind string `json:"kind"`
+	Path string `json:"path"`
+}
+
+// Diff returns the filesystem changes of the container relative to its image.
+func (r *DockerHelper) Diff(ctx context.Context, id string) ([]ContainerChange, error) {
+	out, err := r.buildCmd(ctx, "diff", id).Output()
+	if err != nil {
+		return nil, fmt.Errorf("diff container: %w", command.WrapCommandError(out, err))
+	}
+
+	changes := []ContainerChange{}
+	scan := scanner.NewScanner(bytes.NewReader(out))
+	for scan.Scan() {
+		kind, path, found := strings.Cut(strings.TrimSpace(scan.Text()), " ")
+		if !found {
+			continue
+		}
+
+		changes = append(changes, ContainerChange{Kind: kind, Path: path})
+	}
+
+	return changes, nil
+}
+
 func (r *DockerHelper) buildCmd(ctx context.Context, args ...string) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, r.DockerCommand, args...)
 	if r.Environment != nil {
@@ -0,0 +1,73 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ContainerNetworkSettings are the network settings reported by docker inspect.
+type ContainerNetworkSettings struct {
+	ID              string `json:"Id"`
+	Name            string `json:"Name"`
+	NetworkSettings struct {
+		IPAddress string                          `json:"IPAddress"`
+		Networks  map[string]ContainerNetworkInfo `json:"Networks"`
+	} `json:"NetworkSettings"`
+}
+
+// ContainerNetworkInfo is the endpoint of a container in a single network.
+type ContainerNetworkInfo struct {
+	IPAddress string `json:"IPAddress"`
+}
+
+// ContainerIPs returns the ip addresses of the given containers. If network is empty the ip of
+// the default bridge network is used, or the ips of all networks the container is connected to.
+func (r *DockerHelper) ContainerIPs(ctx context.Context, ids []string, network string) ([]string, error) {
+	settings := []ContainerNetworkSettings{}
+	err := r.Inspect(ctx, ids, "container", &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := []string{}
+	for _, container := range settings {
+		containerIPs, err := container.IPs(network)
+		if err != nil {
+			return nil, err
+		}
+		ips = append(ips, containerIPs...)
+	}
+
+	return ips, nil
+}
+
+// IPs returns the ip addresses of the container in network, see ContainerIPs.
+func (s ContainerNetworkSettings) IPs(network string) ([]string, error) {
+	name := strings.TrimPrefix(s.Name, "/")
+	if network != "" {
+		info, ok := s.NetworkSettings.Networks[network]
+		if !ok || info.IPAddress == "" {
+			return nil, fmt.Errorf("container %s is not connected to network %s", name, network)
+		}
+
+		return []string{info.IPAddress}, nil
+	} else if s.NetworkSettings.IPAddress != "" {
+		return []string{s.NetworkSettings.IPAddress}, nil
+	}
+
+	ips := []string{}
+	for _, networkName := range slices.Sorted(maps.Keys(s.NetworkSettings.Networks)) {
+		ip := s.NetworkSettings.Networks[networkName].IPAddress
+		if ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("container %s has no ip address", name)
+	}
+
+	return ips, nil
+}
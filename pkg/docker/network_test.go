@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerNetworkSettingsIPs(t *testing.T) {
+	out := []byte(`[
+  {"Id":"abc","Name":"/my-workspace","NetworkSettings":{"IPAddress":"172.17.0.2","Networks":{"bridge":{"IPAddress":"172.17.0.2"},"dev":{"IPAddress":"10.0.0.5"}}}},
+  {"Id":"def","Name":"/db","NetworkSettings":{"IPAddress":"","Networks":{"project_default":{"IPAddress":"172.20.0.3"},"backend":{"IPAddress":"172.21.0.3"}}}}
+]`)
+	settings := []ContainerNetworkSettings{}
+	require.NoError(t, json.Unmarshal(out, &settings))
+	require.Len(t, settings, 2)
+
+	ips, err := settings[0].IPs("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"172.17.0.2"}, ips)
+
+	ips, err = settings[0].IPs("dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.5"}, ips)
+
+	ips, err = settings[1].IPs("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"172.21.0.3", "172.20.0.3"}, ips)
+
+	_, err = settings[1].IPs("dev")
+	assert.EqualError(t, err, "container db is not connected to network dev")
+}
@@ -0,0 +1,72 @@
+package docker
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// PortBinding is a port exposed by a container and the host address it is published on, if any.
+type PortBinding struct {
+	Container     string `json:"container"`
+	ContainerPort string `json:"containerPort"`
+	HostIP        string `json:"hostIP,omitempty"`
+	HostPort      string `json:"hostPort,omitempty"`
+}
+
+// containerPorts are the port settings reported by docker inspect.
+type containerPorts struct {
+	Name            string `json:"Name"`
+	NetworkSettings struct {
+		Ports map[string][]struct {
+			HostIP   string `json:"HostIp"`
+			HostPort string `json:"HostPort"`
+		} `json:"Ports"`
+	} `json:"NetworkSettings"`
+}
+
+// PortBindings returns the exposed and published ports of the given containers.
+func (r *DockerHelper) PortBindings(ctx context.Context, ids []string) ([]PortBinding, error) {
+	containers := []containerPorts{}
+	err := r.Inspect(ctx, ids, "container", &containers)
+	if err != nil {
+		return nil, err
+	}
+
+	return portBindings(containers), nil
+}
+
+// portBindings flattens the port settings, a port that is exposed but not published has no
+// host address.
+func portBindings(containers []containerPorts) []PortBinding {
+	bindings := []PortBinding{}
+	for _, container := range containers {
+		name := strings.TrimPrefix(container.Name, "/")
+		for containerPort, hostBindings := range container.NetworkSettings.Ports {
+			if len(hostBindings) == 0 {
+				bindings = append(bindings, PortBinding{
+					Container:     name,
+					ContainerPort: containerPort,
+				})
+				continue
+			}
+
+			for _, hostBinding := range hostBindings {
+				bindings = append(bindings, PortBinding{
+					Container:     name,
+					ContainerPort: containerPort,
+					HostIP:        hostBinding.HostIP,
+					HostPort:      hostBinding.HostPort,
+				})
+			}
+		}
+	}
+
+	slices.SortFunc(bindings, func(a, b PortBinding) int {
+		return strings.Compare(
+			a.Container+"/"+a.ContainerPort+"/"+a.HostIP,
+			b.Container+"/"+b.ContainerPort+"/"+b.HostIP,
+		)
+	})
+	return bindings
+}
@@ -0,0 +1,25 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortBindings(t *testing.T) {
+	out := []byte(`[
+  {"Name":"/my-workspace","NetworkSettings":{"Ports":{"8080/tcp":[{"HostIp":"0.0.0.0","HostPort":"8080"},{"HostIp":"::","HostPort":"8080"}],"9000/tcp":null}}},
+  {"Name":"/db","NetworkSettings":{"Ports":{"5432/tcp":[{"HostIp":"127.0.0.1","HostPort":"15432"}]}}}
+]`)
+	containers := []containerPorts{}
+	require.NoError(t, json.Unmarshal(out, &containers))
+
+	assert.Equal(t, []PortBinding{
+		{Container: "db", ContainerPort: "5432/tcp", HostIP: "127.0.0.1", HostPort: "15432"},
+		{Container: "my-workspace", ContainerPort: "8080/tcp", HostIP: "0.0.0.0", HostPort: "8080"},
+		{Container: "my-workspace", ContainerPort: "8080/tcp", HostIP: "::", HostPort: "8080"},
+		{Container: "my-workspace", ContainerPort: "9000/tcp"},
+	}, portBindings(containers))
+}
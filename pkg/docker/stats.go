@@ -0,0 +1,59 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/command"
+	"github.com/skevetter/log/scanner"
+)
+
+// ContainerStats is a single resource usage sample reported by docker stats.
+type ContainerStats struct {
+	ID       string `json:"ID"`
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+	NetIO    string `json:"NetIO"`
+	BlockIO  string `json:"BlockIO"`
+	PIDs     string `json:"PIDs"`
+}
+
+// Stats returns a single resource usage sample of the given containers.
+func (r *DockerHelper) Stats(ctx context.Context, ids []string) ([]ContainerStats, error) {
+	args := append([]string{"stats", "--no-stream", "--no-trunc", "--format", "{{json .}}"}, ids...)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	err := r.Run(ctx, args, nil, stdout, stderr)
+	if err != nil {
+		return nil, fmt.Errorf("container stats: %w", command.WrapCommandError(stderr.Bytes(), err))
+	}
+
+	return ParseStats(stdout.Bytes())
+}
+
+// ParseStats parses the json lines printed by docker stats --format '{{json .}}'.
+func ParseStats(out []byte) ([]ContainerStats, error) {
+	stats := []ContainerStats{}
+	scan := scanner.NewScanner(bytes.NewReader(out))
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" {
+			continue
+		}
+
+		sample := ContainerStats{}
+		err := json.Unmarshal([]byte(line), &sample)
+		if err != nil {
+			return nil, fmt.Errorf("parse container stats %s: %w", line, err)
+		}
+		stats = append(stats, sample)
+	}
+
+	return stats, nil
+}
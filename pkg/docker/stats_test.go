@@ -0,0 +1,26 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStats(t *testing.T) {
+	out := []byte(`{"BlockIO":"0B / 0B","CPUPerc":"0.15%","ID":"abc","MemPerc":"1.20%","MemUsage":"95MiB / 7.6GiB","Name":"my-workspace","NetIO":"1kB / 0B","PIDs":"12"}
+
+{"BlockIO":"1MB / 0B","CPUPerc":"2.00%","ID":"def","MemPerc":"0.50%","MemUsage":"40MiB / 7.6GiB","Name":"db","NetIO":"2kB / 1kB","PIDs":"30"}
+`)
+
+	stats, err := ParseStats(out)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "abc", stats[0].ID)
+	assert.Equal(t, "0.15%", stats[0].CPUPerc)
+	assert.Equal(t, "95MiB / 7.6GiB", stats[0].MemUsage)
+	assert.Equal(t, "db", stats[1].Name)
+
+	_, err = ParseStats([]byte("not json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/command"
+	"github.com/skevetter/log/scanner"
+)
+
+// topColumns are the ps columns printed by Top, the command has to stay the last column.
+const topColumns = "user,pid,ppid,%cpu,%mem,rss,stat,etime,args"
+
+// ContainerProcess is a single process reported by ps in a container.
+type ContainerProcess struct {
+	User    string `json:"user"`
+	PID     int    `json:"pid"`
+	PPID    int    `json:"ppid"`
+	CPUPerc string `json:"cpuPerc"`
+	MemPerc string `json:"memPerc"`
+	RSS     string `json:"rss"`
+	Stat    string `json:"stat"`
+	Elapsed string `json:"elapsed"`
+	Command string `json:"command"`
+}
+
+// Top returns the processes of the container sorted by cpu usage.
+func (r *DockerHelper) Top(ctx context.Context, id string) ([]ContainerProcess, error) {
+	out, err := r.buildCmd(ctx, "exec", id, "ps", "-eo", topColumns, "--sort=-%cpu").Output()
+	if err != nil {
+		return nil, fmt.Errorf("list container processes: %w", command.WrapCommandError(out, err))
+	}
+
+	return ParseProcesses(out)
+}
+
+// ParseProcesses parses the output of ps -eo with the columns of Top.
+func ParseProcesses(out []byte) ([]ContainerProcess, error) {
+	processes := []ContainerProcess{}
+	scan := scanner.NewScanner(bytes.NewReader(out))
+	header := true
+	for scan.Scan() {
+		line := strings.TrimSpace(scan.Text())
+		if line == "" {
+			continue
+		} else if header {
+			header = false
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 9 {
+			return nil, fmt.Errorf("unexpected ps output %s", line)
+		}
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("parse pid %s: %w", fields[1], err)
+		}
+		ppid, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("parse ppid %s: %w", fields[2], err)
+		}
+
+		processes = append(processes, ContainerProcess{
+			User:    fields[0],
+			PID:     pid,
+			PPID:    ppid,
+			CPUPerc: fields[3],
+			MemPerc: fields[4],
+			RSS:     fields[5],
+			Stat:    fields[6],
+			Elapsed: fields[7],
+			Command: strings.Join(fields[8:], " "),
+		})
+	}
+
+	return processes, nil
+}
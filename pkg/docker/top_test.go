@@ -0,0 +1,34 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcesses(t *testing.T) {
+	out := []byte(`USER         PID    PPID %CPU %MEM   RSS STAT     ELAPSED COMMAND
+vscode       120       1 12.5  3.1 51200 Sl         05:12 node /home/vscode/server.js --port 3000
+root           1       0  0.0  0.1  1024 Ss      01:02:03 /bin/sh -c sleep infinity
+`)
+
+	processes, err := ParseProcesses(out)
+	require.NoError(t, err)
+	require.Len(t, processes, 2)
+	assert.Equal(t, ContainerProcess{
+		User:    "vscode",
+		PID:     120,
+		PPID:    1,
+		CPUPerc: "12.5",
+		MemPerc: "3.1",
+		RSS:     "51200",
+		Stat:    "Sl",
+		Elapsed: "05:12",
+		Command: "node /home/vscode/server.js --port 3000",
+	}, processes[0])
+	assert.Equal(t, 0, processes[1].PPID)
+
+	_, err = ParseProcesses([]byte("USER PID\nroot x y\n"))
+	assert.Error(t, err)
+}
@@ -14,6 +14,8 @@ import (
 
 var syntaxDirectiveRegex = regexp.MustCompile(`(?m)^[\s\t]*#[\s\t]*syntax=.*$`)
 
+var runInstructionRegex = regexp.MustCompile(`(?i)^([\s\t]*RUN)\b`)
+
 func (d *Dockerfile) FindUserStatement(
 	buildArgs, baseImageEnv map[string]string,
 	target string,
@@ -300,6 +302,71 @@ func EnsureFinalStageName(dockerfileContent, defaultLastStageName string) (strin
 	return defaultLastStageName, ReplaceInDockerfile(dockerfileContent, lastChild), nil
 }
 
+// AddCacheMounts adds a BuildKit cache mount for every given target to all RUN instructions.
+// A target can either be a path or a cache mount specification, e.g.
+// type=cache,target=/root/.npm,id=npm.
+func AddCacheMounts(dockerfileContent string, targets []string) (string, error) {
+	if len(targets) == 0 {
+		return dockerfileContent, nil
+	}
+
+	result, err := parser.Parse(strings.NewReader(dockerfileContent))
+	if err != nil {
+		return "", err
+	}
+
+	mountFlags := make([]string, 0, len(targets))
+	for _, target := range targets {
+		mountFlag, err := cacheMountFlag(target)
+		if err != nil {
+			return "", err
+		}
+		mountFlags = append(mountFlags, mountFlag)
+	}
+
+	runLines := map[int]bool{}
+	for _, child := range result.AST.Children {
+		if strings.ToLower(child.Value) == command.Run {
+			runLines[child.StartLine] = true
+		}
+	}
+
+	scan := scanner.NewScanner(strings.NewReader(dockerfileContent))
+	var lines []string
+	for lineNumber := 1; scan.Scan(); lineNumber++ {
+		line := scan.Text()
+		if runLines[lineNumber] {
+			line = runInstructionRegex.ReplaceAllString(
+				line,
+				"${1} "+strings.Join(mountFlags, " "),
+			)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// cacheMountFlag returns the RUN --mount flag of a cache mount target. Mount specifications
+// without a type are cache mounts, other types are rejected.
+func cacheMountFlag(target string) (string, error) {
+	if !strings.Contains(target, "=") {
+		return "--mount=type=cache,target=" + target, nil
+	}
+
+	for field := range strings.SplitSeq(target, ",") {
+		key, value, _ := strings.Cut(field, "=")
+		if strings.TrimSpace(key) != "type" {
+			continue
+		} else if strings.TrimSpace(value) != "cache" {
+			return "", fmt.Errorf("cache mount %s: type must be cache", target)
+		}
+
+		return "--mount=" + target, nil
+	}
+
+	return "--mount=type=cache," + target, nil
+}
+
 func ReplaceInDockerfile(dockerfileContent string, node *parser.Node) string {
 	scan := scanner.NewScanner(strings.NewReader(dockerfileContent))
 	var lines []string
@@ -348,3 +348,30 @@ FROM ${REGISTRY}/${IMAGE}:${TAG}`
 	)
 	s.Equal("gcr.io/my/image:latest", baseImage)
 }
+
+func (s *ParseTestSuite) TestAddCacheMounts() {
+	dockerfileContent := `FROM alpine
+run apk add git
+RUN --mount=type=secret,id=token \\
+    echo hello
+COPY . /app`
+
+	out, err := AddCacheMounts(dockerfileContent, []string{"/root/.cache", "type=cache,target=/var/cache/apk,id=apk"})
+	s.NoError(err)
+	s.Equal(`FROM alpine
+run --mount=type=cache,target=/root/.cache --mount=type=cache,target=/var/cache/apk,id=apk apk add git
+RUN --mount=type=cache,target=/root/.cache --mount=type=cache,target=/var/cache/apk,id=apk --mount=type=secret,id=token \\
+    echo hello
+COPY . /app`, out)
+
+	out, err = AddCacheMounts(dockerfileContent, nil)
+	s.NoError(err)
+	s.Equal(dockerfileContent, out)
+
+	out, err = AddCacheMounts(dockerfileContent, []string{"target=/root/.npm,id=npm"})
+	s.NoError(err)
+	s.Contains(out, "RUN --mount=type=cache,target=/root/.npm,id=npm --mount=type=secret")
+
+	_, err = AddCacheMounts(dockerfileContent, []string{"type=bind,source=/,target=/host"})
+	s.ErrorContains(err, "type must be cache")
+}
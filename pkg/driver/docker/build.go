@@ -3,8 +3,11 @@ package docker
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -71,7 +74,16 @@ func (s *dockerBuildxStrategy) build(
 	platform string,
 	options *build.BuildOptions,
 ) error {
-	args := buildDockerBuildxArgs(options, platform)
+	builder := ""
+	if options.RegistryMirror != "" {
+		var err error
+		builder, err = s.registryMirrorBuilder(ctx, options.RegistryMirror)
+		if err != nil {
+			return err
+		}
+	}
+
+	args := buildDockerBuildxArgs(options, platform, builder)
 	s.driver.Log.Debugf("running docker buildx build with args: %s", strings.Join(args, " "))
 	stderrBuf := &bytes.Buffer{}
 	multiWriter := io.MultiWriter(writer, stderrBuf)
@@ -92,8 +104,55 @@ func (s *dockerBuildxStrategy) name() string {
 	return "docker buildx build"
 }
 
-func buildDockerBuildxArgs(options *build.BuildOptions, platform string) []string {
+// registryMirrorBuilder returns a buildx builder that pulls base images through the registry
+// mirror. The default builder only uses the mirrors of the docker daemon, so a builder with
+// the docker-container driver and a buildkitd config is created once per mirror.
+func (s *dockerBuildxStrategy) registryMirrorBuilder(
+	ctx context.Context,
+	registryMirror string,
+) (string, error) {
+	name := fmt.Sprintf("devpod-mirror-%x", sha256.Sum256([]byte(registryMirror)))[:22]
+	buf := &bytes.Buffer{}
+	err := s.driver.Docker.Run(ctx, []string{"buildx", "inspect", name}, nil, buf, buf)
+	if err == nil {
+		return name, nil
+	}
+
+	buildkitdConfig, err := docker.BuildkitdConfig(registryMirror)
+	if err != nil {
+		return "", err
+	}
+	configPath := filepath.Join(os.TempDir(), name+".toml")
+	err = os.WriteFile(configPath, []byte(buildkitdConfig), 0o600)
+	if err != nil {
+		return "", fmt.Errorf("write buildkitd config: %w", err)
+	}
+	defer func() { _ = os.Remove(configPath) }()
+
+	s.driver.Log.Infof("create buildx builder %s for registry mirror %s", name, registryMirror)
+	buf.Reset()
+	err = s.driver.Docker.Run(ctx, []string{
+		"buildx", "create",
+		"--name", name,
+		"--driver", "docker-container",
+		"--buildkitd-config", configPath,
+	}, nil, buf, buf)
+	if err != nil {
+		return "", fmt.Errorf(
+			"create buildx builder for registry mirror: %w: %s",
+			err,
+			strings.TrimSpace(buf.String()),
+		)
+	}
+
+	return name, nil
+}
+
+func buildDockerBuildxArgs(options *build.BuildOptions, platform, builder string) []string {
 	args := []string{"buildx", "build", "-f", options.Dockerfile}
+	if builder != "" {
+		args = append(args, "--builder", builder)
+	}
 	args = appendBuildFlags(args, options.Load, options.Push)
 	args = appendImageTags(args, options.Images)
 	args = appendBuildArgsAndContexts(args, options.BuildArgs, options.Contexts)
@@ -291,6 +291,11 @@ func (d *dockerDriver) RunDockerDevContainer(
 		return err
 	}
 
+	err = helper.EnsureNetwork(ctx, params.Options.Network, params.Options.CreateNetwork)
+	if err != nil {
+		return err
+	}
+
 	args, err := d.buildRunArgs(params, helper)
 	if err != nil {
 		return err
@@ -310,13 +315,26 @@ func (d *dockerDriver) EnsureImage(
 	ctx context.Context,
 	options *driver.RunOptions,
 ) error {
+	writer := d.Log.Writer(logrus.DebugLevel, false)
+	defer func() { _ = writer.Close() }()
+
+	if options.ImagePullPolicy == driver.ImagePullPolicyAlways {
+		d.Log.Infof("pulling image: image=%s", options.Image)
+		return d.Docker.Pull(ctx, options.Image, nil, writer, writer)
+	}
+
 	d.Log.Infof("inspecting image: image=%s", options.Image)
 	_, err := d.Docker.InspectImage(ctx, options.Image, false)
 	if err != nil {
-		d.Log.Infof("image not found, pulling image: image=%s", options.Image)
-		writer := d.Log.Writer(logrus.DebugLevel, false)
-		defer func() { _ = writer.Close() }()
+		if options.ImagePullPolicy == driver.ImagePullPolicyNever {
+			return fmt.Errorf(
+				"image %s doesn't exist locally and the image pull policy is %s",
+				options.Image,
+				driver.ImagePullPolicyNever,
+			)
+		}
 
+		d.Log.Infof("image not found, pulling image: image=%s", options.Image)
 		return d.Docker.Pull(ctx, options.Image, nil, writer, writer)
 	}
 	return nil
@@ -368,6 +386,9 @@ func (d *dockerDriver) UpdateContainerUserUID(
 	if !d.shouldUpdateUserUID(parsedConfig) {
 		return nil
 	}
+	if warning := userConflictWarning(parsedConfig); warning != "" {
+		d.Log.Warn(warning)
+	}
 
 	localUser, containerUser, err := d.gatherUpdateRequirements(parsedConfig)
 	if err != nil {
@@ -507,6 +528,7 @@ func (d *dockerDriver) buildRunArgs(
 
 	b.addIDEMount().
 		addLabels().
+		addNetwork().
 		addGPU().
 		addRunArgs().
 		addDetached().
@@ -579,6 +601,13 @@ func (b *runArgsBuilder) addLabels() *runArgsBuilder {
 	return b
 }
 
+func (b *runArgsBuilder) addNetwork() *runArgsBuilder {
+	if b.params.Options.Network != "" {
+		b.args = append(b.args, "--network", b.params.Options.Network)
+	}
+	return b
+}
+
 func (b *runArgsBuilder) addGPU() *runArgsBuilder {
 	b.args = appendGPUOptions(b.params.ParsedConfig, b.driver, b.args)
 	return b
@@ -891,6 +920,24 @@ func (d *dockerDriver) shouldUpdateUserUID(parsedConfig *config.DevContainerConf
 	return isLinux && hasUser && shouldUpdate
 }
 
+// userConflictWarning returns a warning if containerUser and remoteUser differ, because only the
+// UID of remoteUser is updated while the container processes run as containerUser.
+func userConflictWarning(parsedConfig *config.DevContainerConfig) string {
+	containerUser, _, _ := strings.Cut(parsedConfig.ContainerUser, ":")
+	remoteUser, _, _ := strings.Cut(parsedConfig.RemoteUser, ":")
+	if containerUser == "" || remoteUser == "" || containerUser == remoteUser {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"containerUser %q and remoteUser %q differ, updateRemoteUserUID only updates the UID of %q "+
+			"to match the local user, processes started as %q keep their UID and may not be able "+
+			"to access the workspace files. Set both to the same user or set updateRemoteUserUID "+
+			"to false in devcontainer.json",
+		containerUser, remoteUser, remoteUser, containerUser,
+	)
+}
+
 func (d *dockerDriver) getContainerUser(parsedConfig *config.DevContainerConfig) string {
 	if parsedConfig.RemoteUser != "" {
 		return parsedConfig.RemoteUser
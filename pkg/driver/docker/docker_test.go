@@ -137,3 +137,31 @@ func (s *DockerDriverTestSuite) TestGatherUpdateRequirements_WithContainerUser()
 	s.NotNil(localUser)
 	s.Equal("container", containerUser)
 }
+
+func newUserConflictConfig(containerUser, remoteUser string) *config.DevContainerConfig {
+	return &config.DevContainerConfig{
+		DevContainerConfigBase: config.DevContainerConfigBase{
+			RemoteUser: remoteUser,
+		},
+		NonComposeBase: config.NonComposeBase{
+			ContainerUser: containerUser,
+		},
+	}
+}
+
+func (s *DockerDriverTestSuite) TestUserConflictWarning_DifferentUsers() {
+	warning := userConflictWarning(newUserConflictConfig("root", "vscode"))
+
+	s.Contains(warning, `containerUser "root" and remoteUser "vscode" differ`)
+	s.Contains(warning, "updateRemoteUserUID")
+}
+
+func (s *DockerDriverTestSuite) TestUserConflictWarning_SameUser() {
+	s.Empty(userConflictWarning(newUserConflictConfig("vscode", "vscode")))
+	s.Empty(userConflictWarning(newUserConflictConfig("vscode:vscode", "vscode")))
+}
+
+func (s *DockerDriverTestSuite) TestUserConflictWarning_OneEmpty() {
+	s.Empty(userConflictWarning(newUserConflictConfig("", "vscode")))
+	s.Empty(userConflictWarning(newUserConflictConfig("vscode", "")))
+}
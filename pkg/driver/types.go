@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	"github.com/skevetter/devpod/pkg/devcontainer/config"
@@ -52,6 +53,34 @@ type ReprovisioningDriver interface {
 	CanReprovision() bool
 }
 
+const (
+	// ImagePullPolicyAlways pulls the image on every start.
+	ImagePullPolicyAlways = "always"
+
+	// ImagePullPolicyIfNotPresent only pulls the image if it doesn't exist locally.
+	ImagePullPolicyIfNotPresent = "if-not-present"
+
+	// ImagePullPolicyNever never pulls the image and fails if it doesn't exist locally.
+	ImagePullPolicyNever = "never"
+)
+
+// ValidateImagePullPolicy returns an error if policy is not a known image pull policy.
+// An empty policy is treated as if-not-present.
+func ValidateImagePullPolicy(policy string) error {
+	switch policy {
+	case "", ImagePullPolicyAlways, ImagePullPolicyIfNotPresent, ImagePullPolicyNever:
+		return nil
+	default:
+		return fmt.Errorf(
+			"unknown image pull policy %s, choose one of %s, %s or %s",
+			policy,
+			ImagePullPolicyAlways,
+			ImagePullPolicyIfNotPresent,
+			ImagePullPolicyNever,
+		)
+	}
+}
+
 // RunOptions are the options for running a container.
 type RunOptions struct {
 	// UID is a unique identifier for this workspace
@@ -103,4 +132,14 @@ type RunOptions struct {
 
 	// GidMap are GID mappings for user namespace
 	GidMap []string `json:"gidMap,omitempty"`
+
+	// Network is an existing docker network to attach the container to
+	Network string `json:"network,omitempty"`
+
+	// CreateNetwork creates the network if it doesn't exist yet
+	CreateNetwork bool `json:"createNetwork,omitempty"`
+
+	// ImagePullPolicy controls if the image is pulled before the container is created. It is
+	// only set if the image is the base image and not built locally.
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
 }
@@ -46,6 +46,14 @@ func WithRecursiveSubmodules() Option {
 	}
 }
 
+// WithCloneDepth sets the number of commits a shallow or bare clone fetches.
+// It has no effect for the other strategies.
+func WithCloneDepth(depth int) Option {
+	return func(c *cloner) {
+		c.depth = depth
+	}
+}
+
 func WithSkipLFS() Option {
 	return func(c *cloner) {
 		c.skipLFS = true
@@ -95,6 +103,7 @@ func (s *CloneStrategy) String() string {
 type cloner struct {
 	extraArgs     []string
 	cloneStrategy CloneStrategy
+	depth         int
 	skipLFS       bool
 }
 
@@ -107,15 +116,19 @@ func (c *cloner) initialArgs() []string {
 	case TreelessCloneStrategy:
 		return []string{"clone", "--filter=tree:0"}
 	case ShallowCloneStrategy:
-		return []string{"clone", "--depth=1"}
+		return []string{"clone", c.depthArg()}
 	case BareCloneStrategy:
-		return []string{"clone", "--bare", "--depth=1"}
+		return []string{"clone", "--bare", c.depthArg()}
 	case FullCloneStrategy:
 	default:
 	}
 	return []string{"clone"}
 }
 
+func (c *cloner) depthArg() string {
+	return fmt.Sprintf("--depth=%d", max(c.depth, 1))
+}
+
 type progressWriter struct {
 	level logrus.Level
 	log   log.Logger
@@ -94,6 +94,36 @@ func PingRepository(str string, extraEnv []string) bool {
 	return err == nil
 }
 
+// repositoryNotFoundRegEx matches the messages of git and the common git hosts for
+// repositories that don't exist or can't be accessed.
+var repositoryNotFoundRegEx = regexp.MustCompile(
+	`(?i)repository not found|repository '[^']*' not found|` +
+		`does not appear to be a git repository|project not found`,
+)
+
+// RepositoryNotFound returns true if the remote explicitly reports the repository as not found.
+// Hosts like GitHub report private repositories the same way if credentials are missing or lack
+// access. Network errors and timeouts are not reported as not found.
+func RepositoryNotFound(str string, extraEnv []string) bool {
+	if !command.Exists("git") {
+		return false
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	out, err := CommandContext(timeoutCtx, extraEnv, "ls-remote", "--quiet", str).CombinedOutput()
+	if err == nil || timeoutCtx.Err() != nil {
+		return false
+	}
+
+	return isRepositoryNotFound(string(out))
+}
+
+func isRepositoryNotFound(output string) bool {
+	return repositoryNotFoundRegEx.MatchString(output)
+}
+
 func GetBranchNameForPR(ref string) string {
 	regex := regexp.MustCompile(PullRequestReference)
 	return regex.ReplaceAllString(ref, "PR${1}")
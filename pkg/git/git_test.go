@@ -254,3 +254,50 @@ func TestGetBranchNameForPRReference(t *testing.T) {
 		assert.Check(t, cmp.Equal(testCase.expectedBranch, outBranch))
 	}
 }
+
+func TestClonerInitialArgs(t *testing.T) {
+	testCases := []struct {
+		options  []Option
+		expected []string
+	}{
+		{
+			options:  []Option{WithCloneStrategy(ShallowCloneStrategy)},
+			expected: []string{"clone", "--depth=1"},
+		},
+		{
+			options:  []Option{WithCloneStrategy(ShallowCloneStrategy), WithCloneDepth(5)},
+			expected: []string{"clone", "--depth=5"},
+		},
+		{
+			options:  []Option{WithCloneStrategy(BloblessCloneStrategy), WithCloneDepth(5)},
+			expected: []string{"clone", "--filter=blob:none"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		c, ok := NewClonerWithOpts(testCase.options...).(*cloner)
+		assert.Assert(t, ok)
+		assert.Check(t, cmp.DeepEqual(testCase.expected, c.initialArgs()))
+	}
+}
+
+func TestIsRepositoryNotFound(t *testing.T) {
+	notFound := []string{
+		"ERROR: Repository not found.\nfatal: Could not read from remote repository.",
+		"remote: Repository not found.\nfatal: repository 'https://github.com/a/b/' not found",
+		"fatal: '/tmp/missing' does not appear to be a git repository",
+	}
+	for _, output := range notFound {
+		assert.Check(t, isRepositoryNotFound(output), output)
+	}
+
+	transient := []string{
+		"fatal: unable to access 'https://github.com/a/b/': Could not resolve host: github.com",
+		"fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+		"ssh: connect to host github.com port 22: Connection timed out",
+		"git@github.com: Permission denied (publickey).",
+	}
+	for _, output := range transient {
+		assert.Check(t, !isRepositoryNotFound(output), output)
+	}
+}
@@ -0,0 +1,21 @@
+package git
+
+import (
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+)
+
+// IdentityCommand returns the shell command that sets the global git user.name and user.email.
+// Empty values are left unchanged.
+func IdentityCommand(name, email string) string {
+	commands := []string{}
+	if name != "" {
+		commands = append(commands, "git config --global user.name "+shellescape.Quote(name))
+	}
+	if email != "" {
+		commands = append(commands, "git config --global user.email "+shellescape.Quote(email))
+	}
+
+	return strings.Join(commands, " && ")
+}
@@ -0,0 +1,18 @@
+package git
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestIdentityCommand(t *testing.T) {
+	assert.Equal(
+		t,
+		IdentityCommand("Jane Doe", "jane@example.com"),
+		"git config --global user.name 'Jane Doe' && git config --global user.email jane@example.com",
+	)
+	assert.Equal(t, IdentityCommand("", "jane@example.com"), "git config --global user.email jane@example.com")
+	assert.Equal(t, IdentityCommand("it's me", ""), `git config --global user.name 'it'"'"'s me'`)
+	assert.Equal(t, IdentityCommand("", ""), "")
+}
@@ -67,6 +67,12 @@ func ConfigureHelper(binaryPath, userName string, port int) error {
 	return nil
 }
 
+// HelperCommand returns the git credential helper that fetches the credentials from the
+// credentials server on port.
+func HelperCommand(binaryPath string, port int) string {
+	return fmt.Sprintf("!'%s' agent git-credentials --port %d", binaryPath, port)
+}
+
 func RemoveHelper(userName string) error {
 	gitConfigPath, err := getGlobalGitConfigPath(userName)
 	if err != nil {
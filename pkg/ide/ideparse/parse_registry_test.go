@@ -20,3 +20,23 @@ func TestNewBrowserIDEsRegistered(t *testing.T) {
 		}
 	}
 }
+
+func TestJetBrainsVersionValidation(t *testing.T) {
+	opts, err := GetIDEOptions("goland")
+	if err != nil {
+		t.Fatalf("GetIDEOptions(goland) error: %v", err)
+	}
+
+	for _, version := range []string{"latest", "2024.1", "2024.1.4"} {
+		_, err := ParseOptions([]string{"VERSION=" + version}, opts)
+		if err != nil {
+			t.Fatalf("ParseOptions(VERSION=%s) error: %v", version, err)
+		}
+	}
+	for _, version := range []string{"", "241.15989", "2024", "v2024.1", "2024.1.4; rm -rf /"} {
+		_, err := ParseOptions([]string{"VERSION=" + version}, opts)
+		if err == nil {
+			t.Fatalf("ParseOptions(VERSION=%s) expected an error", version)
+		}
+	}
+}
@@ -13,11 +13,7 @@ const (
 )
 
 var CLionOptions = ide.Options{
-	VersionOption: {
-		Name:        VersionOption,
-		Description: "The version for the binary",
-		Default:     "latest",
-	},
+	VersionOption: versionOption,
 	DownloadArm64Option: {
 		Name:        DownloadArm64Option,
 		Description: "The download url for the arm64 server binary",
@@ -28,8 +28,21 @@ const (
 	VersionOption       = "VERSION"
 	DownloadAmd64Option = "DOWNLOAD_AMD64"
 	DownloadArm64Option = "DOWNLOAD_ARM64"
+
+	// VersionPattern matches latest or a JetBrains release version like 2024.1 or 2024.1.4,
+	// which is the version used in the download urls of the server backends
+	VersionPattern = `^(latest|\d{4}\.\d+(\.\d+)?)$`
 )
 
+var versionOption = ide.Option{
+	Name:              VersionOption,
+	Description:       "The version for the binary",
+	Default:           "latest",
+	ValidationPattern: VersionPattern,
+	ValidationMessage: "The version needs to be latest or a JetBrains release version, " +
+		"e.g. 2024.1.4",
+}
+
 func getLatestDownloadURL(code string, platform string) string {
 	return fmt.Sprintf("https://download.jetbrains.com/product?code=%s&platform=%s", code, platform)
 }
@@ -0,0 +1,93 @@
+package log
+
+import (
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	logLib "github.com/skevetter/log"
+)
+
+// PackageDebugLogger wraps a logger and additionally writes the debug messages logged from the
+// given packages to a separate debug logger, independent of the level of the wrapped logger.
+type PackageDebugLogger struct {
+	logLib.Logger
+
+	debug    logLib.Logger
+	packages []string
+}
+
+// NewPackageDebugLogger creates a new PackageDebugLogger. Packages are full import paths,
+// e.g. github.com/skevetter/devpod/pkg/tunnel.
+func NewPackageDebugLogger(logger, debug logLib.Logger, packages ...string) *PackageDebugLogger {
+	return &PackageDebugLogger{
+		Logger:   logger,
+		debug:    debug,
+		packages: packages,
+	}
+}
+
+func (p *PackageDebugLogger) Debug(args ...any) {
+	if slices.Contains(p.packages, callerPackage(1)) {
+		p.debug.Debug(args...)
+		return
+	}
+
+	p.Logger.Debug(args...)
+}
+
+func (p *PackageDebugLogger) Debugf(format string, args ...any) {
+	if slices.Contains(p.packages, callerPackage(1)) {
+		p.debug.Debugf(format, args...)
+		return
+	}
+
+	p.Logger.Debugf(format, args...)
+}
+
+// Writer returns a writer of the debug logger for debug writers requested from the given
+// packages, e.g. for the stderr of a tunnel's ssh session.
+func (p *PackageDebugLogger) Writer(level logrus.Level, raw bool) io.WriteCloser {
+	if level == logrus.DebugLevel && slices.Contains(p.packages, callerPackage(1)) {
+		return p.debug.Writer(level, raw)
+	}
+
+	return p.Logger.Writer(level, raw)
+}
+
+func (p *PackageDebugLogger) ErrorStreamOnly() logLib.Logger {
+	return NewPackageDebugLogger(p.Logger.ErrorStreamOnly(), p.debug, p.packages...)
+}
+
+func (p *PackageDebugLogger) WithFields(fields logrus.Fields) logLib.Logger {
+	return NewPackageDebugLogger(p.Logger.WithFields(fields), p.debug.WithFields(fields), p.packages...)
+}
+
+// callerPackage returns the import path of the package of the function skip frames above
+// the caller of callerPackage.
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return ""
+	}
+
+	return packageOfFunc(fn.Name())
+}
+
+// packageOfFunc returns the package import path of a fully qualified function name,
+// e.g. github.com/skevetter/devpod/pkg/tunnel.NewTunnel.func1.
+func packageOfFunc(name string) string {
+	lastSlash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[lastSlash+1:], ".")
+	if dot < 0 {
+		return name
+	}
+
+	return name[:lastSlash+1+dot]
+}
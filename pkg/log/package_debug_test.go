@@ -0,0 +1,83 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	logLib "github.com/skevetter/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageOfFunc(t *testing.T) {
+	require.Equal(t, "github.com/skevetter/devpod/pkg/tunnel",
+		packageOfFunc("github.com/skevetter/devpod/pkg/tunnel.NewTunnel.func1"))
+	require.Equal(t, "github.com/skevetter/devpod/pkg/ssh",
+		packageOfFunc("github.com/skevetter/devpod/pkg/ssh.(*Server).Start"))
+	require.Equal(t, "main", packageOfFunc("main.main"))
+}
+
+func TestPackageDebugLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	debugOut := &bytes.Buffer{}
+	logger := NewPackageDebugLogger(
+		logLib.NewStreamLogger(out, out, logrus.InfoLevel),
+		logLib.NewStreamLogger(debugOut, debugOut, logrus.DebugLevel),
+		"github.com/skevetter/devpod/pkg/log",
+	)
+
+	logger.Debugf("from %s", "log")
+	require.Contains(t, debugOut.String(), "from log")
+	require.Empty(t, out.String())
+
+	logger = NewPackageDebugLogger(
+		logLib.NewStreamLogger(out, out, logrus.InfoLevel),
+		logLib.NewStreamLogger(debugOut, debugOut, logrus.DebugLevel),
+		"github.com/skevetter/devpod/pkg/tunnel",
+	)
+	debugOut.Reset()
+	logger.Debug("filtered")
+	require.Empty(t, debugOut.String())
+	require.Empty(t, out.String())
+}
+
+func TestPackageDebugLoggerWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	debugOut := &lockedBuffer{}
+	logger := NewPackageDebugLogger(
+		logLib.NewStreamLogger(out, out, logrus.InfoLevel),
+		logLib.NewStreamLogger(debugOut, debugOut, logrus.DebugLevel),
+		"github.com/skevetter/devpod/pkg/log",
+	)
+
+	writer := logger.ErrorStreamOnly().Writer(logrus.DebugLevel, false)
+	_, err := writer.Write([]byte("ssh stderr\n"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	require.Eventually(t, func() bool {
+		return strings.Contains(debugOut.String(), "ssh stderr")
+	}, time.Second, 10*time.Millisecond)
+	require.Empty(t, out.String())
+}
+
+type lockedBuffer struct {
+	m   sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.buf.String()
+}
@@ -279,6 +279,9 @@ func resolveAgentBaseConfig(
 	agentConfig.Dockerless.RegistryCache = devConfig.ContextOption(
 		config.ContextOptionRegistryCache,
 	)
+	agentConfig.MinActiveProcesses = devConfig.ContextOption(
+		config.ContextOptionDaemonMinActiveProcesses,
+	)
 	agentConfig.Driver = resolver.ResolveDefaultValue(agentConfig.Driver, options)
 	agentConfig.Local = types.StrBool(
 		resolver.ResolveDefaultValue(string(agentConfig.Local), options),
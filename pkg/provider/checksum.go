@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// RequireChecksums sets the expected checksum on the binaries of the current platform and
+// makes sure every one of them is verified after the download. An expected checksum can only
+// be used if the provider has a single binary for the platform and needs to match the
+// checksum of provider.yaml if it has one. With required set binaries without a checksum are
+// rejected.
+func RequireChecksums(
+	binaries map[string][]*ProviderBinary,
+	expectedChecksum string,
+	required bool,
+) error {
+	expectedChecksum = strings.ToLower(strings.TrimSpace(expectedChecksum))
+	platformBinaries := map[string]*ProviderBinary{}
+	for binaryName, binaryLocations := range binaries {
+		index := slices.IndexFunc(binaryLocations, func(binary *ProviderBinary) bool {
+			return binary.OS == runtime.GOOS && binary.Arch == runtime.GOARCH
+		})
+		if index >= 0 {
+			platformBinaries[binaryName] = binaryLocations[index]
+		}
+	}
+
+	if expectedChecksum != "" {
+		if len(platformBinaries) != 1 {
+			return fmt.Errorf(
+				"--verify-checksum requires exactly one provider binary for %s/%s, found %d. "+
+					"Add the checksums to provider.yaml instead",
+				runtime.GOOS,
+				runtime.GOARCH,
+				len(platformBinaries),
+			)
+		}
+
+		for binaryName, binary := range platformBinaries {
+			if binary.Checksum != "" && !strings.EqualFold(binary.Checksum, expectedChecksum) {
+				return fmt.Errorf(
+					"checksum %s of binary %s in provider.yaml doesn't match %s",
+					binary.Checksum,
+					binaryName,
+					expectedChecksum,
+				)
+			}
+			binary.Checksum = expectedChecksum
+		}
+	}
+
+	if required {
+		for binaryName, binary := range platformBinaries {
+			if binary.Checksum == "" {
+				return fmt.Errorf(
+					"provider binary %s has no checksum, add it to provider.yaml or use "+
+						"--verify-checksum",
+					binaryName,
+				)
+			}
+		}
+	}
+
+	return nil
+}
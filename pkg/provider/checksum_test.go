@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireChecksums(t *testing.T) {
+	newBinaries := func(checksum string) map[string][]*ProviderBinary {
+		return map[string][]*ProviderBinary{
+			"PROVIDER": {
+				{OS: "plan9", Arch: "mips", Path: "https://example.com/plan9"},
+				{
+					OS:       runtime.GOOS,
+					Arch:     runtime.GOARCH,
+					Path:     "https://example.com/p",
+					Checksum: checksum,
+				},
+			},
+		}
+	}
+
+	binaries := newBinaries("")
+	assert.NoError(t, RequireChecksums(binaries, "", false))
+	assert.Error(t, RequireChecksums(binaries, "", true))
+
+	assert.NoError(t, RequireChecksums(binaries, " ABCD ", true))
+	assert.Equal(t, "abcd", binaries["PROVIDER"][1].Checksum)
+	assert.Empty(t, binaries["PROVIDER"][0].Checksum)
+
+	assert.NoError(t, RequireChecksums(newBinaries("ABCD"), "abcd", true))
+	assert.Error(t, RequireChecksums(newBinaries("1234"), "abcd", false))
+
+	binaries = newBinaries("abcd")
+	binaries["HELPER"] = []*ProviderBinary{{OS: runtime.GOOS, Arch: runtime.GOARCH}}
+	assert.Error(t, RequireChecksums(binaries, "abcd", false))
+	assert.Error(t, RequireChecksums(binaries, "", true))
+	assert.Error(t, RequireChecksums(map[string][]*ProviderBinary{}, "abcd", false))
+}
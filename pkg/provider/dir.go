@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/skevetter/devpod/pkg/config"
@@ -21,6 +23,28 @@ const (
 	ProInstanceConfigFile = "pro.json"
 	ProviderConfigFile    = "provider.json"
 
+	// WorkspaceIDEURLFile holds the url of the browser IDE while its tunnel is running
+	WorkspaceIDEURLFile = "ide-url"
+
+	// WorkspaceNetworkPoliciesFile holds the egress rules applied to the workspace container
+	WorkspaceNetworkPoliciesFile = "network-policies.json"
+
+	// WorkspaceSealFile holds the seal written by devpod workspace seal
+	WorkspaceSealFile = "seal.json"
+
+	// WorkspaceSSHFSMountFile holds the local mountpoint of devpod workspace mount-ssh-fs
+	WorkspaceSSHFSMountFile = "sshfs-mount"
+
+	// WorkspaceUpOptionsFile holds the digest of the options of the last successful devpod up
+	WorkspaceUpOptionsFile = "up-options"
+
+	// WorkspaceSSHAuthSockIDFile holds the id of the SSH_AUTH_SOCK the IDE server of the
+	// workspace uses
+	WorkspaceSSHAuthSockIDFile = "ssh-auth-sock-id"
+
+	// WorkspaceEnvFile holds the --workspace-env variables put into the workspace container
+	WorkspaceEnvFile = "workspace-env.json"
+
 	DaemonStateFile = config.BinaryName + "_ts.state"
 )
 
@@ -96,6 +120,16 @@ func GetProviderBinariesDir(context, providerName string) (string, error) {
 	return filepath.Join(providerDir, "binaries"), nil
 }
 
+// GetProviderCommandLogFile returns the file provider command output is written to if PROVIDER_LOG is enabled.
+func GetProviderCommandLogFile(context, providerName string) (string, error) {
+	providerDir, err := GetProviderDir(context, providerName)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(providerDir, "command.log"), nil
+}
+
 func GetMachineDir(context, machineID string) (string, error) {
 	if machineID == "" {
 		return "", fmt.Errorf("machine id is empty")
@@ -434,3 +468,337 @@ func LoadWorkspaceResult(context, workspaceID string) (*config2.Result, error) {
 
 	return workspaceResult, nil
 }
+
+// SaveWorkspaceIDEURL stores the url of the running browser IDE of the workspace.
+func SaveWorkspaceIDEURL(context, workspaceID, url string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(workspaceDir, WorkspaceIDEURLFile), []byte(url), 0o600)
+}
+
+// LoadWorkspaceIDEURL returns the url of the running browser IDE of the workspace or an
+// empty string if no browser tunnel was started.
+func LoadWorkspaceIDEURL(context, workspaceID string) (string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceIDEURLFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// DeleteWorkspaceIDEURL removes the stored browser IDE url of the workspace.
+func DeleteWorkspaceIDEURL(context, workspaceID string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(workspaceDir, WorkspaceIDEURLFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SaveWorkspaceNetworkPolicies stores the egress rules of the workspace. An empty list removes
+// the file.
+func SaveWorkspaceNetworkPolicies(context, workspaceID string, policies []NetworkPolicy) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	policiesFile := filepath.Join(workspaceDir, WorkspaceNetworkPoliciesFile)
+	if len(policies) == 0 {
+		err = os.Remove(policiesFile)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	out, err := json.MarshalIndent(policies, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(policiesFile, out, 0o600)
+}
+
+// LoadWorkspaceNetworkPolicies returns the stored egress rules of the workspace.
+func LoadWorkspaceNetworkPolicies(context, workspaceID string) ([]NetworkPolicy, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceNetworkPoliciesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	policies := []NetworkPolicy{}
+	err = json.Unmarshal(out, &policies)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", WorkspaceNetworkPoliciesFile, err)
+	}
+
+	return policies, nil
+}
+
+// SaveWorkspaceSeal stores the seal of the workspace.
+func SaveWorkspaceSeal(context, workspaceID string, seal *WorkspaceSeal) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.MarshalIndent(seal, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(workspaceDir, WorkspaceSealFile), out, 0o600)
+}
+
+// LoadWorkspaceSeal returns the stored seal of the workspace or nil if it isn't sealed.
+func LoadWorkspaceSeal(context, workspaceID string) (*WorkspaceSeal, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceSealFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	seal := &WorkspaceSeal{}
+	err = json.Unmarshal(out, seal)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", WorkspaceSealFile, err)
+	}
+
+	return seal, nil
+}
+
+// SaveWorkspaceSSHFSMount stores the local path the workspace folder is mounted at.
+func SaveWorkspaceSSHFSMount(context, workspaceID, mountpoint string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(
+		filepath.Join(workspaceDir, WorkspaceSSHFSMountFile),
+		[]byte(mountpoint),
+		0o600,
+	)
+}
+
+// LoadWorkspaceSSHFSMount returns the local path the workspace folder is mounted at or an
+// empty string if it isn't mounted.
+func LoadWorkspaceSSHFSMount(context, workspaceID string) (string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceSSHFSMountFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// DeleteWorkspaceSSHFSMount removes the stored mountpoint of the workspace.
+func DeleteWorkspaceSSHFSMount(context, workspaceID string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filepath.Join(workspaceDir, WorkspaceSSHFSMountFile))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// SaveWorkspaceUpOptions stores the digest of the options of the last successful devpod up.
+func SaveWorkspaceUpOptions(context, workspaceID, digest string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(workspaceDir, WorkspaceUpOptionsFile), []byte(digest), 0o600)
+}
+
+// LoadWorkspaceUpOptions returns the digest of the options of the last successful devpod up or
+// an empty string if there is none.
+func LoadWorkspaceUpOptions(context, workspaceID string) (string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceUpOptionsFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SaveWorkspaceSSHAuthSockID stores the id of the SSH_AUTH_SOCK the IDE server uses.
+func SaveWorkspaceSSHAuthSockID(context, workspaceID, id string) error {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(workspaceDir, WorkspaceSSHAuthSockIDFile), []byte(id), 0o600)
+}
+
+// LoadWorkspaceSSHAuthSockID returns the id of the SSH_AUTH_SOCK the IDE server uses or an
+// empty string if there is none.
+func LoadWorkspaceSSHAuthSockID(context, workspaceID string) (string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceSSHAuthSockIDFile))
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SaveWorkspaceEnv adds the KEY=VALUE variables to the stored workspace env. Variables that are
+// already stored are replaced, the workspace container keeps them as well.
+func SaveWorkspaceEnv(context, workspaceID string, env []string) error {
+	if len(env) == 0 {
+		return nil
+	}
+
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return err
+	}
+	stored, err := LoadWorkspaceEnv(context, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	merged := map[string]string{}
+	for _, variable := range append(stored, env...) {
+		key, value, ok := strings.Cut(variable, "=")
+		if ok {
+			merged[key] = value
+		}
+	}
+	result := []string{}
+	for _, key := range slices.Sorted(maps.Keys(merged)) {
+		result = append(result, key+"="+merged[key])
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(workspaceDir, WorkspaceEnvFile), out, 0o600)
+}
+
+// LoadWorkspaceEnv returns the stored workspace env of the workspace or nil if there is none.
+func LoadWorkspaceEnv(context, workspaceID string) ([]string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, WorkspaceEnvFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	env := []string{}
+	err = json.Unmarshal(out, &env)
+	if err != nil {
+		return nil, fmt.Errorf("parse workspace env: %w", err)
+	}
+
+	return env, nil
+}
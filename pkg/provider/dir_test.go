@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveWorkspaceEnv(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	env, err := LoadWorkspaceEnv("default", "my-workspace")
+	require.NoError(t, err)
+	assert.Empty(t, env)
+
+	require.NoError(t, SaveWorkspaceEnv("default", "my-workspace", []string{"FOO=bar", "URL=a=b"}))
+	require.NoError(t, SaveWorkspaceEnv("default", "my-workspace", nil))
+	require.NoError(t, SaveWorkspaceEnv("default", "my-workspace", []string{"FOO=baz", "INVALID"}))
+
+	env, err = LoadWorkspaceEnv("default", "my-workspace")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"FOO=baz", "URL=a=b"}, env)
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/skevetter/log"
+)
+
+// WorkspaceEventsFile is the file within a workspace folder that holds the events of the last up.
+const WorkspaceEventsFile = "events.jsonl"
+
+const (
+	EventLevelInfo  = "info"
+	EventLevelWarn  = "warn"
+	EventLevelError = "error"
+)
+
+// WorkspaceEvent is a single entry of the workspace event timeline.
+type WorkspaceEvent struct {
+	Timestamp time.Time `json:"ts"`
+	Phase     string    `json:"phase"`
+	Message   string    `json:"message"`
+	Level     string    `json:"level"`
+}
+
+// EventWriter appends events to a workspace events file and mirrors them to the logger.
+// Errors writing the file are only logged, events never fail the operation.
+type EventWriter struct {
+	m    sync.Mutex
+	path string
+	log  log.Logger
+}
+
+// NewEventWriter creates an event writer for the events file in the given workspace folder.
+func NewEventWriter(workspaceDir string, log log.Logger) *EventWriter {
+	return &EventWriter{
+		path: filepath.Join(workspaceDir, WorkspaceEventsFile),
+		log:  log,
+	}
+}
+
+// GetWorkspaceEventsFile returns the path of the events file of a workspace.
+func GetWorkspaceEventsFile(context, workspaceID string) (string, error) {
+	workspaceDir, err := GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(workspaceDir, WorkspaceEventsFile), nil
+}
+
+// Reset removes the events of a previous run.
+func (w *EventWriter) Reset() {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	err := os.Remove(w.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		w.log.Debugf("error resetting workspace events: %v", err)
+	}
+}
+
+// Infof logs the message and records it as an info event.
+func (w *EventWriter) Infof(phase, format string, args ...any) {
+	w.log.Infof(format, args...)
+	w.Record(phase, EventLevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs the message and records it as a warning event.
+func (w *EventWriter) Warnf(phase, format string, args ...any) {
+	w.log.Warnf(format, args...)
+	w.Record(phase, EventLevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Fail records the error as an error event. The error is not logged as the
+// caller returns it.
+func (w *EventWriter) Fail(phase string, err error) {
+	w.Record(phase, EventLevelError, err.Error())
+}
+
+// Record writes an event without logging it.
+func (w *EventWriter) Record(phase, level, message string) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	out, err := json.Marshal(&WorkspaceEvent{
+		Timestamp: time.Now().UTC(),
+		Phase:     phase,
+		Message:   message,
+		Level:     level,
+	})
+	if err != nil {
+		return
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(filepath.Dir(w.path), 0o755)
+	if err != nil {
+		w.log.Debugf("error creating workspace events folder: %v", err)
+		return
+	}
+
+	// #nosec G304 -- path is built from the workspace folder
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		w.log.Debugf("error opening workspace events: %v", err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = f.Write(append(out, '\n'))
+	if err != nil {
+		w.log.Debugf("error writing workspace event: %v", err)
+	}
+}
+
+// LoadWorkspaceEvents reads the events file at path. A missing file has no events.
+func LoadWorkspaceEvents(path string) ([]WorkspaceEvent, error) {
+	// #nosec G304 -- path is built from the workspace folder
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return ParseWorkspaceEvents(f)
+}
+
+// ParseWorkspaceEvents parses json lines events and skips lines that cannot be parsed,
+// e.g. a line cut off by a crash.
+func ParseWorkspaceEvents(reader io.Reader) ([]WorkspaceEvent, error) {
+	events := []WorkspaceEvent{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		event := WorkspaceEvent{}
+		if json.Unmarshal(scanner.Bytes(), &event) != nil {
+			continue
+		}
+
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read workspace events: %w", err)
+	}
+
+	return events, nil
+}
+
+// SortWorkspaceEvents sorts events chronologically and keeps the order of events with the same time.
+func SortWorkspaceEvents(events []WorkspaceEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+}
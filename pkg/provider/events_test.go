@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skevetter/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventWriter(t *testing.T) {
+	dir := t.TempDir()
+	events := NewEventWriter(dir, log.Discard)
+	events.Infof("devcontainer", "creating %s", "devcontainer")
+	events.Fail("up", errors.New("boom"))
+
+	path := filepath.Join(dir, WorkspaceEventsFile)
+	loaded, err := LoadWorkspaceEvents(path)
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "devcontainer", loaded[0].Phase)
+	assert.Equal(t, "creating devcontainer", loaded[0].Message)
+	assert.Equal(t, EventLevelInfo, loaded[0].Level)
+	assert.Equal(t, EventLevelError, loaded[1].Level)
+	assert.Equal(t, "boom", loaded[1].Message)
+
+	events.Reset()
+	_, err = os.Stat(path)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	loaded, err = LoadWorkspaceEvents(path)
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
+
+func TestSortWorkspaceEvents(t *testing.T) {
+	now := time.Now()
+	events := []WorkspaceEvent{
+		{Timestamp: now.Add(time.Second), Phase: "b"},
+		{Timestamp: now, Phase: "a"},
+		{Timestamp: now.Add(time.Second), Phase: "c"},
+	}
+
+	SortWorkspaceEvents(events)
+	assert.Equal(t, "a", events[0].Phase)
+	assert.Equal(t, "b", events[1].Phase)
+	assert.Equal(t, "c", events[2].Phase)
+}
@@ -18,6 +18,12 @@ type Machine struct {
 	// Context is the context where this config file was loaded from
 	Context string `json:"context,omitempty"`
 
+	// Address is the ip or hostname of the machine used by devpod machine ssh --direct
+	Address string `json:"address,omitempty"`
+
+	// SSHUser is the user used by devpod machine ssh --direct
+	SSHUser string `json:"sshUser,omitempty"`
+
 	// Origin is the place where this config file was loaded from
 	Origin string `json:"-"`
 }
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/config"
+)
+
+// UpMetricsFile is the file within the DevPod config folder that records the result of each up.
+const UpMetricsFile = "metrics.jsonl"
+
+// MaxUpMetrics is the number of up metrics that are kept, older entries are dropped.
+const MaxUpMetrics = 1000
+
+// UpMetric is the result of a single devpod up.
+type UpMetric struct {
+	WorkspaceID     string    `json:"workspace_id"`
+	Context         string    `json:"context,omitempty"`
+	Provider        string    `json:"provider"`
+	DurationSeconds float64   `json:"duration_seconds"`
+	Success         bool      `json:"success"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+// UpMetricsSummary aggregates the up metrics of a provider.
+type UpMetricsSummary struct {
+	Provider   string  `json:"provider"`
+	Count      int     `json:"count"`
+	Successful int     `json:"successful"`
+	MinSeconds float64 `json:"minSeconds"`
+	MaxSeconds float64 `json:"maxSeconds"`
+	AvgSeconds float64 `json:"avgSeconds"`
+}
+
+// SuccessRate returns the share of successful ups between 0 and 1.
+func (s *UpMetricsSummary) SuccessRate() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+
+	return float64(s.Successful) / float64(s.Count)
+}
+
+// GetUpMetricsFile returns the path of the up metrics file.
+func GetUpMetricsFile() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, UpMetricsFile), nil
+}
+
+// AppendUpMetric appends the metric as a single json line to the metrics file at path and
+// drops the oldest entries beyond MaxUpMetrics.
+func AppendUpMetric(path string, metric UpMetric) error {
+	err := writeUpMetric(path, metric)
+	if err != nil {
+		return err
+	}
+
+	return truncateUpMetrics(path, MaxUpMetrics)
+}
+
+func writeUpMetric(path string, metric UpMetric) error {
+	out, err := json.Marshal(metric)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G304 -- path is built from the config folder
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = f.Write(append(out, '\n'))
+	return err
+}
+
+// truncateUpMetrics rewrites the metrics file at path with only its last keep lines.
+func truncateUpMetrics(path string, keep int) error {
+	// #nosec G304 -- path is built from the config folder
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := bytes.SplitAfter(bytes.TrimSuffix(content, []byte("\n")), []byte("\n"))
+	if len(lines) <= keep {
+		return nil
+	}
+
+	kept := append(bytes.Join(lines[len(lines)-keep:], nil), '\n')
+	tmpPath := path + ".tmp"
+	err = os.WriteFile(tmpPath, kept, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// LoadUpMetrics reads the metrics file at path, lines that cannot be parsed are skipped.
+// A missing file has no metrics.
+func LoadUpMetrics(path string) ([]UpMetric, error) {
+	// #nosec G304 -- path is built from the config folder
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	metrics := []UpMetric{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		metric := UpMetric{}
+		if json.Unmarshal(scanner.Bytes(), &metric) != nil {
+			continue
+		}
+
+		metrics = append(metrics, metric)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// SummarizeUpMetrics aggregates the metrics of the given provider. Only successful ups
+// count towards the startup times.
+func SummarizeUpMetrics(metrics []UpMetric, provider string) *UpMetricsSummary {
+	summary := &UpMetricsSummary{Provider: provider}
+	total := 0.0
+	for _, metric := range metrics {
+		if metric.Provider != provider {
+			continue
+		}
+
+		summary.Count++
+		if !metric.Success {
+			continue
+		}
+
+		if summary.Successful == 0 || metric.DurationSeconds < summary.MinSeconds {
+			summary.MinSeconds = metric.DurationSeconds
+		}
+		if metric.DurationSeconds > summary.MaxSeconds {
+			summary.MaxSeconds = metric.DurationSeconds
+		}
+		summary.Successful++
+		total += metric.DurationSeconds
+	}
+	if summary.Successful > 0 {
+		summary.AvgSeconds = total / float64(summary.Successful)
+	}
+
+	return summary
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), UpMetricsFile)
+	for _, metric := range []UpMetric{
+		{WorkspaceID: "a", Provider: "docker", DurationSeconds: 10, Success: true},
+		{WorkspaceID: "b", Provider: "docker", DurationSeconds: 30, Success: true},
+		{WorkspaceID: "c", Provider: "docker", DurationSeconds: 5, Success: false},
+		{WorkspaceID: "d", Provider: "kubernetes", DurationSeconds: 60, Success: true},
+	} {
+		metric.Timestamp = time.Now()
+		require.NoError(t, AppendUpMetric(path, metric))
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.WriteString("{\"workspace_id\":\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	metrics, err := LoadUpMetrics(path)
+	require.NoError(t, err)
+	require.Len(t, metrics, 4)
+
+	summary := SummarizeUpMetrics(metrics, "docker")
+	assert.Equal(t, 3, summary.Count)
+	assert.Equal(t, 2, summary.Successful)
+	assert.InDelta(t, 10.0, summary.MinSeconds, 0.001)
+	assert.InDelta(t, 30.0, summary.MaxSeconds, 0.001)
+	assert.InDelta(t, 20.0, summary.AvgSeconds, 0.001)
+	assert.InDelta(t, 2.0/3.0, summary.SuccessRate(), 0.001)
+
+	assert.Equal(t, 0, SummarizeUpMetrics(metrics, "ssh").Count)
+
+	metrics, err = LoadUpMetrics(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.NoError(t, err)
+	assert.Empty(t, metrics)
+}
+
+func TestAppendUpMetricKeepsLastEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), UpMetricsFile)
+	for i := range MaxUpMetrics + 5 {
+		metric := UpMetric{WorkspaceID: strconv.Itoa(i), Provider: "docker", Success: true}
+		require.NoError(t, AppendUpMetric(path, metric))
+	}
+
+	metrics, err := LoadUpMetrics(path)
+	require.NoError(t, err)
+	require.Len(t, metrics, MaxUpMetrics)
+	assert.Equal(t, "5", metrics[0].WorkspaceID)
+	assert.Equal(t, strconv.Itoa(MaxUpMetrics+4), metrics[len(metrics)-1].WorkspaceID)
+}
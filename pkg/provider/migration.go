@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/config"
+)
+
+// ErrNoMigrationPath is returned if the migrations of a provider do not lead from one version to another.
+var ErrNoMigrationPath = errors.New("no migration path")
+
+// ProviderMigration migrates the stored options of a workspace from one provider version to the next.
+type ProviderMigration struct {
+	// From is the provider version the migration applies to
+	From string `json:"from"`
+
+	// To is the provider version after the migration
+	To string `json:"to"`
+
+	// Rename maps old option names to their new names
+	Rename map[string]string `json:"rename,omitempty"`
+
+	// Remove are the names of options that are dropped
+	Remove []string `json:"remove,omitempty"`
+
+	// Set are option values that are set if the option is not set already
+	Set map[string]string `json:"set,omitempty"`
+}
+
+// FindMigrations returns the migrations that lead from version from to version to, in the
+// order they have to be applied.
+func FindMigrations(migrations []*ProviderMigration, from, to string) ([]*ProviderMigration, error) {
+	retMigrations := []*ProviderMigration{}
+	current := normalizeVersion(from)
+	visited := map[string]bool{}
+	for current != normalizeVersion(to) {
+		if visited[current] {
+			return nil, fmt.Errorf("migrations of version %s form a cycle", current)
+		}
+		visited[current] = true
+
+		var next *ProviderMigration
+		for _, migration := range migrations {
+			if normalizeVersion(migration.From) == current {
+				next = migration
+				break
+			}
+		}
+		if next == nil {
+			return nil, fmt.Errorf("%w from %s to %s", ErrNoMigrationPath, from, to)
+		}
+
+		retMigrations = append(retMigrations, next)
+		current = normalizeVersion(next.To)
+	}
+
+	return retMigrations, nil
+}
+
+// Apply returns a copy of options with the migration applied.
+func (m *ProviderMigration) Apply(options map[string]config.OptionValue) map[string]config.OptionValue {
+	retOptions := maps.Clone(options)
+	if retOptions == nil {
+		retOptions = map[string]config.OptionValue{}
+	}
+
+	for oldName, newName := range m.Rename {
+		value, ok := retOptions[oldName]
+		if !ok {
+			continue
+		}
+
+		delete(retOptions, oldName)
+		retOptions[newName] = value
+	}
+	for _, name := range m.Remove {
+		delete(retOptions, name)
+	}
+	for name, value := range m.Set {
+		if _, ok := retOptions[name]; !ok {
+			retOptions[name] = config.OptionValue{Value: value, UserProvided: true}
+		}
+	}
+
+	return retOptions
+}
+
+func normalizeVersion(version string) string {
+	return strings.TrimPrefix(strings.TrimSpace(version), "v")
+}
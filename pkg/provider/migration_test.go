@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMigrations(t *testing.T) {
+	migrations := []*ProviderMigration{
+		{From: "v0.2.0", To: "v0.3.0"},
+		{From: "v0.1.0", To: "v0.2.0"},
+	}
+
+	found, err := FindMigrations(migrations, "0.1.0", "v0.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, []*ProviderMigration{migrations[1], migrations[0]}, found)
+
+	found, err = FindMigrations(migrations, "v0.3.0", "v0.3.0")
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	_, err = FindMigrations(migrations, "v0.0.1", "v0.3.0")
+	assert.ErrorIs(t, err, ErrNoMigrationPath)
+
+	_, err = FindMigrations([]*ProviderMigration{
+		{From: "v1", To: "v2"},
+		{From: "v2", To: "v1"},
+	}, "v1", "v3")
+	assert.ErrorContains(t, err, "cycle")
+}
+
+func TestProviderMigrationApply(t *testing.T) {
+	options := map[string]config.OptionValue{
+		"DISK_SIZE": {Value: "40", UserProvided: true},
+		"ZONE":      {Value: "us-east1-b"},
+		"LEGACY":    {Value: "true"},
+	}
+	migration := &ProviderMigration{
+		Rename: map[string]string{"DISK_SIZE": "DISK_SIZE_GB"},
+		Remove: []string{"LEGACY"},
+		Set:    map[string]string{"ZONE": "europe-west1-b", "MACHINE_TYPE": "e2-standard-4"},
+	}
+
+	migrated := migration.Apply(options)
+	assert.Equal(t, map[string]config.OptionValue{
+		"DISK_SIZE_GB": {Value: "40", UserProvided: true},
+		"ZONE":         {Value: "us-east1-b"},
+		"MACHINE_TYPE": {Value: "e2-standard-4", UserProvided: true},
+	}, migrated)
+	assert.Contains(t, options, "LEGACY")
+}
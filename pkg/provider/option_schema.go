@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/skevetter/devpod/pkg/types"
+)
+
+// OptionSchemaDialect is the JSON Schema dialect of the generated option schemas.
+const OptionSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// OptionSchema is a JSON Schema document describing the options of a provider.
+type OptionSchema struct {
+	Schema      string                           `json:"$schema"`
+	Title       string                           `json:"title,omitempty"`
+	Description string                           `json:"description,omitempty"`
+	Type        string                           `json:"type"`
+	Properties  map[string]*OptionSchemaProperty `json:"properties"`
+	Required    []string                         `json:"required,omitempty"`
+}
+
+// OptionSchemaProperty describes a single provider option.
+type OptionSchemaProperty struct {
+	Type        string `json:"type"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Default     any    `json:"default,omitempty"`
+	Enum        []any  `json:"enum,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	Examples    []any  `json:"examples,omitempty"`
+	WriteOnly   bool   `json:"writeOnly,omitempty"`
+
+	// DevPodType is the original option type, e.g. multiline or duration, which
+	// both map to the JSON Schema type string.
+	DevPodType string `json:"x-devpod-type,omitempty"`
+}
+
+// NewOptionSchema builds the JSON Schema of the given provider options. Hidden
+// options are only included if includeHidden is true.
+func NewOptionSchema(provider *ProviderConfig, options map[string]*types.Option, includeHidden bool) *OptionSchema {
+	schema := &OptionSchema{
+		Schema:      OptionSchemaDialect,
+		Title:       provider.Name,
+		Description: provider.Description,
+		Type:        "object",
+		Properties:  map[string]*OptionSchemaProperty{},
+	}
+
+	for name, option := range options {
+		if option == nil || (option.Hidden && !includeHidden) {
+			continue
+		}
+
+		schema.Properties[name] = newOptionSchemaProperty(option)
+
+		// options with a default or a command are filled in by DevPod
+		if option.Required && option.Default == "" && option.Command == "" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	slices.Sort(schema.Required)
+
+	return schema
+}
+
+func newOptionSchemaProperty(option *types.Option) *OptionSchemaProperty {
+	property := &OptionSchemaProperty{
+		Type:        optionSchemaType(option.Type),
+		Title:       option.DisplayName,
+		Description: option.Description,
+		Pattern:     option.ValidationPattern,
+		WriteOnly:   option.Password,
+		DevPodType:  option.Type,
+	}
+	if option.Default != "" {
+		property.Default = optionSchemaValue(property.Type, option.Default)
+	}
+	for _, enum := range option.Enum {
+		property.Enum = append(property.Enum, optionSchemaValue(property.Type, enum.Value))
+	}
+	for _, suggestion := range option.Suggestions {
+		property.Examples = append(property.Examples, optionSchemaValue(property.Type, suggestion))
+	}
+
+	return property
+}
+
+func optionSchemaType(optionType string) string {
+	switch optionType {
+	case "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// optionSchemaValue converts a string option value into the JSON type of the schema
+// property, values that cannot be converted are kept as strings.
+func optionSchemaValue(schemaType, value string) any {
+	switch schemaType {
+	case "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "boolean":
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+
+	return value
+}
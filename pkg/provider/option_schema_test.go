@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOptionSchema(t *testing.T) {
+	schema := NewOptionSchema(&ProviderConfig{Name: "test"}, map[string]*types.Option{
+		"REGION": {
+			Description: "The region",
+			Required:    true,
+			Enum:        types.OptionEnumArray{{Value: "eu"}, {Value: "us"}},
+		},
+		"DISK_SIZE": {
+			Type:     "number",
+			Default:  "40",
+			Required: true,
+		},
+		"TOKEN": {
+			Password: true,
+		},
+		"INJECT": {
+			Type:    "boolean",
+			Default: "true",
+		},
+		"INTERNAL": {
+			Hidden: true,
+		},
+	}, false)
+
+	assert.Equal(t, OptionSchemaDialect, schema.Schema)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"REGION"}, schema.Required)
+	assert.NotContains(t, schema.Properties, "INTERNAL")
+
+	require.Contains(t, schema.Properties, "REGION")
+	assert.Equal(t, "string", schema.Properties["REGION"].Type)
+	assert.Equal(t, []any{"eu", "us"}, schema.Properties["REGION"].Enum)
+	assert.Equal(t, "number", schema.Properties["DISK_SIZE"].Type)
+	assert.Equal(t, 40.0, schema.Properties["DISK_SIZE"].Default)
+	assert.Equal(t, true, schema.Properties["INJECT"].Default)
+	assert.True(t, schema.Properties["TOKEN"].WriteOnly)
+
+	out, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"$schema":"https://json-schema.org/draft/2020-12/schema"`)
+}
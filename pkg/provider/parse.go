@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"reflect"
@@ -26,6 +27,8 @@ var allowedTypes = []string{
 	"boolean",
 }
 
+// ParseProvider parses the provider.yaml. It doesn't validate against the provider schema, as it
+// also loads installed providers, see ParseAndValidateProvider.
 func ParseProvider(reader io.Reader) (*ProviderConfig, error) {
 	payload, err := io.ReadAll(reader)
 	if err != nil {
@@ -46,6 +49,17 @@ func ParseProvider(reader io.Reader) (*ProviderConfig, error) {
 	return parsedConfig, nil
 }
 
+// ParseAndValidateProvider validates the provider.yaml against the provider schema before
+// parsing it. It is used when a provider is added or updated.
+func ParseAndValidateProvider(payload []byte) (*ProviderConfig, error) {
+	err := ValidateProviderSchema(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProvider(bytes.NewReader(payload))
+}
+
 func validate(config *ProviderConfig) error {
 	// validate name
 	if config.Name == "" {
@@ -44,6 +44,9 @@ type ProviderConfig struct {
 
 	// Binaries is an optional field to specify a binary to execute the commands
 	Binaries map[string][]*ProviderBinary `json:"binaries,omitempty"`
+
+	// Migrations migrate the stored workspace options between provider versions
+	Migrations []*ProviderMigration `json:"migrations,omitempty"`
 }
 
 type ProviderOptionGroup struct {
@@ -95,6 +98,10 @@ type ProviderAgentConfig struct {
 	// to delete the container.
 	ContainerTimeout string `json:"containerInactivityTimeout,omitempty"`
 
+	// MinActiveProcesses is the number of active processes in the devcontainer that
+	// prevents the agent from turning off the server due to inactivity.
+	MinActiveProcesses string `json:"minActiveProcesses,omitempty"`
+
 	// InjectGitCredentials signals DevPod if git credentials should get synced into
 	// the remote machine for cloning the repository.
 	InjectGitCredentials types.StrBool `json:"injectGitCredentials,omitempty"`
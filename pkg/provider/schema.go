@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+// ProviderSchemaVersion is the version of the provider.yaml schema used for validation.
+// Bump it and add a new schema file when the provider.yaml format changes.
+const ProviderSchemaVersion = "v1"
+
+//go:embed schema/provider.v1.json
+var providerSchemaV1 []byte
+
+var providerSchemas = map[string][]byte{
+	"v1": providerSchemaV1,
+}
+
+// SchemaError is a single provider.yaml schema violation.
+type SchemaError struct {
+	// Path is the JSON pointer of the invalid value
+	Path string
+
+	// Line is the approximate line of the invalid value in provider.yaml, 0 if unknown
+	Line int
+
+	Message string
+}
+
+func (e SchemaError) String() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", e.Line, path, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", path, e.Message)
+}
+
+// ValidateProviderSchema validates the raw provider.yaml against the embedded schema of the current version.
+func ValidateProviderSchema(payload []byte) error {
+	schema, err := compileProviderSchema(ProviderSchemaVersion)
+	if err != nil {
+		return err
+	}
+
+	jsonPayload, err := yaml.YAMLToJSON(payload)
+	if err != nil {
+		return fmt.Errorf("parse provider config: %w", err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("parse provider config: %w", err)
+	}
+
+	err = schema.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	validationErr := &jsonschema.ValidationError{}
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	schemaErrors := collectSchemaErrors(validationErr, payload)
+	messages := make([]string, 0, len(schemaErrors))
+	for _, schemaError := range schemaErrors {
+		messages = append(messages, schemaError.String())
+	}
+
+	return fmt.Errorf("provider.yaml doesn't match schema %s:\n%s", ProviderSchemaVersion, strings.Join(messages, "\n"))
+}
+
+func compileProviderSchema(version string) (*jsonschema.Schema, error) {
+	rawSchema, ok := providerSchemas[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider schema version %s", version)
+	}
+
+	schemaDoc, err := jsonschema.UnmarshalJSON(bytes.NewReader(rawSchema))
+	if err != nil {
+		return nil, fmt.Errorf("parse provider schema: %w", err)
+	}
+
+	url := "provider." + version + ".json"
+	compiler := jsonschema.NewCompiler()
+	err = compiler.AddResource(url, schemaDoc)
+	if err != nil {
+		return nil, fmt.Errorf("add provider schema: %w", err)
+	}
+
+	return compiler.Compile(url)
+}
+
+// collectSchemaErrors flattens the validation error tree into its leaf errors.
+func collectSchemaErrors(validationErr *jsonschema.ValidationError, payload []byte) []SchemaError {
+	root := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(payload, root); err != nil {
+		root = nil
+	}
+
+	printer := message.NewPrinter(language.English)
+	schemaErrors := []SchemaError{}
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			schemaErrors = append(schemaErrors, SchemaError{
+				Path:    jsonPointer(e.InstanceLocation),
+				Line:    findYAMLLine(root, e.InstanceLocation),
+				Message: e.ErrorKind.LocalizedString(printer),
+			})
+			return
+		}
+
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(validationErr)
+
+	return schemaErrors
+}
+
+func jsonPointer(tokens []string) string {
+	pointer := ""
+	for _, token := range tokens {
+		token = strings.ReplaceAll(token, "~", "~0")
+		pointer += "/" + strings.ReplaceAll(token, "/", "~1")
+	}
+
+	return pointer
+}
+
+// findYAMLLine returns the line of the yaml node at the given path or of its closest parent.
+func findYAMLLine(root *yamlv3.Node, path []string) int {
+	if root == nil || len(root.Content) == 0 {
+		return 0
+	}
+
+	node := root.Content[0]
+	line := node.Line
+	for _, token := range path {
+		var next *yamlv3.Node
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == token {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yamlv3.SequenceNode:
+			index, err := strconv.Atoi(token)
+			if err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			return line
+		}
+
+		node = next
+	}
+
+	return line
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateProviderSchemaRepoProviders(t *testing.T) {
+	for _, path := range []string{
+		"../../providers/docker/provider.yaml",
+		"../../providers/kubernetes/provider.yaml",
+		"../../providers/pro/provider.yaml",
+		"../../examples/simple-k8s-provider/provider.yaml",
+		"../../e2e/tests/machineprovider/testdata/machineprovider/provider.yaml",
+		"../../e2e/tests/machineprovider/testdata/machineprovider2/provider.yaml",
+	} {
+		t.Run(path, func(t *testing.T) {
+			payload, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.NoError(t, ValidateProviderSchema(payload))
+		})
+	}
+}
+
+func TestValidateProviderSchemaReportsLine(t *testing.T) {
+	payload := []byte(`name: test
+version: v0.0.1
+options:
+  FOO:
+    type: list
+exec:
+  command: echo
+`)
+
+	err := ValidateProviderSchema(payload)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 5: /options/FOO/type")
+}
+
+func TestValidateProviderSchemaUnknownField(t *testing.T) {
+	payload := []byte(`name: test
+version: v0.0.1
+exec:
+  command: echo
+unknown: true
+`)
+
+	err := ValidateProviderSchema(payload)
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "unknown"), err.Error())
+}
+
+func TestValidateProviderSchemaMigrations(t *testing.T) {
+	payload := []byte(`name: test
+version: v0.2.0
+exec:
+  command: echo
+migrations:
+  - from: v0.1.0
+    to: v0.2.0
+    rename:
+      DISK_SIZE: DISK_SIZE_GB
+    remove:
+      - LEGACY
+`)
+	assert.NoError(t, ValidateProviderSchema(payload))
+
+	payload = []byte(`name: test
+version: v0.2.0
+exec:
+  command: echo
+migrations:
+  - from: v0.1.0
+`)
+	assert.Error(t, ValidateProviderSchema(payload))
+}
+
+func TestParseProviderAcceptsUnknownFields(t *testing.T) {
+	payload := []byte(`name: test
+version: v0.0.1
+exec:
+  command: echo
+agent:
+  minActiveProcesses: "2"
+unknown: true
+`)
+
+	providerConfig, err := ParseProvider(bytes.NewReader(payload))
+	require.NoError(t, err, "installed providers must still load")
+	assert.Equal(t, "2", providerConfig.Agent.MinActiveProcesses)
+
+	_, err = ParseAndValidateProvider(payload)
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/skevetter/devpod/pkg/types"
+)
+
+// SealHash returns the SHA-256 of the workspace config and the container image digest. The
+// seal itself and the last used timestamp, which changes on every access, are not part of the
+// hash so it can be verified later.
+func SealHash(workspace *Workspace, imageDigest string) (string, error) {
+	sealed := *workspace
+	sealed.Seal = nil
+	sealed.LastUsedTimestamp = types.Time{}
+
+	out, err := json.Marshal(&sealed)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write(out)
+	_, _ = hash.Write([]byte(imageDigest))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// SealInfo is printed by the agent for devpod workspace seal.
+type SealInfo struct {
+	// ImageDigest is the digest of the image of the workspace container
+	ImageDigest string `json:"imageDigest"`
+
+	// Changes are the files changed in the container outside of /workspaces, e.g. "A /etc/motd"
+	Changes []string `json:"changes,omitempty"`
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealHash(t *testing.T) {
+	workspace := &Workspace{ID: "my-ws", Source: WorkspaceSource{GitRepository: "github.com/a/b"}}
+	hash, err := SealHash(workspace, "sha256:1234")
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	workspace.LastUsedTimestamp = types.NewTime(time.Now())
+	workspace.Seal = &WorkspaceSeal{Hash: hash}
+	sameHash, err := SealHash(workspace, "sha256:1234")
+	require.NoError(t, err)
+	assert.Equal(t, hash, sameHash, "seal and last used timestamp are not part of the hash")
+
+	otherImage, err := SealHash(workspace, "sha256:5678")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherImage)
+
+	workspace.Source.GitBranch = "main"
+	otherConfig, err := SealHash(workspace, "sha256:1234")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherConfig)
+}
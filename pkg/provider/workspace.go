@@ -74,6 +74,33 @@ type Workspace struct {
 
 	// Path to an alternate file where DevPod entries are written (for read-only SSH configs)
 	SSHConfigIncludePath string `json:"sshConfigIncludePath,omitempty"`
+
+	// Tags are user defined labels to organize workspaces
+	Tags []string `json:"tags,omitempty"`
+
+	// Annotations are user defined key value metadata, unlike labels they don't affect the container
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Pinned protects the workspace from being deleted or stopped in bulk without --force
+	Pinned bool `json:"pinned,omitempty"`
+
+	// ExtraMounts are mounts attached via devpod workspace attach-volume that are added on every devpod up
+	ExtraMounts []string `json:"extraMounts,omitempty"`
+
+	// ResourceLimits are the container limits set via devpod workspace set-cpu-limit and
+	// set-memory-limit that are applied on every devpod up
+	ResourceLimits *ResourceLimits `json:"resourceLimits,omitempty"`
+
+	// GitConfig is the git identity set via devpod workspace set-git-config that is configured in
+	// the container on every devpod up
+	GitConfig *WorkspaceGitConfig `json:"gitConfig,omitempty"`
+
+	// FeatureOptions are the feature options set via devpod workspace set-feature-options by
+	// feature id that override the options of the devcontainer.json
+	FeatureOptions map[string]map[string]string `json:"featureOptions,omitempty"`
+
+	// Seal is set by devpod workspace seal, sealed workspaces can't be recreated
+	Seal *WorkspaceSeal `json:"seal,omitempty"`
 }
 
 type ProMetadata struct {
@@ -93,6 +120,10 @@ type WorkspaceIDEConfig struct {
 
 	// Options are the local options that override the global ones
 	Options map[string]config.OptionValue `json:"options,omitempty"`
+
+	// Shell is the shell used for ssh sessions and commands in the workspace container.
+	// If empty, the login shell of the remote user is used.
+	Shell string `json:"shell,omitempty"`
 }
 
 type WorkspaceMachineConfig struct {
@@ -112,6 +143,50 @@ type WorkspaceProviderConfig struct {
 	Options map[string]config.OptionValue `json:"options,omitempty"`
 }
 
+// WorkspaceGitConfig is the git identity of the workspace container.
+type WorkspaceGitConfig struct {
+	// Name is the git user.name
+	Name string `json:"name,omitempty"`
+
+	// Email is the git user.email
+	Email string `json:"email,omitempty"`
+}
+
+// WorkspaceSeal records the state of a workspace at the time it was sealed.
+type WorkspaceSeal struct {
+	// Hash is the SHA-256 of the workspace config and the container image digest
+	Hash string `json:"hash"`
+
+	// ImageDigest is the digest of the container image
+	ImageDigest string `json:"imageDigest"`
+
+	// Timestamp is the time the workspace was sealed
+	Timestamp types.Time `json:"timestamp"`
+}
+
+// ResourceLimits are the cpu and memory limits of the workspace container in docker notation.
+type ResourceLimits struct {
+	// CPUs is the number of cpus, e.g. 1.5
+	CPUs string `json:"cpus,omitempty"`
+
+	// Memory is the memory limit, e.g. 4g
+	Memory string `json:"memory,omitempty"`
+}
+
+const (
+	NetworkPolicyAllow = "allow"
+	NetworkPolicyDeny  = "deny"
+)
+
+// NetworkPolicy is an egress rule of the workspace container.
+type NetworkPolicy struct {
+	// Action is either allow or deny
+	Action string `json:"action"`
+
+	// CIDR is the destination the rule applies to
+	CIDR string `json:"cidr"`
+}
+
 type WorkspaceSource struct {
 	// GitRepository is the repository to clone
 	GitRepository string `json:"gitRepository,omitempty"`
@@ -199,6 +274,9 @@ type AgentWorkspaceInfo struct {
 
 	// RegistryCache defines the registry to use for caching builds
 	RegistryCache string `json:"registryCache,omitempty"`
+
+	// NetworkPolicies are the egress rules applied to the container on start
+	NetworkPolicies []NetworkPolicy `json:"networkPolicies,omitempty"`
 }
 
 type CLIOptions struct {
@@ -216,13 +294,16 @@ type CLIOptions struct {
 	DevContainerID              string            `json:"devContainerID,omitempty"`
 	WorkspaceEnv                []string          `json:"workspaceEnv,omitempty"`
 	WorkspaceEnvFile            []string          `json:"workspaceEnvFile,omitempty"`
+	DevContainerEnv             []string          `json:"devContainerEnv,omitempty"`
 	InitEnv                     []string          `json:"initEnv,omitempty"`
 	Recreate                    bool              `json:"recreate,omitempty"`
 	Reset                       bool              `json:"reset,omitempty"`
+	AgentForceReinstall         bool              `json:"agentForceReinstall,omitempty"`
 	DisableDaemon               bool              `json:"disableDaemon,omitempty"`
 	DaemonInterval              string            `json:"daemonInterval,omitempty"`
 	GitCloneStrategy            git.CloneStrategy `json:"gitCloneStrategy,omitempty"`
 	GitCloneRecursiveSubmodules bool              `json:"gitCloneRecursive,omitempty"`
+	GitCloneDepth               int               `json:"gitCloneDepth,omitempty"`
 	FallbackImage               string            `json:"fallbackImage,omitempty"`
 	GitSSHSigningKey            string            `json:"gitSshSigningKey,omitempty"`
 	SSHAuthSockID               string            `json:"sshAuthSockID,omitempty"` // ID to use when looking for SSH_AUTH_SOCK, defaults to a new random ID if not set (only used for browser IDEs)
@@ -234,6 +315,14 @@ type CLIOptions struct {
 	Userns                      string            `json:"userns,omitempty"`
 	UidMap                      []string          `json:"uidMap,omitempty"`
 	GidMap                      []string          `json:"gidMap,omitempty"`
+	Network                     string            `json:"network,omitempty"`
+	CreateNetwork               bool              `json:"createNetwork,omitempty"`
+	ImagePullPolicy             string            `json:"imagePullPolicy,omitempty"`
+	RegistryMirror              string            `json:"registryMirror,omitempty"`
+	SkipLifecycleCommands       bool              `json:"skipLifecycleCommands,omitempty"`
+	Tracing                     *TracingOptions   `json:"tracing,omitempty"`
+	SSHDAllowedCiphers          string            `json:"sshdAllowedCiphers,omitempty"`
+	SSHDAllowedKeyExchanges     string            `json:"sshdAllowedKeyExchanges,omitempty"`
 
 	// build options
 	// Repository specifies the container registry repository to push the built image to (e.g., ghcr.io/user/image).
@@ -261,6 +350,13 @@ type CLIOptions struct {
 	ForceInternalBuildKit bool `json:"forceInternalBuildKit,omitempty"`
 }
 
+// TracingOptions carry the trace of devpod up to the agent, so the spans of the agent are part
+// of the same trace. The agent sends its spans back to the client through the tunnel.
+type TracingOptions struct {
+	// Carrier holds the W3C trace context of the parent span
+	Carrier map[string]string `json:"carrier,omitempty"`
+}
+
 // BuildOptions extends CLIOptions with additional build-specific configuration.
 type BuildOptions struct {
 	CLIOptions
@@ -0,0 +1,19 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// envDriver reads the secret from the environment variable named by the path.
+type envDriver struct{}
+
+func (d *envDriver) Fetch(_ context.Context, path string) ([]byte, error) {
+	value, ok := os.LookupEnv(path)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", path)
+	}
+
+	return []byte(value), nil
+}
@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Driver fetches a secret from a secret manager.
+type Driver interface {
+	// Fetch returns the secret stored at path as a JSON object mapping keys to values.
+	Fetch(ctx context.Context, path string) ([]byte, error)
+}
+
+var drivers = map[string]Driver{
+	"env":   &envDriver{},
+	"vault": &vaultDriver{},
+	"ssm":   &ssmDriver{},
+}
+
+// Register adds a driver for the given provider name, replacing an existing one.
+func Register(name string, driver Driver) {
+	drivers[name] = driver
+}
+
+// Fetch fetches the secret referenced by ref in the form <provider>:<path> and returns its
+// key-value pairs.
+func Fetch(ctx context.Context, ref string) (map[string]string, error) {
+	name, path, ok := strings.Cut(ref, ":")
+	if !ok || name == "" || path == "" {
+		return nil, fmt.Errorf("invalid secret %s, expected <provider>:<path>", ref)
+	}
+
+	driver, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf(
+			"unknown secret provider %s in %s, supported are %s",
+			name,
+			ref,
+			strings.Join(slices.Sorted(maps.Keys(drivers)), ", "),
+		)
+	}
+
+	raw, err := driver.Fetch(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("fetch secret %s: %w", ref, err)
+	}
+
+	values := map[string]string{}
+	err = json.Unmarshal(raw, &values)
+	if err != nil {
+		return nil, fmt.Errorf("secret %s must be a JSON object of string values: %w", ref, err)
+	}
+
+	return values, nil
+}
+
+// FetchEnv fetches the secrets referenced by refs and returns their values as KEY=VALUE pairs.
+// Keys of later secrets override the ones of earlier secrets.
+func FetchEnv(ctx context.Context, refs []string) ([]string, error) {
+	env := []string{}
+	for _, ref := range refs {
+		values, err := Fetch(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(values)) {
+			env = append(env, key+"="+values[key])
+		}
+	}
+
+	return env, nil
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchEnv(t *testing.T) {
+	t.Setenv("MY_SECRET", `{"B":"2","A":"1"}`)
+
+	env, err := FetchEnv(context.Background(), []string{"env:MY_SECRET"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"A=1", "B=2"}, env)
+}
+
+func TestFetchErrors(t *testing.T) {
+	t.Setenv("MY_SECRET", `{"A":1}`)
+
+	_, err := Fetch(context.Background(), "MY_SECRET")
+	require.EqualError(t, err, "invalid secret MY_SECRET, expected <provider>:<path>")
+
+	_, err = Fetch(context.Background(), "unknown:MY_SECRET")
+	require.EqualError(t, err, "unknown secret provider unknown in unknown:MY_SECRET, supported are env, ssm, vault")
+
+	_, err = Fetch(context.Background(), "env:MISSING_SECRET")
+	require.EqualError(t, err, "fetch secret env:MISSING_SECRET: environment variable MISSING_SECRET is not set")
+
+	_, err = Fetch(context.Background(), "env:MY_SECRET")
+	require.ErrorContains(t, err, "secret env:MY_SECRET must be a JSON object of string values")
+}
+
+func TestFetchVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "my-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/my-app":
+			_, _ = w.Write([]byte(`{"data":{"data":{"A":"1"},"metadata":{"version":1}}}`))
+		case "/v1/kv/my-app":
+			_, _ = w.Write([]byte(`{"data":{"B":"2"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	t.Setenv(envVaultAddr, server.URL)
+	t.Setenv(envVaultToken, "my-token")
+
+	values, err := Fetch(context.Background(), "vault:secret/data/my-app")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"A": "1"}, values)
+
+	values, err = Fetch(context.Background(), "vault:kv/my-app")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"B": "2"}, values)
+
+	_, err = Fetch(context.Background(), "vault:kv/missing")
+	require.ErrorContains(t, err, "fetch secret vault:kv/missing: vault returned 404 Not Found")
+}
@@ -0,0 +1,33 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ssmDriver reads the secret from an AWS SSM parameter. Credentials and region are taken from
+// the standard AWS environment variables and config files.
+type ssmDriver struct{}
+
+func (d *ssmDriver) Fetch(ctx context.Context, path string) ([]byte, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load aws config: %w", err)
+	}
+
+	out, err := ssm.NewFromConfig(cfg).GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(path),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	} else if out.Parameter == nil || out.Parameter.Value == nil {
+		return nil, fmt.Errorf("parameter %s has no value", path)
+	}
+
+	return []byte(*out.Parameter.Value), nil
+}
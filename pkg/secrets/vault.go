@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	envVaultAddr      = "VAULT_ADDR"
+	envVaultToken     = "VAULT_TOKEN"
+	envVaultNamespace = "VAULT_NAMESPACE"
+)
+
+// vaultDriver reads the secret from HashiCorp Vault via VAULT_ADDR and VAULT_TOKEN. Both KV
+// version 1 and 2 secrets are supported, for version 2 the path has to include the data segment,
+// e.g. secret/data/my-app.
+type vaultDriver struct{}
+
+type vaultResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+func (d *vaultDriver) Fetch(ctx context.Context, path string) ([]byte, error) {
+	addr := os.Getenv(envVaultAddr)
+	token := os.Getenv(envVaultToken)
+	if addr == "" || token == "" {
+		return nil, fmt.Errorf("%s and %s need to be set", envVaultAddr, envVaultToken)
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv(envVaultNamespace); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return vaultSecretData(body)
+}
+
+// vaultSecretData returns the key-value pairs of a vault read response, unwrapping the nested
+// data of KV version 2 secrets.
+func vaultSecretData(body []byte) ([]byte, error) {
+	response := &vaultResponse{}
+	err := json.Unmarshal(body, response)
+	if err != nil {
+		return nil, fmt.Errorf("parse vault response: %w", err)
+	} else if response.Data == nil {
+		return nil, fmt.Errorf("vault response has no data")
+	}
+
+	_, hasMetadata := response.Data["metadata"]
+	if data, ok := response.Data["data"]; ok && hasMetadata {
+		return data, nil
+	}
+
+	return json.Marshal(response.Data)
+}
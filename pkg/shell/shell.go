@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/skevetter/devpod/pkg/config"
 	"mvdan.cc/sh/v3/expand"
 	"mvdan.cc/sh/v3/interp"
 	"mvdan.cc/sh/v3/syntax"
@@ -116,6 +117,19 @@ func (devNull) Close() error {
 	return nil
 }
 
+// GetInteractiveShell returns the shell for interactive sessions. It prefers the shell
+// configured via devpod up --shell and otherwise falls back to GetShell. Commands must not run
+// with this shell as it might not be POSIX compatible.
+func GetInteractiveShell(userName string) ([]string, error) {
+	if runtime.GOOS != "windows" {
+		if shell := os.Getenv(config.EnvDefaultShell); shell != "" {
+			return []string{shell}, nil
+		}
+	}
+
+	return GetShell(userName)
+}
+
 func GetShell(userName string) ([]string, error) {
 	// try to get a shell
 	if runtime.GOOS != "windows" {
@@ -5,7 +5,10 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"runtime"
 	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
 )
 
 func TestRunEmulatedShell_KillExecutesRealBinary(t *testing.T) {
@@ -26,3 +29,26 @@ func TestRunEmulatedShell_KillExecutesRealBinary(t *testing.T) {
 		)
 	}
 }
+
+func TestGetInteractiveShell_PrefersDefaultShellEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("default shell is not used on windows")
+	}
+
+	t.Setenv(config.EnvDefaultShell, "/usr/bin/fish")
+	shell, err := GetInteractiveShell("")
+	if err != nil {
+		t.Fatalf("get interactive shell: %v", err)
+	}
+	if len(shell) != 1 || shell[0] != "/usr/bin/fish" {
+		t.Fatalf("expected /usr/bin/fish, got %v", shell)
+	}
+
+	shell, err = GetShell("")
+	if err != nil {
+		t.Fatalf("get shell: %v", err)
+	}
+	if len(shell) > 0 && shell[0] == "/usr/bin/fish" {
+		t.Fatalf("expected the login shell for commands, got %v", shell)
+	}
+}
@@ -3,6 +3,7 @@
 package agent
 
 import (
+	"net"
 	"os"
 
 	"github.com/skevetter/devpod/pkg/util"
@@ -23,6 +24,10 @@ func ForwardToRemote(client *ssh.Client, addr string) error {
 	return gosshagent.ForwardToRemote(client, addr)
 }
 
+func dialAgent(addr string) (net.Conn, error) {
+	return net.Dial("unix", addr)
+}
+
 func RequestAgentForwarding(session *ssh.Session) error {
 	return gosshagent.RequestAgentForwarding(session)
 }
@@ -3,6 +3,7 @@ package agent
 import (
 	"errors"
 	"io"
+	"net"
 	"os"
 	"strings"
 	"sync"
@@ -62,6 +63,13 @@ func ForwardToRemote(client *ssh.Client, addr string) error {
 	return gosshagent.ForwardToRemote(client, addr)
 }
 
+func dialAgent(addr string) (net.Conn, error) {
+	if strings.Contains(addr, "\\\\.\\pipe\\") {
+		return winio.DialPipe(addr, nil)
+	}
+	return net.Dial("unix", addr)
+}
+
 func RequestAgentForwarding(session *ssh.Session) error {
 	return gosshagent.RequestAgentForwarding(session)
 }
@@ -0,0 +1,157 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	gosshagent "golang.org/x/crypto/ssh/agent"
+)
+
+var errFilteredAgentReadOnly = errors.New("agent: forwarded agent is read only")
+
+// filteredAgent wraps an agent and only exposes the key with the given fingerprint.
+// Requests that modify the agent are refused.
+type filteredAgent struct {
+	upstream    gosshagent.ExtendedAgent
+	fingerprint string
+}
+
+// NewFilteredAgent returns an agent that only lists and signs with the key of upstream
+// matching the given SHA256 fingerprint.
+func NewFilteredAgent(upstream gosshagent.ExtendedAgent, fingerprint string) gosshagent.ExtendedAgent {
+	return &filteredAgent{
+		upstream:    upstream,
+		fingerprint: fingerprint,
+	}
+}
+
+func (a *filteredAgent) List() ([]*gosshagent.Key, error) {
+	keys, err := a.upstream.List()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*gosshagent.Key{}
+	for _, key := range keys {
+		if ssh.FingerprintSHA256(key) == a.fingerprint {
+			filtered = append(filtered, key)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (a *filteredAgent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *filteredAgent) SignWithFlags(
+	key ssh.PublicKey,
+	data []byte,
+	flags gosshagent.SignatureFlags,
+) (*ssh.Signature, error) {
+	if ssh.FingerprintSHA256(key) != a.fingerprint {
+		return nil, fmt.Errorf("agent: key %s is not forwarded", ssh.FingerprintSHA256(key))
+	}
+
+	return a.upstream.SignWithFlags(key, data, flags)
+}
+
+func (a *filteredAgent) Signers() ([]ssh.Signer, error) {
+	signers, err := a.upstream.Signers()
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []ssh.Signer{}
+	for _, signer := range signers {
+		if ssh.FingerprintSHA256(signer.PublicKey()) == a.fingerprint {
+			filtered = append(filtered, signer)
+		}
+	}
+
+	return filtered, nil
+}
+
+func (a *filteredAgent) Add(gosshagent.AddedKey) error {
+	return errFilteredAgentReadOnly
+}
+
+func (a *filteredAgent) Remove(ssh.PublicKey) error {
+	return errFilteredAgentReadOnly
+}
+
+func (a *filteredAgent) RemoveAll() error {
+	return errFilteredAgentReadOnly
+}
+
+func (a *filteredAgent) Lock([]byte) error {
+	return errFilteredAgentReadOnly
+}
+
+func (a *filteredAgent) Unlock([]byte) error {
+	return errFilteredAgentReadOnly
+}
+
+func (a *filteredAgent) Extension(string, []byte) ([]byte, error) {
+	return nil, gosshagent.ErrExtensionUnsupported
+}
+
+// ForwardIdentityToRemote forwards the agent at addr to the remote, but only exposes the
+// key with the given fingerprint. The key has to be loaded into the agent.
+func ForwardIdentityToRemote(client *ssh.Client, addr, fingerprint string) error {
+	conn, err := dialAgent(addr)
+	if err != nil {
+		return fmt.Errorf("connect to ssh agent: %w", err)
+	}
+
+	filtered := NewFilteredAgent(gosshagent.NewClient(conn), fingerprint)
+	keys, err := filtered.List()
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("list ssh agent keys: %w", err)
+	} else if len(keys) == 0 {
+		_ = conn.Close()
+		return fmt.Errorf("key %s is not loaded into the ssh agent, add it via ssh-add", fingerprint)
+	}
+
+	go func() {
+		_ = client.Wait()
+		_ = conn.Close()
+	}()
+
+	return gosshagent.ForwardToAgent(client, filtered)
+}
+
+// IdentityFingerprint returns the SHA256 fingerprint of the given key file. For private keys
+// the public key is read from the .pub file next to it if the private key is encrypted.
+func IdentityFingerprint(path string) (string, error) {
+	// #nosec G304 -- path is provided by the user
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read identity: %w", err)
+	}
+
+	if publicKey, _, _, _, err := ssh.ParseAuthorizedKey(data); err == nil {
+		return ssh.FingerprintSHA256(publicKey), nil
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if err == nil {
+		return ssh.FingerprintSHA256(signer.PublicKey()), nil
+	}
+
+	if !strings.HasSuffix(path, ".pub") {
+		// #nosec G304 -- path is provided by the user
+		if data, pubErr := os.ReadFile(path + ".pub"); pubErr == nil {
+			if publicKey, _, _, _, pubErr := ssh.ParseAuthorizedKey(data); pubErr == nil {
+				return ssh.FingerprintSHA256(publicKey), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("parse identity %s: %w", path, err)
+}
@@ -0,0 +1,80 @@
+package agent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	gosshagent "golang.org/x/crypto/ssh/agent"
+)
+
+func newTestKey(t *testing.T) (ed25519.PrivateKey, ssh.PublicKey) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	return privateKey, sshPublicKey
+}
+
+func TestFilteredAgent(t *testing.T) {
+	forwardedKey, forwardedPublicKey := newTestKey(t)
+	otherKey, otherPublicKey := newTestKey(t)
+
+	keyring := gosshagent.NewKeyring().(gosshagent.ExtendedAgent)
+	require.NoError(t, keyring.Add(gosshagent.AddedKey{PrivateKey: forwardedKey}))
+	require.NoError(t, keyring.Add(gosshagent.AddedKey{PrivateKey: otherKey}))
+
+	filtered := NewFilteredAgent(keyring, ssh.FingerprintSHA256(forwardedPublicKey))
+
+	keys, err := filtered.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, forwardedPublicKey.Marshal(), keys[0].Marshal())
+
+	signers, err := filtered.Signers()
+	require.NoError(t, err)
+	assert.Len(t, signers, 1)
+
+	_, err = filtered.Sign(forwardedPublicKey, []byte("data"))
+	assert.NoError(t, err)
+	_, err = filtered.Sign(otherPublicKey, []byte("data"))
+	assert.Error(t, err)
+
+	assert.Error(t, filtered.RemoveAll())
+	keys, err = keyring.List()
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestIdentityFingerprint(t *testing.T) {
+	privateKey, publicKey := newTestKey(t)
+	dir := t.TempDir()
+
+	pemBlock, err := ssh.MarshalPrivateKey(privateKey, "")
+	require.NoError(t, err)
+	privateKeyFile := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(privateKeyFile, pem.EncodeToMemory(pemBlock), 0o600))
+
+	encryptedBlock, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte("secret"))
+	require.NoError(t, err)
+	encryptedKeyFile := filepath.Join(dir, "id_encrypted")
+	require.NoError(t, os.WriteFile(encryptedKeyFile, pem.EncodeToMemory(encryptedBlock), 0o600))
+	require.NoError(t, os.WriteFile(encryptedKeyFile+".pub", ssh.MarshalAuthorizedKey(publicKey), 0o600))
+
+	for _, path := range []string{privateKeyFile, encryptedKeyFile, encryptedKeyFile + ".pub"} {
+		fingerprint, err := IdentityFingerprint(path)
+		require.NoError(t, err, path)
+		assert.Equal(t, ssh.FingerprintSHA256(publicKey), fingerprint, path)
+	}
+
+	_, err = IdentityFingerprint(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
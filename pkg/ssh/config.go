@@ -2,10 +2,12 @@ package ssh
 
 import (
 	"bufio"
+	"cmp"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"slices"
 	"strings"
@@ -19,6 +21,9 @@ import (
 
 var configLock sync.Mutex
 
+// devPodProxyCommandMarker is part of every ProxyCommand built by DevPod.
+const devPodProxyCommandMarker = " ssh --stdio --context "
+
 var (
 	MarkerStartPrefix = "# DevPod Start "
 	MarkerEndPrefix   = "# DevPod End "
@@ -35,6 +40,9 @@ type SSHConfigParams struct {
 	GPGAgent             bool
 	DevPodHome           string
 	Provider             string
+	IdentityFile         string
+	X11Forwarding        bool
+	X11Trusted           bool
 	Log                  log.Logger
 }
 
@@ -47,18 +55,7 @@ func ConfigureSSHConfig(params SSHConfigParams) error {
 		targetPath = params.SSHConfigIncludePath
 	}
 
-	newFile, err := addHost(addHostParams{
-		path:       targetPath,
-		host:       params.Workspace + config.SSHHostSuffix,
-		user:       params.User,
-		context:    params.Context,
-		workspace:  params.Workspace,
-		workdir:    params.Workdir,
-		command:    params.Command,
-		gpgagent:   params.GPGAgent,
-		devPodHome: params.DevPodHome,
-		provider:   params.Provider,
-	})
+	newFile, err := addHost(newAddHostParams(targetPath, params))
 	if err != nil {
 		return fmt.Errorf("parse ssh config: %w", err)
 	}
@@ -66,6 +63,144 @@ func ConfigureSSHConfig(params SSHConfigParams) error {
 	return writeSSHConfig(targetPath, newFile, params.Log)
 }
 
+// UpdateSSHConfig rewrites the host entry of the workspace. The given params are the defaults,
+// the params of an existing entry, e.g. a custom ProxyCommand, take precedence and update
+// changes the fields the caller wants to set.
+func UpdateSSHConfig(params SSHConfigParams, update func(params *SSHConfigParams)) error {
+	configLock.Lock()
+	defer configLock.Unlock()
+
+	targetPath := cmp.Or(params.SSHConfigIncludePath, params.SSHConfigPath)
+	err := loadSSHConfigParams(targetPath, &params)
+	if err != nil {
+		return fmt.Errorf("parse ssh config: %w", err)
+	}
+	update(&params)
+
+	newFile, err := addHost(newAddHostParams(targetPath, params))
+	if err != nil {
+		return fmt.Errorf("parse ssh config: %w", err)
+	}
+
+	return writeSSHConfig(targetPath, newFile, params.Log)
+}
+
+// ResolveSSHConfigPaths resolves the ssh config and include path of a workspace. Empty paths
+// fall back to the context options.
+func ResolveSSHConfigPaths(
+	devPodConfig *config.Config,
+	sshConfigPath string,
+	sshConfigIncludePath string,
+) (string, string, error) {
+	sshConfigPath, err := ResolveSSHConfigPath(
+		cmp.Or(sshConfigPath, devPodConfig.ContextOption(config.ContextOptionSSHConfigPath)),
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid ssh config path: %w", err)
+	}
+
+	sshConfigIncludePath = cmp.Or(
+		sshConfigIncludePath,
+		devPodConfig.ContextOption(config.ContextOptionSSHConfigIncludePath),
+	)
+	if sshConfigIncludePath != "" {
+		sshConfigIncludePath, err = ResolveSSHConfigPath(sshConfigIncludePath)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid ssh config include path: %w", err)
+		}
+	}
+
+	return sshConfigPath, sshConfigIncludePath, nil
+}
+
+// loadSSHConfigParams overrides params with the values of the existing host entry.
+func loadSSHConfigParams(path string, params *SSHConfigParams) error {
+	_, err := transformHostSection(
+		path,
+		params.Workspace+config.SSHHostSuffix,
+		func(line string) string {
+			key, value, _ := strings.Cut(strings.TrimSpace(line), " ")
+			switch strings.ToLower(key) {
+			case "user":
+				params.User = unquote(value)
+			case "identityfile":
+				params.IdentityFile = unquote(value)
+			case "forwardx11":
+				params.X11Forwarding = value == "yes"
+			case "forwardx11trusted":
+				params.X11Trusted = value == "yes"
+			case "proxycommand":
+				loadProxyCommandParams(value, params)
+			}
+
+			return line
+		},
+	)
+
+	return err
+}
+
+var (
+	workdirFlagRegExp    = regexp.MustCompile(`--workdir "([^"]*)"`)
+	devPodHomeFlagRegExp = regexp.MustCompile(`--devpod-home "([^"]*)"`)
+)
+
+// loadProxyCommandParams reads the flags of a ProxyCommand built by DevPod. Any other
+// command is a custom ProxyCommand.
+func loadProxyCommandParams(proxyCommand string, params *SSHConfigParams) {
+	if !strings.Contains(proxyCommand, devPodProxyCommandMarker) {
+		params.Command = unquote(proxyCommand)
+		return
+	}
+
+	params.Command = ""
+	if match := workdirFlagRegExp.FindStringSubmatch(proxyCommand); match != nil {
+		params.Workdir = match[1]
+	}
+	if match := devPodHomeFlagRegExp.FindStringSubmatch(proxyCommand); match != nil {
+		params.DevPodHome = match[1]
+	}
+	params.GPGAgent = params.GPGAgent || strings.Contains(proxyCommand, "--gpg-agent-forwarding")
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// SSHConfigEntry returns the host entry ConfigureSSHConfig would write without modifying any file.
+func SSHConfigEntry(params SSHConfigParams) (string, error) {
+	execPath, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	hostParams := newAddHostParams("", params)
+	lines := buildSSHConfigLines(hostParams, buildProxyCommand(execPath, hostParams))
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func newAddHostParams(path string, params SSHConfigParams) addHostParams {
+	return addHostParams{
+		path:         path,
+		host:         params.Workspace + config.SSHHostSuffix,
+		user:         params.User,
+		context:      params.Context,
+		workspace:    params.Workspace,
+		workdir:      params.Workdir,
+		command:      params.Command,
+		gpgagent:     params.GPGAgent,
+		devPodHome:   params.DevPodHome,
+		provider:     params.Provider,
+		identityFile: params.IdentityFile,
+		x11:          params.X11Forwarding,
+		x11Trusted:   params.X11Trusted,
+	}
+}
+
 type DevPodSSHEntry struct {
 	Host      string
 	User      string
@@ -73,16 +208,19 @@ type DevPodSSHEntry struct {
 }
 
 type addHostParams struct {
-	path       string
-	host       string
-	user       string
-	context    string
-	workspace  string
-	workdir    string
-	command    string
-	gpgagent   bool
-	devPodHome string
-	provider   string
+	path         string
+	host         string
+	user         string
+	context      string
+	workspace    string
+	workdir      string
+	command      string
+	gpgagent     bool
+	devPodHome   string
+	provider     string
+	identityFile string
+	x11          bool
+	x11Trusted   bool
 }
 
 func addHost(params addHostParams) (string, error) {
@@ -139,11 +277,15 @@ func (b *proxyCommandBuilder) withGPGAgent(enabled bool) *proxyCommandBuilder {
 	return b
 }
 
-func (b *proxyCommandBuilder) build() string {
+func (b *proxyCommandBuilder) command() string {
 	if len(b.options) == 0 {
-		return "  ProxyCommand " + b.baseCommand
+		return b.baseCommand
 	}
-	return fmt.Sprintf("  ProxyCommand %s %s", b.baseCommand, strings.Join(b.options, " "))
+	return fmt.Sprintf("%s %s", b.baseCommand, strings.Join(b.options, " "))
+}
+
+func (b *proxyCommandBuilder) build() string {
+	return "  ProxyCommand " + b.command()
 }
 
 // sshConfigBuilder builds SSH config entries.
@@ -183,11 +325,28 @@ func (b *sshConfigBuilder) addSSHOptions(provider string) *sshConfigBuilder {
 	return b
 }
 
+func (b *sshConfigBuilder) addX11(forward, trusted bool) *sshConfigBuilder {
+	if forward {
+		b.lines = append(b.lines, "  ForwardX11 yes")
+		if trusted {
+			b.lines = append(b.lines, "  ForwardX11Trusted yes")
+		}
+	}
+	return b
+}
+
 func (b *sshConfigBuilder) addProxyCommand(proxyCmd string) *sshConfigBuilder {
 	b.lines = append(b.lines, proxyCmd)
 	return b
 }
 
+func (b *sshConfigBuilder) addIdentityFile(identityFile string) *sshConfigBuilder {
+	if identityFile != "" {
+		b.lines = append(b.lines, fmt.Sprintf("  IdentityFile \"%s\"", identityFile))
+	}
+	return b
+}
+
 func (b *sshConfigBuilder) addUser(user, host string) *sshConfigBuilder {
 	b.lines = append(b.lines, "  User "+user, MarkerEndPrefix+host)
 	return b
@@ -200,7 +359,7 @@ func (b *sshConfigBuilder) build() []string {
 // buildProxyCommand creates the ProxyCommand string.
 func buildProxyCommand(execPath string, params addHostParams) string {
 	if params.command != "" {
-		return fmt.Sprintf("  ProxyCommand \"%s\"", params.command)
+		return "  ProxyCommand " + params.command
 	}
 
 	return newProxyCommandBuilder(execPath, params.context, params.user, params.workspace).
@@ -214,7 +373,9 @@ func buildProxyCommand(execPath string, params addHostParams) string {
 func buildSSHConfigLines(params addHostParams, proxyCmd string) []string {
 	return newSSHConfigBuilder(params.host).
 		addSSHOptions(params.provider).
+		addX11(params.x11, params.x11Trusted).
 		addProxyCommand(proxyCmd).
+		addIdentityFile(params.identityFile).
 		addUser(params.user, params.host).
 		build()
 }
@@ -1,8 +1,12 @@
 package ssh
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/skevetter/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -17,19 +21,20 @@ func TestSSHConfigSuite(t *testing.T) {
 
 func (s *SSHConfigTestSuite) TestAddHostSection() {
 	tests := []struct {
-		name       string
-		config     string
-		execPath   string
-		host       string
-		user       string
-		context    string
-		workspace  string
-		workdir    string
-		command    string
-		gpgagent   bool
-		devPodHome string
-		provider   string
-		expected   string
+		name         string
+		config       string
+		execPath     string
+		host         string
+		user         string
+		context      string
+		workspace    string
+		workdir      string
+		command      string
+		gpgagent     bool
+		devPodHome   string
+		provider     string
+		identityFile string
+		expected     string
 	}{
 		{
 			name:       "Basic host addition",
@@ -150,6 +155,27 @@ Host testhost
   HostKeyAlgorithms rsa-sha2-256,rsa-sha2-512,ssh-rsa
   ProxyCommand "/path/to/exec" ssh --stdio --context testcontext --user testuser testworkspace --gpg-agent-forwarding
   User testuser
+# DevPod End testhost`,
+		},
+		{
+			name:         "Host addition with identity file",
+			config:       "",
+			execPath:     "/path/to/exec",
+			host:         "testhost",
+			user:         "testuser",
+			context:      "testcontext",
+			workspace:    "testworkspace",
+			identityFile: "/path/to/id_devpod_ed25519",
+			expected: `# DevPod Start testhost
+Host testhost
+  ForwardAgent yes
+  LogLevel error
+  StrictHostKeyChecking no
+  UserKnownHostsFile /dev/null
+  HostKeyAlgorithms rsa-sha2-256,rsa-sha2-512,ssh-rsa
+  ProxyCommand "/path/to/exec" ssh --stdio --context testcontext --user testuser testworkspace
+  IdentityFile "/path/to/id_devpod_ed25519"
+  User testuser
 # DevPod End testhost`,
 		},
 		{
@@ -172,7 +198,7 @@ Host testhost
   StrictHostKeyChecking no
   UserKnownHostsFile /dev/null
   HostKeyAlgorithms rsa-sha2-256,rsa-sha2-512,ssh-rsa
-  ProxyCommand "ssh -W %h:%p bastion"
+  ProxyCommand ssh -W %h:%p bastion
   User testuser
 # DevPod End testhost`,
 		},
@@ -294,16 +320,17 @@ Host testhost
 	for _, tt := range tests {
 		s.Run(tt.name, func() {
 			result, err := addHostSection(tt.config, tt.execPath, addHostParams{
-				path:       "",
-				host:       tt.host,
-				user:       tt.user,
-				context:    tt.context,
-				workspace:  tt.workspace,
-				workdir:    tt.workdir,
-				command:    tt.command,
-				gpgagent:   tt.gpgagent,
-				devPodHome: tt.devPodHome,
-				provider:   tt.provider,
+				path:         "",
+				host:         tt.host,
+				user:         tt.user,
+				context:      tt.context,
+				workspace:    tt.workspace,
+				workdir:      tt.workdir,
+				command:      tt.command,
+				gpgagent:     tt.gpgagent,
+				devPodHome:   tt.devPodHome,
+				provider:     tt.provider,
+				identityFile: tt.identityFile,
 			})
 
 			assert.NoError(s.T(), err)
@@ -313,7 +340,7 @@ Host testhost
 			assert.Contains(s.T(), result, "User "+tt.user)
 
 			if tt.command != "" {
-				assert.Contains(s.T(), result, "ProxyCommand \""+tt.command+"\"")
+				assert.Contains(s.T(), result, "ProxyCommand "+tt.command+"\n")
 			}
 
 			if tt.workdir != "" {
@@ -330,3 +357,88 @@ Host testhost
 		})
 	}
 }
+
+func (s *SSHConfigTestSuite) TestAddHostSectionWithX11() {
+	result, err := addHostSection("", "/path/to/exec", addHostParams{
+		host:       "testhost",
+		user:       "testuser",
+		context:    "testcontext",
+		workspace:  "testworkspace",
+		x11:        true,
+		x11Trusted: true,
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Contains(s.T(), result, "  HostKeyAlgorithms rsa-sha2-256,rsa-sha2-512,ssh-rsa\n"+
+		"  ForwardX11 yes\n"+
+		"  ForwardX11Trusted yes\n"+
+		"  ProxyCommand")
+}
+
+func (s *SSHConfigTestSuite) TestSSHConfigEntry() {
+	result, err := SSHConfigEntry(SSHConfigParams{
+		Context:   "testcontext",
+		Workspace: "testworkspace",
+		User:      "vscode",
+		Workdir:   "/workspaces/test",
+	})
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), strings.HasPrefix(
+		result,
+		"# DevPod Start testworkspace.devpod\nHost testworkspace.devpod\n",
+	))
+	assert.Contains(
+		s.T(),
+		result,
+		"--context testcontext --user vscode testworkspace --workdir \"/workspaces/test\"",
+	)
+	assert.True(s.T(), strings.HasSuffix(result, "  User vscode\n# DevPod End testworkspace.devpod\n"))
+}
+
+func (s *SSHConfigTestSuite) TestUpdateSSHConfigPreservesEntry() {
+	sshConfigPath := filepath.Join(s.T().TempDir(), "config")
+	params := SSHConfigParams{
+		SSHConfigPath: sshConfigPath,
+		Context:       "default",
+		Workspace:     "my-ws",
+		User:          "vscode",
+		Workdir:       "/workspaces/my-ws",
+		Command:       "ssh -W %h:%p bastion",
+		X11Forwarding: true,
+		IdentityFile:  "/keys/id_devpod",
+		Log:           log.Discard,
+	}
+	s.Require().NoError(ConfigureSSHConfig(params))
+
+	defaults := SSHConfigParams{
+		SSHConfigPath: sshConfigPath,
+		Context:       "default",
+		Workspace:     "my-ws",
+		User:          "root",
+		Log:           log.Discard,
+	}
+	s.Require().NoError(UpdateSSHConfig(defaults, func(params *SSHConfigParams) {
+		params.Workdir = "/app"
+	}))
+
+	content, err := os.ReadFile(sshConfigPath)
+	s.Require().NoError(err)
+	s.Contains(string(content), "ProxyCommand ssh -W %h:%p bastion\n")
+	s.Contains(string(content), "ForwardX11 yes")
+	s.Contains(string(content), `IdentityFile "/keys/id_devpod"`)
+	s.Contains(string(content), "User vscode")
+
+	params.Command = ""
+	params.GPGAgent = true
+	s.Require().NoError(ConfigureSSHConfig(params))
+	s.Require().NoError(UpdateSSHConfig(defaults, func(params *SSHConfigParams) {
+		params.IdentityFile = "/keys/id_devpod_new"
+	}))
+
+	content, err = os.ReadFile(sshConfigPath)
+	s.Require().NoError(err)
+	s.Contains(string(content), `--workdir "/workspaces/my-ws" --gpg-agent-forwarding`)
+	s.Contains(string(content), `IdentityFile "/keys/id_devpod_new"`)
+	s.Equal(1, strings.Count(string(content), "Host my-ws.devpod"))
+}
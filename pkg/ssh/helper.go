@@ -83,6 +83,32 @@ func StdioClientFromKeyBytesWithUser(
 	return ssh.NewClient(c, chans, req), nil
 }
 
+// StdioClientWithHostKeyCallback creates a client over stdio that verifies the host key with the given callback.
+// The client authenticates with the private key if keyBytes are given.
+func StdioClientWithHostKeyCallback(
+	keyBytes []byte,
+	reader io.Reader,
+	writer io.WriteCloser,
+	user string,
+	exitOnClose bool,
+	hostKeyCallback ssh.HostKeyCallback,
+) (*ssh.Client, error) {
+	conn := stdio.NewStdioStream(reader, writer, exitOnClose, 0)
+	clientConfig, err := ConfigFromKeyBytes(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	clientConfig.User = user
+	clientConfig.HostKeyCallback = hostKeyCallback
+	c, chans, req, err := ssh.NewClientConn(conn, "stdio", clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.NewClient(c, chans, req), nil
+}
+
 func ConfigFromKeyBytes(keyBytes []byte) (*ssh.ClientConfig, error) {
 	clientConfig := &ssh.ClientConfig{
 		Auth:            []ssh.AuthMethod{},
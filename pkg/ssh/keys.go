@@ -2,6 +2,7 @@ package ssh
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -22,6 +23,9 @@ var (
 	DevPodSSHHostKeyFile    = "id_" + config.BinaryName + "_rsa_host"
 	DevPodSSHPrivateKeyFile = "id_" + config.BinaryName + "_rsa"
 	DevPodSSHPublicKeyFile  = "id_" + config.BinaryName + "_rsa.pub"
+
+	DevPodSSHEd25519PrivateKeyFile = "id_" + config.BinaryName + "_ed25519"
+	DevPodSSHEd25519PublicKeyFile  = "id_" + config.BinaryName + "_ed25519.pub"
 )
 
 var keyLock sync.Mutex
@@ -38,6 +42,20 @@ func rsaKeyGen() (privateKey string, publicKey string, err error) {
 	}, privateKeyRaw)
 }
 
+func ed25519KeyGen() (privateKey string, publicKey string, err error) {
+	_, privateKeyRaw, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generate private key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKeyRaw, "")
+	if err != nil {
+		return "", "", fmt.Errorf("marshal private key: %w", err)
+	}
+
+	return generateKeys(*block, privateKeyRaw)
+}
+
 func generateKeys(
 	block pem.Block,
 	cp crypto.Signer,
@@ -245,3 +263,92 @@ func GetPublicKey(context, workspaceID string) (string, error) {
 
 	return GetPublicKeyBase(workspaceDir)
 }
+
+// ResetWorkspaceKeyPair generates a new Ed25519 key pair for the workspace and overwrites
+// a previously generated one. It returns the path of the private key and the public key.
+func ResetWorkspaceKeyPair(context, workspaceID string) (string, string, error) {
+	workspaceDir, err := provider.GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", "", err
+	}
+
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
+	privateKey, publicKey, err := ed25519KeyGen()
+	if err != nil {
+		return "", "", fmt.Errorf("generate key pair: %w", err)
+	}
+
+	// #nosec G301 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.MkdirAll(workspaceDir, 0o755)
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKeyFile := filepath.Join(workspaceDir, DevPodSSHEd25519PrivateKeyFile)
+	err = os.WriteFile(privateKeyFile, []byte(privateKey), 0o600)
+	if err != nil {
+		return "", "", fmt.Errorf("write private ssh key: %w", err)
+	}
+
+	// #nosec G306 -- TODO Consider using a more secure permission setting and ownership if needed.
+	err = os.WriteFile(filepath.Join(workspaceDir, DevPodSSHEd25519PublicKeyFile), []byte(publicKey), 0o644)
+	if err != nil {
+		return "", "", fmt.Errorf("write public ssh key: %w", err)
+	}
+
+	return privateKeyFile, publicKey, nil
+}
+
+// GetWorkspacePublicKey returns the base64 encoded public key generated by ResetWorkspaceKeyPair
+// or an empty string if the key was never reset.
+func GetWorkspacePublicKey(context, workspaceID string) (string, error) {
+	workspaceDir, err := provider.GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := os.ReadFile(filepath.Join(workspaceDir, DevPodSSHEd25519PublicKeyFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read public ssh key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// GetWorkspacePrivateKeyRaw returns the private key generated by ResetWorkspaceKeyPair or nil if
+// the key was never reset.
+func GetWorkspacePrivateKeyRaw(context, workspaceID string) ([]byte, error) {
+	identityFile := GetWorkspaceIdentityFile(context, workspaceID)
+	if identityFile == "" {
+		return nil, nil
+	}
+
+	out, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("read private ssh key: %w", err)
+	}
+
+	return out, nil
+}
+
+// GetWorkspaceIdentityFile returns the path of the workspace key generated by
+// ResetWorkspaceKeyPair or an empty string if the key was never reset.
+func GetWorkspaceIdentityFile(context, workspaceID string) string {
+	workspaceDir, err := provider.GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return ""
+	}
+
+	privateKeyFile := filepath.Join(workspaceDir, DevPodSSHEd25519PrivateKeyFile)
+	_, err = os.Stat(privateKeyFile)
+	if err != nil {
+		return ""
+	}
+
+	return privateKeyFile
+}
@@ -0,0 +1,185 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DevPodKnownHostsFile is the name of the known hosts file DevPod maintains in its config dir.
+const DevPodKnownHostsFile = "known_hosts"
+
+// ResolveKnownHostsFile returns the known hosts file to use, falling back to
+// DEVPOD_KNOWN_HOSTS_FILE and then to ~/.devpod/known_hosts.
+func ResolveKnownHostsFile(knownHostsFile string) (string, error) {
+	if knownHostsFile != "" {
+		return knownHostsFile, nil
+	} else if envKnownHostsFile := os.Getenv(config.EnvKnownHostsFile); envKnownHostsFile != "" {
+		return envKnownHostsFile, nil
+	}
+
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, DevPodKnownHostsFile), nil
+}
+
+// GetHostPublicKey returns the public key of the host key of the given workspace.
+func GetHostPublicKey(context, workspaceID string) (ssh.PublicKey, error) {
+	hostKey, err := GetHostKey(context, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyBytes, err := base64.StdEncoding.DecodeString(hostKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse host key: %w", err)
+	}
+
+	return signer.PublicKey(), nil
+}
+
+// AddKnownHost writes the key for host into the known hosts file and replaces
+// any existing entries of that host, e.g. after the host key was rotated.
+func AddKnownHost(knownHostsFile, host string, key ssh.PublicKey) error {
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(knownHostsFile), 0o700)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(knownHostsFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read known hosts: %w", err)
+	}
+
+	normalizedHost := knownhosts.Normalize(host)
+	out := &bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if knownHostsLineMatches(line, normalizedHost) {
+			continue
+		}
+
+		out.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read known hosts: %w", err)
+	}
+	out.WriteString(knownhosts.Line([]string{host}, key) + "\n")
+
+	return os.WriteFile(knownHostsFile, out.Bytes(), 0o600)
+}
+
+// RemoveKnownHost removes all entries of host from the known hosts file.
+func RemoveKnownHost(knownHostsFile, host string) error {
+	keyLock.Lock()
+	defer keyLock.Unlock()
+
+	content, err := os.ReadFile(knownHostsFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("read known hosts: %w", err)
+	}
+
+	normalizedHost := knownhosts.Normalize(host)
+	out := &bytes.Buffer{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if knownHostsLineMatches(line, normalizedHost) {
+			continue
+		}
+
+		out.WriteString(line + "\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read known hosts: %w", err)
+	}
+
+	return os.WriteFile(knownHostsFile, out.Bytes(), 0o600)
+}
+
+// KnownHostsCallback returns a host key callback that verifies the key of host against the
+// known hosts file. Keys of unknown hosts are trusted on first use and added to the file.
+func KnownHostsCallback(knownHostsFile, host string) (ssh.HostKeyCallback, error) {
+	_, err := os.Stat(knownHostsFile)
+	if errors.Is(err, os.ErrNotExist) {
+		err = os.MkdirAll(filepath.Dir(knownHostsFile), 0o700)
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(knownHostsFile, nil, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("create known hosts: %w", err)
+		}
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("read known hosts: %w", err)
+	}
+
+	// connections to workspaces are tunneled through stdio, so there is no meaningful
+	// remote address and the host is only identified by its name
+	address := net.JoinHostPort(host, "22")
+	remote := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+	return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+		err := callback(address, remote, key)
+		keyErr := &knownhosts.KeyError{}
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return AddKnownHost(knownHostsFile, host, key)
+		} else if errors.As(err, &keyErr) {
+			return fmt.Errorf(
+				"host key of %s doesn't match the key in %s, run devpod up to refresh it: %w",
+				host,
+				knownHostsFile,
+				err,
+			)
+		}
+
+		return err
+	}, nil
+}
+
+func knownHostsLineMatches(line, normalizedHost string) bool {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+		return false
+	}
+
+	hosts := fields[0]
+	if strings.HasPrefix(hosts, "@") && len(fields) > 2 {
+		hosts = fields[1]
+	}
+	for _, h := range strings.Split(hosts, ",") {
+		if h == normalizedHost {
+			return true
+		}
+	}
+
+	return false
+}
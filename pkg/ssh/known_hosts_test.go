@@ -0,0 +1,90 @@
+package ssh
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestPublicKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return key
+}
+
+func TestAddKnownHostReplacesExistingEntry(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	oldKey := newTestPublicKey(t)
+	newKey := newTestPublicKey(t)
+	otherKey := newTestPublicKey(t)
+
+	require.NoError(t, AddKnownHost(file, "other.devpod", otherKey))
+	require.NoError(t, AddKnownHost(file, "ws.devpod", oldKey))
+	require.NoError(t, AddKnownHost(file, "ws.devpod", newKey))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "other.devpod")
+
+	callback, err := KnownHostsCallback(file, "ws.devpod")
+	require.NoError(t, err)
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+	assert.NoError(t, callback("stdio", addr, newKey))
+	assert.Error(t, callback("stdio", addr, oldKey))
+}
+
+func TestKnownHostsCallbackTrustsOnFirstUse(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "nested", "known_hosts")
+	key := newTestPublicKey(t)
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 22}
+
+	callback, err := KnownHostsCallback(file, "ws.devpod")
+	require.NoError(t, err)
+	require.NoError(t, callback("stdio", addr, key))
+
+	callback, err = KnownHostsCallback(file, "ws.devpod")
+	require.NoError(t, err)
+	assert.NoError(t, callback("stdio", addr, key))
+	assert.Error(t, callback("stdio", addr, newTestPublicKey(t)))
+}
+
+func TestRemoveKnownHost(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "known_hosts")
+	require.NoError(t, RemoveKnownHost(file, "ws.devpod"))
+
+	require.NoError(t, AddKnownHost(file, "other.devpod", newTestPublicKey(t)))
+	require.NoError(t, AddKnownHost(file, "ws.devpod", newTestPublicKey(t)))
+	require.NoError(t, RemoveKnownHost(file, "ws.devpod"))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 1)
+	assert.Contains(t, lines[0], "other.devpod")
+}
+
+func TestResolveKnownHostsFile(t *testing.T) {
+	t.Setenv(config.EnvKnownHostsFile, "/env/known_hosts")
+
+	knownHostsFile, err := ResolveKnownHostsFile("/flag/known_hosts")
+	require.NoError(t, err)
+	assert.Equal(t, "/flag/known_hosts", knownHostsFile)
+
+	knownHostsFile, err = ResolveKnownHostsFile("")
+	require.NoError(t, err)
+	assert.Equal(t, "/env/known_hosts", knownHostsFile)
+}
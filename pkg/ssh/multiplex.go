@@ -0,0 +1,151 @@
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/config"
+)
+
+const (
+	// SocketsDir is the directory below the devpod config dir that holds ControlMaster sockets.
+	SocketsDir = "sockets"
+
+	// ControlPersist keeps the master connection alive after the last session exited.
+	ControlPersist = "60s"
+)
+
+// MultiplexOptions holds the options to build an OpenSSH command line that shares a
+// single ControlMaster connection across sessions.
+type MultiplexOptions struct {
+	ExecPath        string
+	Context         string
+	Workspace       string
+	User            string
+	Workdir         string
+	DevPodHome      string
+	ControlPath     string
+	AgentForwarding bool
+	TTY             bool
+	Command         string
+
+	// KnownHostsFile verifies the workspace host key against the given file if set
+	KnownHostsFile string
+
+	// ProxyCommand replaces the devpod ssh proxy if set, %h and %p are expanded by OpenSSH
+	ProxyCommand string
+
+	// EscapeChar is passed to OpenSSH as -e if set
+	EscapeChar string
+
+	// Compress enables ssh compression via -C
+	Compress bool
+
+	// X11Forwarding forwards the local X11 display via -X
+	X11Forwarding bool
+
+	// X11Trusted forwards the local X11 display as trusted client via -Y
+	X11Trusted bool
+
+	// SendEnv sends the given local env variables via SendEnv
+	SendEnv []string
+
+	// SetEnv sets the given NAME=VALUE env variables in the workspace via SetEnv
+	SetEnv []string
+
+	// KeepAliveInterval makes OpenSSH send keepalive requests via ServerAliveInterval if set
+	KeepAliveInterval time.Duration
+}
+
+// ResolveControlPath returns the ControlMaster socket path for the given workspace. If
+// controlPath is empty the socket is placed under ~/.devpod/sockets/<workspace>.sock.
+func ResolveControlPath(controlPath, workspace string) (string, error) {
+	if controlPath == "" {
+		configDir, err := config.GetConfigDir()
+		if err != nil {
+			return "", err
+		}
+
+		controlPath = filepath.Join(configDir, SocketsDir, workspace+".sock")
+	} else {
+		controlPath = strings.ReplaceAll(controlPath, "%w", workspace)
+	}
+
+	// #nosec G301 -- the socket directory only needs to be accessible by the current user
+	err := os.MkdirAll(filepath.Dir(controlPath), 0o700)
+	if err != nil {
+		return "", fmt.Errorf("create socket dir: %w", err)
+	}
+
+	return controlPath, nil
+}
+
+// MultiplexArgs returns the arguments for the OpenSSH client to connect to the workspace
+// through the devpod ssh proxy while reusing an existing ControlMaster connection.
+func MultiplexArgs(options MultiplexOptions) []string {
+	proxyCommand := newProxyCommandBuilder(
+		options.ExecPath,
+		options.Context,
+		options.User,
+		options.Workspace,
+	).
+		withDevPodHome(options.DevPodHome).
+		withWorkdir(options.Workdir).
+		command()
+	if options.ProxyCommand != "" {
+		proxyCommand = options.ProxyCommand
+	}
+
+	strictHostKeyChecking, userKnownHostsFile := "no", "/dev/null"
+	if options.KnownHostsFile != "" {
+		strictHostKeyChecking, userKnownHostsFile = "accept-new", options.KnownHostsFile
+	}
+
+	args := []string{
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + options.ControlPath,
+		"-o", "ControlPersist=" + ControlPersist,
+		"-o", "StrictHostKeyChecking=" + strictHostKeyChecking,
+		"-o", "UserKnownHostsFile=" + userKnownHostsFile,
+		"-o", "LogLevel=error",
+		"-o", "ProxyCommand=" + proxyCommand,
+		"-l", options.User,
+	}
+	if options.AgentForwarding {
+		args = append(args, "-A")
+	}
+	if options.TTY {
+		args = append(args, "-t")
+	}
+	if options.EscapeChar != "" {
+		args = append(args, "-e", options.EscapeChar)
+	}
+	if options.Compress {
+		args = append(args, "-C")
+	}
+	if options.X11Trusted {
+		args = append(args, "-o", "ForwardX11=yes", "-o", "ForwardX11Trusted=yes", "-Y")
+	} else if options.X11Forwarding {
+		args = append(args, "-o", "ForwardX11=yes", "-X")
+	}
+	for _, envVar := range options.SendEnv {
+		args = append(args, "-o", "SendEnv="+envVar)
+	}
+	for _, envVar := range options.SetEnv {
+		args = append(args, "-o", "SetEnv="+envVar)
+	}
+	if seconds := int(options.KeepAliveInterval.Seconds()); seconds > 0 {
+		args = append(args, "-o", "ServerAliveInterval="+strconv.Itoa(seconds))
+	}
+
+	args = append(args, options.Workspace+config.SSHHostSuffix)
+	if options.Command != "" {
+		args = append(args, options.Command)
+	}
+
+	return args
+}
@@ -0,0 +1,204 @@
+package ssh
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MultiplexTestSuite struct {
+	suite.Suite
+}
+
+func TestMultiplexSuite(t *testing.T) {
+	suite.Run(t, new(MultiplexTestSuite))
+}
+
+func (s *MultiplexTestSuite) TestResolveControlPath() {
+	home := s.T().TempDir()
+	s.T().Setenv("DEVPOD_HOME", home)
+
+	path, err := ResolveControlPath("", "my-ws")
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(home, SocketsDir, "my-ws.sock"), path)
+	s.DirExists(filepath.Join(home, SocketsDir))
+
+	custom := filepath.Join(home, "custom", "%w.ctl")
+	path, err = ResolveControlPath(custom, "my-ws")
+	s.Require().NoError(err)
+	s.Equal(filepath.Join(home, "custom", "my-ws.ctl"), path)
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgs() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:        "/path/to/devpod",
+		Context:         "default",
+		Workspace:       "my-ws",
+		User:            "vscode",
+		Workdir:         "/workspaces/my-ws",
+		ControlPath:     "/tmp/my-ws.sock",
+		AgentForwarding: true,
+		TTY:             true,
+	})
+
+	s.Equal([]string{
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=/tmp/my-ws.sock",
+		"-o", "ControlPersist=60s",
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "LogLevel=error",
+		"-o", `ProxyCommand="/path/to/devpod" ssh --stdio --context default --user vscode my-ws --workdir "/workspaces/my-ws"`,
+		"-l", "vscode",
+		"-A",
+		"-t",
+		"my-ws.devpod",
+	}, args)
+
+	args = MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "root",
+		ControlPath: "/tmp/my-ws.sock",
+		Command:     "echo hello",
+	})
+	s.Equal([]string{"my-ws.devpod", "echo hello"}, args[len(args)-2:])
+	s.NotContains(args, "-A")
+	s.NotContains(args, "-t")
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithKnownHostsFile() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:       "/path/to/devpod",
+		Context:        "default",
+		Workspace:      "my-ws",
+		User:           "vscode",
+		ControlPath:    "none",
+		KnownHostsFile: "/home/user/.devpod/known_hosts",
+	})
+
+	s.Contains(args, "StrictHostKeyChecking=accept-new")
+	s.Contains(args, "UserKnownHostsFile=/home/user/.devpod/known_hosts")
+	s.NotContains(args, "UserKnownHostsFile=/dev/null")
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithProxyCommand() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:     "/path/to/devpod",
+		Context:      "default",
+		Workspace:    "my-ws",
+		User:         "vscode",
+		ControlPath:  "none",
+		ProxyCommand: "ssh -W %h:%p bastion",
+	})
+
+	s.Contains(args, "ProxyCommand=ssh -W %h:%p bastion")
+	s.Contains(args, "ControlPath=none")
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithEscapeChar() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "vscode",
+		ControlPath: "none",
+		EscapeChar:  "none",
+	})
+
+	s.Equal([]string{"-e", "none", "my-ws.devpod"}, args[len(args)-3:])
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithCompress() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "vscode",
+		ControlPath: "none",
+		Compress:    true,
+	})
+
+	s.Equal([]string{"-C", "my-ws.devpod"}, args[len(args)-2:])
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithX11Forwarding() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:      "/path/to/devpod",
+		Context:       "default",
+		Workspace:     "my-ws",
+		User:          "vscode",
+		ControlPath:   "none",
+		X11Forwarding: true,
+	})
+	s.Equal([]string{"-o", "ForwardX11=yes", "-X", "my-ws.devpod"}, args[len(args)-4:])
+
+	args = MultiplexArgs(MultiplexOptions{
+		ExecPath:      "/path/to/devpod",
+		Context:       "default",
+		Workspace:     "my-ws",
+		User:          "vscode",
+		ControlPath:   "none",
+		X11Forwarding: true,
+		X11Trusted:    true,
+	})
+	s.Equal(
+		[]string{"-o", "ForwardX11=yes", "-o", "ForwardX11Trusted=yes", "-Y", "my-ws.devpod"},
+		args[len(args)-6:],
+	)
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithSendEnv() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "vscode",
+		ControlPath: "none",
+		SendEnv:     []string{"LANG", "AWS_PROFILE"},
+	})
+	s.Equal(
+		[]string{"-o", "SendEnv=LANG", "-o", "SendEnv=AWS_PROFILE", "my-ws.devpod"},
+		args[len(args)-5:],
+	)
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithSetEnv() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "vscode",
+		ControlPath: "none",
+		SendEnv:     []string{"LANG"},
+		SetEnv:      []string{"FOO=bar"},
+	})
+	s.Equal(
+		[]string{"-o", "SendEnv=LANG", "-o", "SetEnv=FOO=bar", "my-ws.devpod"},
+		args[len(args)-5:],
+	)
+}
+
+func (s *MultiplexTestSuite) TestMultiplexArgsWithKeepAliveInterval() {
+	args := MultiplexArgs(MultiplexOptions{
+		ExecPath:          "/path/to/devpod",
+		Context:           "default",
+		Workspace:         "my-ws",
+		User:              "vscode",
+		ControlPath:       "none",
+		KeepAliveInterval: 30 * time.Second,
+	})
+	s.Equal([]string{"-o", "ServerAliveInterval=30", "my-ws.devpod"}, args[len(args)-3:])
+
+	args = MultiplexArgs(MultiplexOptions{
+		ExecPath:    "/path/to/devpod",
+		Context:     "default",
+		Workspace:   "my-ws",
+		User:        "vscode",
+		ControlPath: "none",
+	})
+	s.NotContains(args, "ServerAliveInterval=30")
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/devpod/pkg/config"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Algorithms restricts the algorithms the ssh server negotiates. Empty lists use the
+// golang crypto/ssh defaults.
+type Algorithms struct {
+	Ciphers      []string
+	KeyExchanges []string
+}
+
+// ParseAlgorithms parses comma separated cipher and key exchange lists and validates
+// them against the algorithms supported by golang crypto/ssh.
+func ParseAlgorithms(ciphers, keyExchanges string) (Algorithms, error) {
+	algorithms := Algorithms{
+		Ciphers:      splitList(ciphers),
+		KeyExchanges: splitList(keyExchanges),
+	}
+
+	return algorithms, algorithms.Validate()
+}
+
+// Validate returns an error if one of the algorithms is not supported or insecure.
+func (a Algorithms) Validate() error {
+	supported := gossh.SupportedAlgorithms()
+	insecure := gossh.InsecureAlgorithms()
+	err := validateAlgorithms("cipher", a.Ciphers, supported.Ciphers, insecure.Ciphers)
+	if err != nil {
+		return err
+	}
+
+	return validateAlgorithms(
+		"key exchange",
+		a.KeyExchanges,
+		supported.KeyExchanges,
+		insecure.KeyExchanges,
+	)
+}
+
+// Args returns the helper ssh-server flags for the algorithms.
+func (a Algorithms) Args() []string {
+	args := []string{}
+	if len(a.Ciphers) > 0 {
+		args = append(args, "--ciphers", strings.Join(a.Ciphers, ","))
+	}
+	if len(a.KeyExchanges) > 0 {
+		args = append(args, "--key-exchanges", strings.Join(a.KeyExchanges, ","))
+	}
+
+	return args
+}
+
+// Command appends the helper ssh-server flags for the algorithms to the ssh-server command.
+func (a Algorithms) Command(command string) string {
+	if args := a.Args(); len(args) > 0 {
+		return command + " " + shellescape.QuoteCommand(args)
+	}
+
+	return command
+}
+
+func (a Algorithms) serverConfig() *gossh.ServerConfig {
+	return &gossh.ServerConfig{
+		Config: gossh.Config{
+			Ciphers:      a.Ciphers,
+			KeyExchanges: a.KeyExchanges,
+		},
+	}
+}
+
+func validateAlgorithms(kind string, algorithms, supported, insecure []string) error {
+	for _, algorithm := range algorithms {
+		if slices.Contains(insecure, algorithm) {
+			return fmt.Errorf("insecure ssh %s %s is not allowed", kind, algorithm)
+		} else if !slices.Contains(supported, algorithm) {
+			return fmt.Errorf(
+				"unsupported ssh %s %s, choose one of: %s",
+				kind,
+				algorithm,
+				strings.Join(supported, ", "),
+			)
+		}
+	}
+
+	return nil
+}
+
+func splitList(value string) []string {
+	algorithms := []string{}
+	for algorithm := range strings.SplitSeq(value, ",") {
+		algorithm = strings.TrimSpace(algorithm)
+		if algorithm != "" {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+
+	return algorithms
+}
+
+// AlgorithmsFromContext returns the algorithms configured in the DevPod context options.
+func AlgorithmsFromContext(devPodConfig *config.Config) (Algorithms, error) {
+	return ParseAlgorithms(
+		devPodConfig.ContextOption(config.ContextOptionSSHDAllowedCiphers),
+		devPodConfig.ContextOption(config.ContextOptionSSHDAllowedKeyExchanges),
+	)
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlgorithms(t *testing.T) {
+	algorithms, err := ParseAlgorithms(
+		"aes256-gcm@openssh.com, aes128-gcm@openssh.com",
+		"ecdh-sha2-nistp256",
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aes256-gcm@openssh.com", "aes128-gcm@openssh.com"}, algorithms.Ciphers)
+	assert.Equal(t, []string{"ecdh-sha2-nistp256"}, algorithms.KeyExchanges)
+	assert.Equal(t, []string{
+		"--ciphers", "aes256-gcm@openssh.com,aes128-gcm@openssh.com",
+		"--key-exchanges", "ecdh-sha2-nistp256",
+	}, algorithms.Args())
+
+	algorithms, err = ParseAlgorithms("", "")
+	require.NoError(t, err)
+	assert.Empty(t, algorithms.Args())
+
+	assert.Equal(
+		t,
+		"'agent' helper ssh-server --stdio --ciphers aes256-gcm@openssh.com",
+		Algorithms{Ciphers: []string{"aes256-gcm@openssh.com"}}.
+			Command("'agent' helper ssh-server --stdio"),
+	)
+
+	_, err = ParseAlgorithms("aes128-cbc", "")
+	require.ErrorContains(t, err, "insecure ssh cipher aes128-cbc")
+
+	_, err = ParseAlgorithms("", "diffie-hellman-group1-sha1")
+	require.ErrorContains(t, err, "insecure ssh key exchange diffie-hellman-group1-sha1")
+
+	_, err = ParseAlgorithms("blowfish-cbc", "")
+	require.ErrorContains(t, err, "unsupported ssh cipher blowfish-cbc")
+
+	_, err = ParseAlgorithms("", "unknown-kex")
+	require.ErrorContains(t, err, "unsupported ssh key exchange unknown-kex")
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/config"
+)
+
+// TokenEnv is the env variable the token of a nested ssh server is passed in, so it doesn't
+// show up in its command line.
+const TokenEnv = "DEVPOD_SSH_SERVER_TOKEN"
+
+// internalEnv are the variables DevPod passes to nested ssh servers. They are accepted
+// regardless of the configured patterns.
+var internalEnv = []string{TokenEnv, config.EnvDefaultShell}
+
+// ParseAcceptEnv splits a comma separated list of env variable patterns. The patterns follow
+// the OpenSSH AcceptEnv syntax, '*' and '?' are wildcards.
+func ParseAcceptEnv(value string) []string {
+	return splitList(value)
+}
+
+// AcceptEnvFromContext returns the env variable patterns configured in the DevPod context
+// options.
+func AcceptEnvFromContext(devPodConfig *config.Config) []string {
+	return ParseAcceptEnv(devPodConfig.ContextOption(config.ContextOptionSSHAcceptEnvPattern))
+}
+
+// AcceptEnvArgs returns the helper ssh-server flags for the patterns. Nothing is returned if
+// all variables are accepted, which is the default of the server.
+func AcceptEnvArgs(patterns []string) []string {
+	if len(patterns) == 0 || (len(patterns) == 1 && patterns[0] == "*") {
+		return nil
+	}
+
+	return []string{"--accept-env", strings.Join(patterns, ",")}
+}
+
+// filterEnv returns the variables of env whose name matches one of the patterns or is an
+// internal variable. Without patterns all variables are accepted.
+func filterEnv(env []string, patterns []string) []string {
+	if len(patterns) == 0 {
+		return env
+	}
+
+	accepted := []string{}
+	for _, variable := range env {
+		name, _, _ := strings.Cut(variable, "=")
+		if slices.Contains(internalEnv, name) {
+			accepted = append(accepted, variable)
+			continue
+		}
+		for _, pattern := range patterns {
+			matched, err := path.Match(pattern, name)
+			if err == nil && matched {
+				accepted = append(accepted, variable)
+				break
+			}
+		}
+	}
+
+	return accepted
+}
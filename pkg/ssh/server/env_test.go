@@ -0,0 +1,40 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterEnv(t *testing.T) {
+	env := []string{"LANG=C.UTF-8", "LC_ALL=C", "AWS_PROFILE=dev", "SECRET=abc"}
+
+	assert.Equal(t, env, filterEnv(env, nil))
+	assert.Equal(t, env, filterEnv(env, ParseAcceptEnv("*")))
+	assert.Equal(
+		t,
+		[]string{"LANG=C.UTF-8", "LC_ALL=C", "AWS_PROFILE=dev"},
+		filterEnv(env, ParseAcceptEnv("LANG, LC_*,AWS_PROFILE")),
+	)
+	assert.Empty(t, filterEnv(env, ParseAcceptEnv("GIT_?")))
+	assert.Equal(
+		t,
+		[]string{TokenEnv + "=abc", config.EnvDefaultShell + "=zsh"},
+		filterEnv(
+			append(env, TokenEnv+"=abc", config.EnvDefaultShell+"=zsh"),
+			ParseAcceptEnv("GIT_?"),
+		),
+	)
+}
+
+func TestAcceptEnvArgs(t *testing.T) {
+	assert.Empty(t, AcceptEnvArgs(nil))
+	assert.Empty(t, AcceptEnvArgs(ParseAcceptEnv("*")))
+	assert.Equal(
+		t,
+		[]string{"--accept-env", "LANG,LC_*"},
+		AcceptEnvArgs(ParseAcceptEnv("LANG, LC_*")),
+	)
+}
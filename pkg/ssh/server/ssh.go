@@ -10,6 +10,7 @@ import (
 	"github.com/skevetter/devpod/pkg/shell"
 	"github.com/skevetter/log"
 	"github.com/skevetter/ssh"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 const (
@@ -27,6 +28,7 @@ type server struct {
 	shell       []string
 	workdir     string
 	reuseSock   string
+	acceptEnv   []string
 	sshServer   ssh.Server
 	log         log.Logger
 }
@@ -37,6 +39,8 @@ func NewServer(
 	keys []ssh.PublicKey,
 	workdir string,
 	reuseSock string,
+	algorithms Algorithms,
+	acceptEnv []string,
 	log log.Logger,
 ) (Server, error) {
 	sh, err := shell.GetShell("")
@@ -55,6 +59,7 @@ func NewServer(
 		shell:       sh,
 		workdir:     workdir,
 		reuseSock:   reuseSock,
+		acceptEnv:   acceptEnv,
 		log:         log,
 		currentUser: currentUser.Username,
 		sshServer: ssh.Server{
@@ -67,6 +72,10 @@ func NewServer(
 				log.Debugf("attempt to bind %s:%d - %s", host, port, "granted")
 				return true
 			},
+			X11ForwardingCallback: func(ctx ssh.Context, x11 ssh.X11) bool {
+				log.Debugf("accepted x11 forwarding for screen %d", x11.ScreenNumber)
+				return true
+			},
 			ReverseUnixForwardingCallback: func(ctx ssh.Context, socketPath string) bool {
 				log.Debugf("attempt to bind socket %s", socketPath)
 
@@ -111,6 +120,12 @@ func NewServer(
 		}
 	}
 
+	if len(algorithms.Ciphers) > 0 || len(algorithms.KeyExchanges) > 0 {
+		server.sshServer.ServerConfigCallback = func(ctx ssh.Context) *gossh.ServerConfig {
+			return algorithms.serverConfig()
+		}
+	}
+
 	if len(hostKey) > 0 {
 		err = server.sshServer.SetOption(ssh.HostKeyPEM(hostKey))
 		if err != nil {
@@ -141,6 +156,17 @@ func (s *server) handler(sess ssh.Session) {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", "SSH_AUTH_SOCK", l.Addr().String()))
 	}
 
+	if x11, ok := sess.X11(); ok {
+		env, cleanup, err := setupX11Forwarding(sess, x11)
+		if err != nil {
+			exitWithError(sess, err, s.log)
+			return
+		}
+		defer cleanup()
+
+		cmd.Env = append(cmd.Env, env...)
+	}
+
 	// start shell session
 	if isPty {
 		err = execPTY(ptyExecParams{
@@ -200,7 +226,7 @@ func (s *server) getCommand(sess ssh.Session, isPty bool) *exec.Cmd {
 
 	cmd.Dir = findWorkdir(s.workdir, user)
 	cmd.Env = append(cmd.Env, os.Environ()...)
-	cmd.Env = append(cmd.Env, sess.Environ()...)
+	cmd.Env = append(cmd.Env, filterEnv(sess.Environ(), s.acceptEnv)...)
 	return cmd
 }
 
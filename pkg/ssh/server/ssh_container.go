@@ -12,9 +12,15 @@ import (
 	shellpkg "github.com/skevetter/devpod/pkg/shell"
 	"github.com/skevetter/log"
 	"github.com/skevetter/ssh"
+	gossh "golang.org/x/crypto/ssh"
 )
 
-func NewContainerServer(addr string, workdir string, log log.Logger) (Server, error) {
+func NewContainerServer(
+	addr string,
+	workdir string,
+	algorithms Algorithms,
+	log log.Logger,
+) (Server, error) {
 	forwardHandler := &ssh.ForwardedTCPHandler{}
 	forwardedUnixHandler := &ssh.ForwardedUnixHandler{}
 	server := &containerServer{
@@ -61,6 +67,12 @@ func NewContainerServer(addr string, workdir string, log log.Logger) (Server, er
 		},
 	}
 
+	if len(algorithms.Ciphers) > 0 || len(algorithms.KeyExchanges) > 0 {
+		server.sshServer.ServerConfigCallback = func(ctx ssh.Context) *gossh.ServerConfig {
+			return algorithms.serverConfig()
+		}
+	}
+
 	server.sshServer.Handler = server.handler
 	return server, nil
 }
@@ -128,8 +140,12 @@ func (s *containerServer) getCommand(sess ssh.Session, isPty bool) (*exec.Cmd, e
 	var cmd *exec.Cmd
 	user := sess.User()
 
-	// get login shell for user
-	shell, err := shellpkg.GetShell(user)
+	// get login shell for user, the configured shell is only used for interactive sessions
+	getShell := shellpkg.GetShell
+	if len(sess.RawCommand()) == 0 {
+		getShell = shellpkg.GetInteractiveShell
+	}
+	shell, err := getShell(user)
 	if err != nil {
 		return cmd, fmt.Errorf("get shell for user %s: %w", user, err)
 	}
@@ -0,0 +1,69 @@
+//go:build !windows
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+
+	"github.com/skevetter/ssh"
+)
+
+// setupX11Forwarding starts proxying X11 connections of the session to the client display and
+// returns the DISPLAY and XAUTHORITY environment variables for the session command.
+func setupX11Forwarding(sess ssh.Session, x11 ssh.X11) ([]string, func(), error) {
+	listener, xauthFile, err := ssh.NewX11Forwarder(x11)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new x11 forwarder: %w", err)
+	}
+	_ = xauthFile.Close()
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		_ = listener.Close()
+		_ = os.Remove(xauthFile.Name())
+		return nil, nil, err
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil {
+		_ = listener.Close()
+		_ = os.Remove(xauthFile.Name())
+		return nil, nil, err
+	}
+
+	// the session command may run as a different user that needs to read the xauthority file
+	chownToUser(xauthFile.Name(), sess.User())
+
+	go ssh.ForwardX11Connections(listener, xauthFile, sess)
+	display := fmt.Sprintf(
+		"%s:%d.%d",
+		ssh.X11DisplayHost,
+		portNumber-ssh.X11DisplayBasePort,
+		x11.ScreenNumber,
+	)
+	return []string{
+		"DISPLAY=" + display,
+		"XAUTHORITY=" + xauthFile.Name(),
+	}, func() { _ = listener.Close() }, nil
+}
+
+func chownToUser(path, userName string) {
+	sessionUser, err := user.Lookup(userName)
+	if err != nil {
+		return
+	}
+
+	uid, err := strconv.Atoi(sessionUser.Uid)
+	if err != nil {
+		return
+	}
+	gid, err := strconv.Atoi(sessionUser.Gid)
+	if err != nil {
+		return
+	}
+
+	_ = os.Chown(path, uid, gid)
+}
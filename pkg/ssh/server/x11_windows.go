@@ -0,0 +1,13 @@
+//go:build windows
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/skevetter/ssh"
+)
+
+func setupX11Forwarding(sess ssh.Session, x11 ssh.X11) ([]string, func(), error) {
+	return nil, nil, fmt.Errorf("x11 forwarding is not supported on windows")
+}
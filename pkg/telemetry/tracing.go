@@ -0,0 +1,268 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/skevetter/devpod/pkg/version"
+	"github.com/skevetter/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// EnvOTLPEndpoint is the standard OpenTelemetry variable for the OTLP endpoint. Tracing
+	// is disabled if it is not set.
+	EnvOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// WorkspaceTraceFile is the file within a workspace folder that holds the spans of the
+	// last traced up.
+	WorkspaceTraceFile = "trace.json"
+
+	tracerName      = "github.com/skevetter/devpod"
+	shutdownTimeout = 5 * time.Second
+)
+
+// Span names of devpod up.
+const (
+	SpanUp              = "devpod.up"
+	SpanAgentInject     = "devpod.up.agent_inject"
+	SpanCloneRepository = "devpod.up.clone_repository"
+	SpanBuildImage      = "devpod.up.build_image"
+	SpanInstallFeatures = "devpod.up.install_features"
+	SpanLifecycleHooks  = "devpod.up.lifecycle_hooks"
+)
+
+// TraceSpan is a span of the last traced up of a workspace.
+type TraceSpan struct {
+	TraceID      string    `json:"traceId"`
+	SpanID       string    `json:"spanId"`
+	ParentSpanID string    `json:"parentSpanId,omitempty"`
+	Name         string    `json:"name"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Error        string    `json:"error,omitempty"`
+}
+
+var (
+	tracerProvider *sdktrace.TracerProvider
+	traceClient    *recordingClient
+)
+
+// StartTracing sets up the OTLP exporter if OTEL_EXPORTER_OTLP_ENDPOINT is set. The exporter
+// is configured by the standard OTEL_EXPORTER_OTLP_* variables and also exports the spans the
+// agents send back through their tunnel. The returned function flushes the spans and must be
+// called before the process exits. Without an endpoint all spans are no-ops.
+func StartTracing(ctx context.Context, log log.Logger) func() {
+	if os.Getenv(EnvOTLPEndpoint) == "" {
+		return func() {}
+	}
+
+	client := &recordingClient{Client: otlptracegrpc.NewClient()}
+	exporter, err := otlptrace.New(ctx, client)
+	if err != nil {
+		log.Warnf("create OTLP trace exporter: %v", err)
+		return func() {}
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(newResource()),
+	)
+	traceClient = client
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Warnf("export traces: %v", err)
+	}))
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		err := tracerProvider.Shutdown(shutdownCtx)
+		if err != nil {
+			log.Warnf("flush traces: %v", err)
+		}
+	}
+}
+
+// NewTracingOptions returns the options to continue the trace of ctx in the agent or nil if
+// tracing is disabled.
+func NewTracingOptions(ctx context.Context) *provider.TracingOptions {
+	if traceClient == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return &provider.TracingOptions{Carrier: carrier}
+}
+
+// UploadTraces exports the spans an agent sent through its tunnel to the OTLP endpoint. It
+// does nothing if tracing is disabled.
+func UploadTraces(ctx context.Context, payload string) error {
+	if traceClient == nil {
+		return nil
+	}
+
+	request := &coltracepb.ExportTraceServiceRequest{}
+	err := protojson.Unmarshal([]byte(payload), request)
+	if err != nil {
+		return err
+	}
+
+	return traceClient.UploadTraces(ctx, request.GetResourceSpans())
+}
+
+// WriteTrace flushes the spans and writes the ones exported so far to the trace file of the
+// workspace. It does nothing if tracing is disabled.
+func WriteTrace(ctx context.Context, devPodContext, workspaceID string) error {
+	if tracerProvider == nil {
+		return nil
+	}
+
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer cancel()
+	err := tracerProvider.ForceFlush(flushCtx)
+	if err != nil {
+		return err
+	}
+
+	traceFile, err := GetWorkspaceTraceFile(devPodContext, workspaceID)
+	if err != nil {
+		return err
+	}
+	out, err := json.Marshal(traceClient.takeSpans())
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(traceFile, out, 0o600)
+}
+
+// GetWorkspaceTraceFile returns the path of the trace file of a workspace.
+func GetWorkspaceTraceFile(context, workspaceID string) (string, error) {
+	workspaceDir, err := provider.GetWorkspaceDir(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(workspaceDir, WorkspaceTraceFile), nil
+}
+
+// LoadWorkspaceTrace reads the spans of a trace file. It returns no spans if the file does
+// not exist.
+func LoadWorkspaceTrace(traceFile string) ([]TraceSpan, error) {
+	out, err := os.ReadFile(traceFile) // #nosec G304: not user input
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	spans := []TraceSpan{}
+	err = json.Unmarshal(out, &spans)
+	if err != nil {
+		return nil, err
+	}
+
+	return spans, nil
+}
+
+// StartSpan starts a span of the global tracer provider.
+func StartSpan(
+	ctx context.Context,
+	name string,
+	attributes ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// EndSpan records the error, if any, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func newResource() *resource.Resource {
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", "devpod"),
+		attribute.String("service.version", version.GetVersion()),
+	))
+	if err != nil {
+		return resource.Default()
+	}
+
+	return res
+}
+
+// recordingClient uploads the spans to the OTLP endpoint and keeps them for the trace file
+// of the workspace.
+type recordingClient struct {
+	otlptrace.Client
+
+	m     sync.Mutex
+	spans []TraceSpan
+}
+
+func (c *recordingClient) UploadTraces(
+	ctx context.Context,
+	resourceSpans []*tracepb.ResourceSpans,
+) error {
+	c.m.Lock()
+	for _, resourceSpan := range resourceSpans {
+		for _, scopeSpans := range resourceSpan.GetScopeSpans() {
+			for _, span := range scopeSpans.GetSpans() {
+				c.spans = append(c.spans, newTraceSpan(span))
+			}
+		}
+	}
+	c.m.Unlock()
+
+	return c.Client.UploadTraces(ctx, resourceSpans)
+}
+
+func (c *recordingClient) takeSpans() []TraceSpan {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	spans := c.spans
+	c.spans = nil
+	return spans
+}
+
+func newTraceSpan(span *tracepb.Span) TraceSpan {
+	traceSpan := TraceSpan{
+		TraceID:      hex.EncodeToString(span.GetTraceId()),
+		SpanID:       hex.EncodeToString(span.GetSpanId()),
+		ParentSpanID: hex.EncodeToString(span.GetParentSpanId()),
+		Name:         span.GetName(),
+		Start:        time.Unix(0, int64(span.GetStartTimeUnixNano())), // #nosec G115
+		End:          time.Unix(0, int64(span.GetEndTimeUnixNano())),   // #nosec G115
+	}
+	if span.GetStatus().GetCode() == tracepb.Status_STATUS_CODE_ERROR {
+		traceSpan.Error = span.GetStatus().GetMessage()
+	}
+
+	return traceSpan
+}
@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// SendTracesFunc sends the encoded spans of the agent to the client.
+type SendTracesFunc func(ctx context.Context, payload string) error
+
+// AgentTracing keeps the spans of the agent in memory until they are sent to the client,
+// which exports them with its own OTLP configuration. This way the agent needs neither the
+// endpoint nor the credentials of the collector and works on remote machines as well.
+type AgentTracing struct {
+	tracerProvider *sdktrace.TracerProvider
+	spans          *spanBuffer
+}
+
+// StartAgentTracing continues the trace of the client in the agent. It returns the context
+// with the parent span and nil if the client doesn't trace.
+func StartAgentTracing(
+	ctx context.Context,
+	options *provider.TracingOptions,
+) (context.Context, *AgentTracing) {
+	if options == nil || len(options.Carrier) == 0 {
+		return ctx, nil
+	}
+
+	spans := &spanBuffer{}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(spans),
+		sdktrace.WithResource(newResource()),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	ctx = propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier(options.Carrier))
+	return ctx, &AgentTracing{tracerProvider: tracerProvider, spans: spans}
+}
+
+// Send sends the spans that ended so far to the client. It needs to be called while the
+// tunnel to the client is still open.
+func (t *AgentTracing) Send(ctx context.Context, send SendTracesFunc) error {
+	if t == nil {
+		return nil
+	}
+
+	spans := t.spans.take()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	return otlptrace.NewUnstarted(sendClient(send)).ExportSpans(ctx, spans)
+}
+
+// Shutdown stops recording spans.
+func (t *AgentTracing) Shutdown(ctx context.Context) {
+	if t == nil {
+		return
+	}
+
+	_ = t.tracerProvider.Shutdown(ctx)
+}
+
+// spanBuffer is a span exporter that keeps the spans until they are taken.
+type spanBuffer struct {
+	m     sync.Mutex
+	spans []sdktrace.ReadOnlySpan
+}
+
+func (b *spanBuffer) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.spans = append(b.spans, spans...)
+	return nil
+}
+
+func (b *spanBuffer) Shutdown(context.Context) error {
+	return nil
+}
+
+func (b *spanBuffer) take() []sdktrace.ReadOnlySpan {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	spans := b.spans
+	b.spans = nil
+	return spans
+}
+
+// sendClient is an OTLP client that hands the spans to a SendTracesFunc.
+type sendClient SendTracesFunc
+
+func (c sendClient) Start(context.Context) error {
+	return nil
+}
+
+func (c sendClient) Stop(context.Context) error {
+	return nil
+}
+
+func (c sendClient) UploadTraces(ctx context.Context, resourceSpans []*tracepb.ResourceSpans) error {
+	payload, err := protojson.Marshal(&coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: resourceSpans,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c(ctx, string(payload))
+}
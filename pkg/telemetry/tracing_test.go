@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+// fakeClient is an OTLP client that keeps the uploaded spans.
+type fakeClient struct {
+	resourceSpans []*tracepb.ResourceSpans
+}
+
+func (c *fakeClient) Start(context.Context) error { return nil }
+
+func (c *fakeClient) Stop(context.Context) error { return nil }
+
+func (c *fakeClient) UploadTraces(_ context.Context, resourceSpans []*tracepb.ResourceSpans) error {
+	c.resourceSpans = append(c.resourceSpans, resourceSpans...)
+	return nil
+}
+
+var _ otlptrace.Client = &fakeClient{}
+
+func TestNewTracingOptionsDisabled(t *testing.T) {
+	traceClient = nil
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "up")
+	defer span.End()
+
+	assert.Nil(t, NewTracingOptions(ctx))
+}
+
+func TestAgentSpansAreExportedByTheClient(t *testing.T) {
+	uploaded := &fakeClient{}
+	traceClient = &recordingClient{Client: uploaded}
+	defer func() { traceClient = nil }()
+
+	assert.Nil(t, NewTracingOptions(context.Background()), "no span in context")
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "up")
+	defer span.End()
+
+	options := NewTracingOptions(ctx)
+	require.NotNil(t, options)
+	assert.Contains(t, options.Carrier, "traceparent")
+
+	agentCtx, tracing := StartAgentTracing(context.Background(), options)
+	require.NotNil(t, tracing)
+	defer tracing.Shutdown(context.Background())
+	_, agentSpan := StartSpan(agentCtx, SpanBuildImage)
+	EndSpan(agentSpan, nil)
+
+	err := tracing.Send(context.Background(), UploadTraces)
+	require.NoError(t, err)
+
+	require.Len(t, uploaded.resourceSpans, 1)
+	spans := traceClient.takeSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, SpanBuildImage, spans[0].Name)
+	assert.Equal(t, span.SpanContext().TraceID().String(), spans[0].TraceID)
+	assert.Equal(t, span.SpanContext().SpanID().String(), spans[0].ParentSpanID)
+}
+
+func TestStartAgentTracingDisabled(t *testing.T) {
+	ctx := context.Background()
+	agentCtx, tracing := StartAgentTracing(ctx, nil)
+	assert.Equal(t, ctx, agentCtx)
+	assert.Nil(t, tracing)
+	assert.NoError(t, tracing.Send(ctx, UploadTraces), "sending without tracing is a no-op")
+
+	agentCtx, tracing = StartAgentTracing(ctx, &provider.TracingOptions{})
+	assert.Equal(t, ctx, agentCtx)
+	assert.Nil(t, tracing)
+}
@@ -0,0 +1,214 @@
+// Package terminalproxy keeps a single ssh connection to a workspace open and multiplexes
+// terminals of local clients onto it as ssh sessions attached to a persistent tmux session.
+package terminalproxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	"github.com/skevetter/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultSession is the name of the tmux session in the workspace.
+	DefaultSession = "devpod"
+
+	defaultTerm = "xterm-256color"
+	defaultRows = 24
+	defaultCols = 80
+
+	maxReconnectDelay = 30 * time.Second
+)
+
+// Request is the first line a local client sends as JSON before the raw terminal stream.
+type Request struct {
+	Term string `json:"term,omitempty"`
+	Rows int    `json:"rows,omitempty"`
+	Cols int    `json:"cols,omitempty"`
+}
+
+// Dialer connects to the workspace. The returned function releases the resources of the
+// connection after the client is closed.
+type Dialer func(ctx context.Context) (*ssh.Client, func(), error)
+
+// Proxy shares one ssh connection between all local terminals and reconnects it if it drops.
+type Proxy struct {
+	Dial    Dialer
+	Session string
+	Log     log.Logger
+
+	m      sync.Mutex
+	client *ssh.Client
+}
+
+// Serve accepts local connections until the context is canceled.
+func (p *Proxy) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	_, err := p.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				p.close()
+				return nil
+			}
+
+			return err
+		}
+
+		go func() {
+			defer func() { _ = conn.Close() }()
+
+			err := p.handle(ctx, conn)
+			if err != nil {
+				p.Log.Debugf("terminal session: %v", err)
+			}
+		}()
+	}
+}
+
+// handle attaches the local connection to the tmux session in the workspace.
+func (p *Proxy) handle(ctx context.Context, conn net.Conn) error {
+	reader := bufio.NewReader(conn)
+	request, err := ReadRequest(reader)
+	if err != nil {
+		_, _ = fmt.Fprintf(conn, "invalid terminal request: %v\r\n", err)
+		return err
+	}
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("open session: %w", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	err = session.RequestPty(request.Term, request.Rows, request.Cols, ssh.TerminalModes{
+		ssh.ECHO: 1,
+	})
+	if err != nil {
+		return fmt.Errorf("request pty: %w", err)
+	}
+
+	session.Stdin = reader
+	session.Stdout = conn
+	session.Stderr = conn
+	return session.Run(SessionCommand(p.Session))
+}
+
+// getClient returns the current connection or establishes a new one.
+func (p *Proxy) getClient(ctx context.Context) (*ssh.Client, error) {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.client != nil {
+		return p.client, nil
+	}
+
+	client, release, err := p.Dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connect to workspace: %w", err)
+	}
+	p.client = client
+	go p.watch(ctx, client, release)
+	return client, nil
+}
+
+// watch reconnects as soon as the connection drops, so the next terminal attaches immediately.
+func (p *Proxy) watch(ctx context.Context, client *ssh.Client, release func()) {
+	_ = client.Wait()
+	release()
+
+	p.m.Lock()
+	if p.client == client {
+		p.client = nil
+	}
+	p.m.Unlock()
+
+	delay := time.Second
+	for ctx.Err() == nil {
+		p.Log.Infof("Connection to workspace lost, reconnecting")
+		_, err := p.getClient(ctx)
+		if err == nil {
+			p.Log.Infof("Reconnected to workspace")
+			return
+		}
+
+		p.Log.Debugf("reconnect: %v", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+func (p *Proxy) close() {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	if p.client != nil {
+		_ = p.client.Close()
+		p.client = nil
+	}
+}
+
+// ReadRequest reads the terminal request line and applies the defaults.
+func ReadRequest(reader *bufio.Reader) (*Request, error) {
+	line, err := reader.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	request := &Request{}
+	err = json.Unmarshal(line, request)
+	if err != nil {
+		return nil, fmt.Errorf("expected a JSON line with term, rows and cols: %w", err)
+	}
+	if request.Term == "" {
+		request.Term = defaultTerm
+	}
+	if request.Rows <= 0 {
+		request.Rows = defaultRows
+	}
+	if request.Cols <= 0 {
+		request.Cols = defaultCols
+	}
+
+	return request, nil
+}
+
+// SessionCommand attaches to the tmux session or creates it. Without tmux a login shell is
+// started, which does not survive a disconnect.
+func SessionCommand(session string) string {
+	if session == "" {
+		session = DefaultSession
+	}
+
+	return fmt.Sprintf(
+		`if command -v tmux >/dev/null 2>&1; then exec tmux new-session -A -s %s; fi; `+
+			`exec "${SHELL:-sh}" -l`,
+		shellescape.Quote(session),
+	)
+}
@@ -0,0 +1,38 @@
+package terminalproxy
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRequest(t *testing.T) {
+	input := `{"term":"screen","rows":50,"cols":120}` + "\nls\n"
+	reader := bufio.NewReader(strings.NewReader(input))
+	request, err := ReadRequest(reader)
+	require.NoError(t, err)
+	assert.Equal(t, &Request{Term: "screen", Rows: 50, Cols: 120}, request)
+
+	rest, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "ls\n", rest, "terminal stream after the request is kept")
+}
+
+func TestReadRequestDefaults(t *testing.T) {
+	request, err := ReadRequest(bufio.NewReader(strings.NewReader("{}\n")))
+	require.NoError(t, err)
+	assert.Equal(t, &Request{Term: defaultTerm, Rows: defaultRows, Cols: defaultCols}, request)
+}
+
+func TestReadRequestInvalid(t *testing.T) {
+	_, err := ReadRequest(bufio.NewReader(strings.NewReader("ls -la\n")))
+	assert.Error(t, err)
+}
+
+func TestSessionCommand(t *testing.T) {
+	assert.Contains(t, SessionCommand(""), "tmux new-session -A -s devpod;")
+	assert.Contains(t, SessionCommand("my session"), "tmux new-session -A -s 'my session';")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/skevetter/devpod/pkg/ssh"
+	glssh "github.com/skevetter/ssh"
 )
 
 type Token struct {
@@ -29,6 +30,23 @@ func GetDevPodToken() (string, error) {
 	return buildToken(hostKey, publicKey)
 }
 
+// GetWorkspaceToken returns a token that holds the host key of the given workspace. Once the
+// workspace key was reset via devpod workspace reset-ssh-key, the token also holds its public
+// key and the ssh server only accepts connections authenticated with it.
+func GetWorkspaceToken(context, workspaceID string) (string, error) {
+	hostKey, err := ssh.GetHostKey(context, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("generate host key: %w", err)
+	}
+
+	publicKey, err := ssh.GetWorkspacePublicKey(context, workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return buildToken(hostKey, publicKey)
+}
+
 func buildToken(hostKey string, publicKey string) (string, error) {
 	out, err := json.Marshal(&Token{
 		HostKey:        hostKey,
@@ -55,3 +73,28 @@ func ParseToken(token string) (*Token, error) {
 
 	return t, nil
 }
+
+// PublicKeys returns the authorized public keys of the token.
+func (t *Token) PublicKeys() ([]glssh.PublicKey, error) {
+	if t.AuthorizedKeys == "" {
+		return nil, nil
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(t.AuthorizedKeys)
+	if err != nil {
+		return nil, fmt.Errorf("seems like the provided encoded string is not base64 encoded")
+	}
+
+	var keys []glssh.PublicKey
+	for len(keyBytes) > 0 {
+		key, _, _, rest, err := glssh.ParseAuthorizedKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse authorized key: %w", err)
+		}
+
+		keys = append(keys, key)
+		keyBytes = rest
+	}
+
+	return keys, nil
+}
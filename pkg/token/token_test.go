@@ -0,0 +1,80 @@
+package token
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	helperssh "github.com/skevetter/devpod/pkg/ssh/server"
+	"github.com/skevetter/log"
+	glssh "github.com/skevetter/ssh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestWorkspaceTokenRejectsOldKeyAfterReset(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	keys := workspaceTokenKeys(t)
+	assert.Empty(t, keys, "no key is required before the first reset")
+
+	oldIdentityFile, _, err := devssh.ResetWorkspaceKeyPair("default", "my-workspace")
+	require.NoError(t, err)
+	oldKey, err := os.ReadFile(oldIdentityFile)
+	require.NoError(t, err)
+
+	newIdentityFile, _, err := devssh.ResetWorkspaceKeyPair("default", "my-workspace")
+	require.NoError(t, err)
+	newKey, err := os.ReadFile(newIdentityFile)
+	require.NoError(t, err)
+
+	keys = workspaceTokenKeys(t)
+	require.Len(t, keys, 1)
+
+	server, err := helperssh.NewServer(
+		"127.0.0.1:0",
+		nil,
+		keys,
+		"",
+		"",
+		helperssh.Algorithms{},
+		nil,
+		log.Discard,
+	)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	go func() { _ = server.Serve(listener) }()
+
+	assert.Error(t, dial(t, listener.Addr().String(), oldKey), "old key must be rejected")
+	assert.NoError(t, dial(t, listener.Addr().String(), newKey))
+}
+
+func workspaceTokenKeys(t *testing.T) []glssh.PublicKey {
+	t.Helper()
+
+	workspaceToken, err := GetWorkspaceToken("default", "my-workspace")
+	require.NoError(t, err)
+	parsedToken, err := ParseToken(workspaceToken)
+	require.NoError(t, err)
+	keys, err := parsedToken.PublicKeys()
+	require.NoError(t, err)
+	return keys
+}
+
+func dial(t *testing.T, addr string, keyBytes []byte) error {
+	t.Helper()
+
+	clientConfig, err := devssh.ConfigFromKeyBytes(keyBytes)
+	require.NoError(t, err)
+	client, err := gossh.Dial("tcp", addr, clientConfig)
+	if err != nil {
+		return err
+	}
+
+	return client.Close()
+}
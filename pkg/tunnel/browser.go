@@ -10,6 +10,7 @@ import (
 	"github.com/sirupsen/logrus"
 	client2 "github.com/skevetter/devpod/pkg/client"
 	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/provider"
 	devssh "github.com/skevetter/devpod/pkg/ssh"
 	"github.com/skevetter/log"
 	"golang.org/x/crypto/ssh"
@@ -43,6 +44,18 @@ func StartBrowserTunnel(p BrowserTunnelParams) error {
 		}()
 	}
 
+	workspace := p.Client.WorkspaceConfig()
+	err := provider.SaveWorkspaceIDEURL(workspace.Context, workspace.ID, p.TargetURL)
+	if err != nil {
+		p.Logger.Debugf("Error saving ide url: %v", err)
+	}
+	defer func() {
+		err := provider.DeleteWorkspaceIDEURL(workspace.Context, workspace.ID)
+		if err != nil {
+			p.Logger.Debugf("Error deleting ide url: %v", err)
+		}
+	}()
+
 	if p.DaemonStartFunc != nil {
 		return p.DaemonStartFunc(p.Ctx)
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/skevetter/devpod/pkg/config"
 	"github.com/skevetter/devpod/pkg/provider"
 	devssh "github.com/skevetter/devpod/pkg/ssh"
+	sshServer "github.com/skevetter/devpod/pkg/ssh/server"
 	"github.com/skevetter/log"
 	"golang.org/x/crypto/ssh"
 )
@@ -74,6 +75,11 @@ func (c *ContainerTunnel) Run(
 	// Get the timeout from the context options
 	timeout := config.ParseTimeOption(cfg, config.ContextOptionAgentInjectTimeout)
 
+	algorithms, err := sshServer.AlgorithmsFromContext(cfg)
+	if err != nil {
+		return err
+	}
+
 	// tunnel to host
 	tunnelChan := make(chan error, 1)
 	go func() {
@@ -81,7 +87,9 @@ func (c *ContainerTunnel) Run(
 		defer func() { _ = writer.Close() }()
 		defer c.log.Debugf("Tunnel to host closed")
 
-		command := fmt.Sprintf("'%s' helper ssh-server --stdio", c.client.AgentPath())
+		command := algorithms.Command(
+			fmt.Sprintf("'%s' helper ssh-server --stdio", c.client.AgentPath()),
+		)
 		if c.log.GetLevel() == logrus.DebugLevel {
 			command += " --debug"
 		}
@@ -130,7 +138,7 @@ func (c *ContainerTunnel) Run(
 		}
 
 		// wait until we are done
-		if err := c.runInContainer(cancelCtx, sshClient, handler, envVars); err != nil {
+		if err := c.runInContainer(cancelCtx, sshClient, handler, envVars, algorithms); err != nil {
 			containerChan <- fmt.Errorf("run in container: %w", err)
 		} else {
 			containerChan <- nil
@@ -208,6 +216,7 @@ func (c *ContainerTunnel) runInContainer(
 	sshClient *ssh.Client,
 	handler Handler,
 	envVars map[string]string,
+	algorithms sshServer.Algorithms,
 ) error {
 	// compress info
 	workspaceInfo, _, err := c.client.AgentInfo(provider.CLIOptions{})
@@ -241,11 +250,11 @@ func (c *ContainerTunnel) runInContainer(
 		c.log.Debugf("Run container tunnel")
 		defer c.log.Debugf("Container tunnel exited")
 
-		command := fmt.Sprintf(
+		command := algorithms.Command(fmt.Sprintf(
 			"'%s' agent container-tunnel --workspace-info '%s'",
 			c.client.AgentPath(),
 			workspaceInfo,
-		)
+		))
 		if c.log.GetLevel() == logrus.DebugLevel {
 			command += " --debug"
 		}
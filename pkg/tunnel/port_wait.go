@@ -0,0 +1,66 @@
+package tunnel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"al.essio.dev/pkg/shellescape"
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
+	devssh "github.com/skevetter/devpod/pkg/ssh"
+	"github.com/skevetter/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const portProcessPollInterval = 2 * time.Second
+
+// waitForPortProcess blocks until the waitFor process of the port attribute runs in the container
+// or the wait timeout is reached. The port is forwarded in both cases.
+func waitForPortProcess(
+	ctx context.Context,
+	containerClient *ssh.Client,
+	port string,
+	attribute config2.PortAttribute,
+	log log.Logger,
+) {
+	timeout := attribute.GetWaitTimeout()
+	log.Debugf("waiting up to %s for process %s before forwarding port %s", timeout, attribute.WaitFor, port)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	command := processRunningCommand(attribute.WaitFor)
+	for {
+		err := devssh.Run(timeoutCtx, devssh.RunOptions{
+			Client:  containerClient,
+			Command: command,
+			Stdout:  io.Discard,
+			Stderr:  io.Discard,
+		})
+		if err == nil {
+			log.Debugf("process %s is running, forwarding port %s", attribute.WaitFor, port)
+			return
+		}
+
+		select {
+		case <-timeoutCtx.Done():
+			if ctx.Err() == nil {
+				log.Warnf(
+					"process %s didn't start within %s, forwarding port %s anyway",
+					attribute.WaitFor,
+					timeout,
+					port,
+				)
+			}
+			return
+		case <-time.After(portProcessPollInterval):
+		}
+	}
+}
+
+// processRunningCommand succeeds if a process matching the pattern runs. The grep processes
+// and the shell running this command are filtered out as their command line contains "grep".
+func processRunningCommand(pattern string) string {
+	return fmt.Sprintf("ps aux | grep -v grep | grep -q -- %s", shellescape.Quote(pattern))
+}
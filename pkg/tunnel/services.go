@@ -347,7 +347,15 @@ func forwardConfigPorts(ctx context.Context, p portForwardParams, result *config
 		}
 
 		// Forward port asynchronously to avoid blocking
+		attribute, hasAttribute := result.MergedConfig.PortsAttributes[port]
+		if !hasAttribute {
+			attribute = result.MergedConfig.PortsAttributes[strconv.FormatInt(portNumber, 10)]
+		}
 		go func(port string) {
+			if attribute.WaitFor != "" {
+				waitForPortProcess(ctx, p.containerClient, port, attribute, p.log)
+			}
+
 			p.log.Debugf("forward port %s", port)
 			if err := devssh.PortForward(
 				ctx,
@@ -3,7 +3,9 @@ package tunnel
 import (
 	"encoding/base64"
 	"testing"
+	"time"
 
+	config2 "github.com/skevetter/devpod/pkg/devcontainer/config"
 	"github.com/skevetter/log"
 	"github.com/stretchr/testify/assert"
 )
@@ -66,3 +68,13 @@ func TestBuildCredentialsCommand_NoSigningKey(t *testing.T) {
 
 	assert.NotContains(t, command, "--git-user-signing-key")
 }
+
+func TestProcessRunningCommand(t *testing.T) {
+	assert.Equal(t, "ps aux | grep -v grep | grep -q -- 'npm run dev'", processRunningCommand("npm run dev"))
+}
+
+func TestPortAttributeWaitTimeout(t *testing.T) {
+	assert.Equal(t, config2.DefaultPortWaitTimeout, config2.PortAttribute{}.GetWaitTimeout())
+	assert.Equal(t, config2.DefaultPortWaitTimeout, config2.PortAttribute{WaitTimeout: "soon"}.GetWaitTimeout())
+	assert.Equal(t, 2*time.Minute, config2.PortAttribute{WaitTimeout: "2m"}.GetWaitTimeout())
+}
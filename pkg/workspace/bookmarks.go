@@ -0,0 +1,123 @@
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/skevetter/devpod/pkg/file"
+)
+
+// BookmarksFile is the file within the DevPod config dir that holds the workspace aliases.
+const BookmarksFile = "bookmarks.json"
+
+// Bookmark points an alias to a workspace of a context.
+type Bookmark struct {
+	WorkspaceID string `json:"workspaceId"`
+	Context     string `json:"context"`
+}
+
+func getBookmarksPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, BookmarksFile), nil
+}
+
+// LoadBookmarks returns all bookmarks by alias.
+func LoadBookmarks() (map[string]Bookmark, error) {
+	bookmarksPath, err := getBookmarksPath()
+	if err != nil {
+		return nil, err
+	}
+
+	// #nosec G304 -- path is built from the config directory
+	payload, err := os.ReadFile(bookmarksPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[string]Bookmark{}, nil
+		}
+		return nil, fmt.Errorf("read bookmarks: %w", err)
+	}
+
+	bookmarks := map[string]Bookmark{}
+	err = json.Unmarshal(payload, &bookmarks)
+	if err != nil {
+		return nil, fmt.Errorf("parse bookmarks %s: %w", bookmarksPath, err)
+	}
+
+	return bookmarks, nil
+}
+
+// SaveBookmarks overwrites the bookmarks file.
+func SaveBookmarks(bookmarks map[string]Bookmark) error {
+	bookmarksPath, err := getBookmarksPath()
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(bookmarksPath), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(bookmarksPath, payload, 0o600)
+}
+
+// ValidateBookmarkAlias checks that the alias can be typed as a single workspace argument and
+// doesn't look like a path.
+func ValidateBookmarkAlias(alias string) error {
+	if alias == "" || strings.ContainsAny(alias, " \t/\\:") {
+		return fmt.Errorf(
+			"invalid alias %q, aliases must not be empty or contain spaces, slashes or colons",
+			alias,
+		)
+	} else if alias == "." || alias == ".." || strings.HasPrefix(alias, "~") {
+		return fmt.Errorf("invalid alias %q, aliases must not look like a path", alias)
+	}
+
+	return nil
+}
+
+// resolveBookmark returns the workspace id the alias points to in the given context or an empty string.
+func resolveBookmark(contextName, alias string) string {
+	bookmarks, err := LoadBookmarks()
+	if err != nil {
+		return ""
+	}
+
+	bookmark, ok := bookmarks[alias]
+	if !ok || bookmark.Context != contextName {
+		return ""
+	}
+
+	return bookmark.WorkspaceID
+}
+
+// resolveBookmarkArgs replaces a bookmark alias as first argument with its workspace id. A
+// local path always takes precedence over a bookmark of the same name.
+func resolveBookmarkArgs(devPodConfig *config.Config, args []string) []string {
+	if len(args) == 0 {
+		return args
+	} else if isLocalPath, _ := file.IsLocalDir(args[0]); isLocalPath {
+		return args
+	}
+
+	workspaceID := resolveBookmark(devPodConfig.DefaultContext, args[0])
+	if workspaceID == "" {
+		return args
+	}
+
+	return append([]string{workspaceID}, args[1:]...)
+}
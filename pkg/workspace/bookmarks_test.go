@@ -0,0 +1,50 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+
+	"github.com/skevetter/devpod/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveBookmarkArgs(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+
+	err := SaveBookmarks(map[string]Bookmark{
+		"py": {WorkspaceID: "vscode-remote-try-python", Context: "default"},
+	})
+	require.NoError(t, err)
+
+	devPodConfig := &config.Config{DefaultContext: "default"}
+	assert.Equal(t, []string{"vscode-remote-try-python", "extra"}, resolveBookmarkArgs(devPodConfig, []string{"py", "extra"}))
+	assert.Equal(t, []string{"other"}, resolveBookmarkArgs(devPodConfig, []string{"other"}))
+
+	devPodConfig.DefaultContext = "staging"
+	assert.Equal(t, []string{"py"}, resolveBookmarkArgs(devPodConfig, []string{"py"}))
+}
+
+func TestValidateBookmarkAlias(t *testing.T) {
+	assert.NoError(t, ValidateBookmarkAlias("py"))
+	assert.Error(t, ValidateBookmarkAlias(""))
+	assert.Error(t, ValidateBookmarkAlias("my alias"))
+	assert.Error(t, ValidateBookmarkAlias("./py"))
+	assert.Error(t, ValidateBookmarkAlias("."))
+	assert.Error(t, ValidateBookmarkAlias(".."))
+	assert.Error(t, ValidateBookmarkAlias("~"))
+}
+
+func TestResolveBookmarkArgsPrefersLocalPath(t *testing.T) {
+	t.Setenv(config.EnvHome, t.TempDir())
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.Mkdir("py", 0o755))
+
+	err := SaveBookmarks(map[string]Bookmark{
+		"py": {WorkspaceID: "vscode-remote-try-python", Context: "default"},
+	})
+	require.NoError(t, err)
+
+	devPodConfig := &config.Config{DefaultContext: "default"}
+	assert.Equal(t, []string{"py"}, resolveBookmarkArgs(devPodConfig, []string{"py"}))
+}
@@ -0,0 +1,88 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/skevetter/devpod/pkg/agent"
+	"github.com/skevetter/devpod/pkg/devcontainer"
+	providerpkg "github.com/skevetter/devpod/pkg/provider"
+)
+
+// TempFolder is a leftover temporary folder of a workspace.
+type TempFolder struct {
+	WorkspaceID string
+	Path        string
+	Size        int64
+}
+
+// FindTempFolders returns the leftover .docker-compose folders of the given workspace.
+// Only folders on the local machine are scanned, folders within remote machines are not found.
+func FindTempFolders(contextName, workspaceID string) ([]TempFolder, error) {
+	origins := []string{}
+	workspaceDir, err := providerpkg.GetWorkspaceDir(contextName, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	origins = append(origins, workspaceDir)
+
+	agentWorkspaceDir, err := agent.GetAgentWorkspaceDir("", contextName, workspaceID)
+	if err == nil && agentWorkspaceDir != workspaceDir {
+		origins = append(origins, agentWorkspaceDir)
+	}
+
+	folders := []TempFolder{}
+	for _, origin := range origins {
+		folder := devcontainer.GetDockerComposeFolder(origin)
+		size, err := folderSize(folder)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("scan %s: %w", folder, err)
+		}
+
+		folders = append(folders, TempFolder{
+			WorkspaceID: workspaceID,
+			Path:        folder,
+			Size:        size,
+		})
+	}
+
+	return folders, nil
+}
+
+// RemoveTempFolders removes the given folders.
+func RemoveTempFolders(folders []TempFolder) error {
+	for _, folder := range folders {
+		err := os.RemoveAll(folder.Path)
+		if err != nil {
+			return fmt.Errorf("remove %s: %w", folder.Path, err)
+		}
+	}
+
+	return nil
+}
+
+func folderSize(folder string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(folder, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		} else if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+		return nil
+	})
+
+	return size, err
+}
@@ -35,6 +35,14 @@ func Delete(ctx context.Context, opts DeleteOptions) (string, error) {
 		return handleDeleteLoadError(ctx, opts, err)
 	}
 
+	if workspaceConfig := client.WorkspaceConfig(); workspaceConfig.Pinned && !opts.Force && !opts.ClientDelete.Force {
+		return "", fmt.Errorf(
+			"workspace %s is pinned, unpin it with 'devpod workspace unpin %s' or use --force to delete anyway",
+			workspaceConfig.ID,
+			workspaceConfig.ID,
+		)
+	}
+
 	if id, done, err := deleteImportedWorkspace(client, opts); done {
 		return id, err
 	}
@@ -1,7 +1,6 @@
 package workspace
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -34,6 +33,9 @@ type ProviderParams struct {
 	Raw          []byte
 	Source       *provider.ProviderSource
 	Log          log.Logger
+
+	// VerifyChecksum is the expected sha256 of the provider binary for the current platform
+	VerifyChecksum string
 }
 
 // LoadProviders loads all known providers for the given context.
@@ -411,15 +413,16 @@ func loadProviderEntry(
 }
 
 func installRawProvider(p ProviderParams) (*provider.ProviderConfig, error) {
-	providerConfig, err := provider.ParseProvider(bytes.NewReader(p.Raw))
+	providerConfig, err := provider.ParseAndValidateProvider(p.Raw)
 	if err != nil {
 		return nil, err
 	}
 	return installProvider(ProviderParams{
-		DevPodConfig: p.DevPodConfig,
-		ProviderName: p.ProviderName,
-		Source:       p.Source,
-		Log:          p.Log,
+		DevPodConfig:   p.DevPodConfig,
+		ProviderName:   p.ProviderName,
+		Source:         p.Source,
+		Log:            p.Log,
+		VerifyChecksum: p.VerifyChecksum,
 	}, providerConfig)
 }
 
@@ -467,7 +470,7 @@ func updateProvider(p ProviderParams) (*provider.ProviderConfig, error) {
 }
 
 func parseAndValidateProvider(p ProviderParams) (*provider.ProviderConfig, error) {
-	providerConfig, err := provider.ParseProvider(bytes.NewReader(p.Raw))
+	providerConfig, err := provider.ParseAndValidateProvider(p.Raw)
 	if err != nil {
 		return nil, err
 	}
@@ -517,6 +520,16 @@ func downloadAndSaveProvider(p ProviderParams, providerConfig *provider.Provider
 		return fmt.Errorf("get provider dir: %w", err)
 	}
 
+	err = provider.RequireChecksums(
+		providerConfig.Binaries,
+		p.VerifyChecksum,
+		p.DevPodConfig.ContextOption(config.ContextOptionProviderVerifyChecksum) == config.BoolTrue,
+	)
+	if err != nil {
+		_ = os.RemoveAll(providerDir)
+		return err
+	}
+
 	if _, err := provider.DownloadBinaries(
 		providerConfig.Binaries,
 		binariesDir,
@@ -1,6 +1,7 @@
 package workspace
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -105,6 +106,66 @@ func applyProviderUpdate(
 	return nil
 }
 
+// ProviderVersionCheck is the result of comparing an installed provider with the latest version of its source.
+type ProviderVersionCheck struct {
+	CurrentVersion  string
+	LatestVersion   string
+	UpdateAvailable bool
+	// Skipped is true if the provider is internal or a development version and was not checked
+	Skipped bool
+}
+
+// CheckProviderVersion resolves the source of the provider again and reports if it has a newer version.
+func CheckProviderVersion(
+	devPodConfig *config.Config,
+	providerName string,
+	log log.Logger,
+) (*ProviderVersionCheck, error) {
+	p, err := FindProvider(devPodConfig, providerName, log)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &ProviderVersionCheck{CurrentVersion: p.Config.Version}
+	if shouldSkipProviderUpdate(p.Config.Version == version.DevVersion, p.Config.Source.Internal) {
+		check.Skipped = true
+		return check, nil
+	}
+
+	providerSource, err := ResolveProviderSource(devPodConfig, providerName, log)
+	if err != nil {
+		return nil, err
+	}
+	providerRaw, _, err := ResolveProvider(providerSource, log)
+	if err != nil {
+		return nil, fmt.Errorf("resolve provider: %w", err)
+	}
+	latestProvider, err := provider2.ParseProvider(bytes.NewReader(providerRaw))
+	if err != nil {
+		return nil, fmt.Errorf("parse provider: %w", err)
+	}
+
+	check.LatestVersion = latestProvider.Version
+	check.UpdateAvailable, err = providerVersionIsNewer(latestProvider.Version, p.Config.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+func providerVersionIsNewer(latestVersion, currentVersion string) (bool, error) {
+	latest, err := semver.Parse(strings.TrimPrefix(latestVersion, "v"))
+	if err != nil {
+		return false, fmt.Errorf("parse version %s: %w", latestVersion, err)
+	}
+	current, err := semver.Parse(strings.TrimPrefix(currentVersion, "v"))
+	if err != nil {
+		return false, fmt.Errorf("parse version %s: %w", currentVersion, err)
+	}
+	return latest.GT(current), nil
+}
+
 // GetProInstance returns the ProInstance associated with the given provider name, or nil if not found.
 func GetProInstance(
 	devPodConfig *config.Config,
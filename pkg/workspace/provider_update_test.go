@@ -73,3 +73,20 @@ func TestProviderVersionNeedsUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderVersionIsNewer(t *testing.T) {
+	newer, err := providerVersionIsNewer("v0.2.0", "v0.1.9")
+	assert.NoError(t, err)
+	assert.True(t, newer)
+
+	newer, err = providerVersionIsNewer("0.1.0", "v0.1.0")
+	assert.NoError(t, err)
+	assert.False(t, newer)
+
+	newer, err = providerVersionIsNewer("v0.1.0", "v0.2.0")
+	assert.NoError(t, err)
+	assert.False(t, newer)
+
+	_, err = providerVersionIsNewer("latest", "v0.2.0")
+	assert.Error(t, err)
+}
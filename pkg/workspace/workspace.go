@@ -187,6 +187,7 @@ func Get(ctx context.Context, opts GetOptions) (client.BaseWorkspaceClient, erro
 		return getWorkspaceClient(opts.DevPodConfig, provider, workspace, machine, opts.Log)
 	}
 
+	opts.Args = resolveBookmarkArgs(opts.DevPodConfig, opts.Args)
 	workspace := findWorkspaceByArgs(ctx, opts)
 	if workspace == nil {
 		return nil, fmt.Errorf("workspace not found for args: %v", opts.Args)
@@ -257,6 +258,7 @@ func resolveWorkspace(
 	}
 
 	// check if workspace already exists
+	params.Args = resolveBookmarkArgs(devPodConfig, params.Args)
 	isLocalPath, name := file.IsLocalDir(params.Args[0])
 
 	// convert to id